@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"path/filepath"
 
@@ -44,58 +45,84 @@ Examples:
   gokeenapi add-awg --config config.yaml --conf-file /path/to/wg.conf
 
   # Add WireGuard connection with custom name
-  gokeenapi add-awg --config config.yaml --conf-file /path/to/wg.conf --name MyVPN`,
+  gokeenapi add-awg --config config.yaml --conf-file /path/to/wg.conf --name MyVPN
+
+  # Add WireGuard connection from an AmneziaVPN share link
+  gokeenapi add-awg --config config.yaml --share-link 'vpn://...'`,
 	}
-	var name, confFile string
+	var name, confFile, shareLink string
 	cmd.Flags().StringVar(&confFile, "conf-file", "",
 		`Path to WireGuard configuration file (.conf).
 Must contain valid [Interface] and [Peer] sections.
-This flag is required.`)
+This flag is required unless --share-link is used.`)
+	addShareLinkFlag(cmd, &shareLink)
 	cmd.Flags().StringVar(&name, "name", "",
 		`Custom name for the new WireGuard interface.
 If not specified, Keenetic (Netcraze) will auto-generate a name (e.g., Wireguard0).
 The name will be used as the interface ID for other commands.`)
 
 	cmd.RunE = func(cmd *cobra.Command, args []string) error {
-		if confFile == "" {
-			return fmt.Errorf("conf-file flag is required")
-		}
-		confPath, err := filepath.Abs(confFile)
-		if err != nil {
-			return err
-		}
-		// we must check that wireguard component is installed on the router - otherwise it will fail
-		installed, err := gokeenrestapi.Checks.CheckComponentInstalled("wireguard")
-		if err != nil {
-			return err
-		}
-		if installed == "" {
-			return fmt.Errorf("wireguard component is not installed. Please install 'WireGuard VPN' component in your Keenetic (Netcraze) router first")
+		if confFile == "" && shareLink == "" {
+			return fmt.Errorf("conf-file flag is required (or use --share-link)")
 		}
-		err = gokeenrestapi.Checks.CheckAWGInterfaceExistsFromConfFile(confPath)
+		confFile, description, cleanup, err := resolveAwgConfFile(confFile, shareLink)
 		if err != nil {
 			return err
 		}
-		gokeenlog.InfoSubStepf("Conf-file: %v", color.CyanString("%v", confPath))
-		createdInterface, err := gokeenrestapi.AwgConf.AddInterface(confPath, name)
-		if err != nil {
-			return err
+		defer cleanup()
+		if name == "" {
+			name = description
 		}
-		gokeenlog.InfoSubStepf("ID: %v", color.CyanString(createdInterface.Created))
-		err = gokeenrestapi.AwgConf.ConfigureOrUpdateInterface(confPath, createdInterface.Created)
-		if err != nil {
-			return err
+		// Without a name the router would show the temporary file name of the decoded link
+		if name == "" && shareLink != "" {
+			return errors.New("--name is required: the share link has neither a description nor a host name")
 		}
-		err = gokeenrestapi.Interface.SetGlobalIpInInterface(createdInterface.Created, true)
-		if err != nil {
-			return err
-		}
-		err = gokeenrestapi.Interface.UpInterface(createdInterface.Created)
+		confPath, err := filepath.Abs(confFile)
 		if err != nil {
 			return err
 		}
-		err = gokeenrestapi.Interface.WaitUntilInterfaceIsUp(createdInterface.Created)
+		_, err = addAwgInterface(confPath, name, true)
 		return err
 	}
 	return cmd
 }
+
+// addAwgInterface creates a WireGuard interface from the conf file, configures it and brings it up.
+// Returns the ID of the created interface
+func addAwgInterface(confPath, name string, waitUntilUp bool) (string, error) {
+	// we must check that wireguard component is installed on the router - otherwise it will fail
+	installed, err := gokeenrestapi.Checks.CheckComponentInstalled("wireguard")
+	if err != nil {
+		return "", err
+	}
+	if installed == "" {
+		return "", fmt.Errorf("wireguard component is not installed. Please install 'WireGuard VPN' component in your Keenetic (Netcraze) router first")
+	}
+	err = gokeenrestapi.Checks.CheckAWGInterfaceExistsFromConfFile(confPath)
+	if err != nil {
+		return "", err
+	}
+	gokeenlog.InfoSubStepf("Conf-file: %v", color.CyanString("%v", confPath))
+	createdInterface, err := gokeenrestapi.AwgConf.AddInterface(confPath, name)
+	if err != nil {
+		return "", err
+	}
+	gokeenlog.InfoSubStepf("ID: %v", color.CyanString(createdInterface.Created))
+	err = gokeenrestapi.AwgConf.ConfigureOrUpdateInterface(confPath, createdInterface.Created)
+	if err != nil {
+		return "", err
+	}
+	err = gokeenrestapi.Interface.SetGlobalIpInInterface(createdInterface.Created, true)
+	if err != nil {
+		return "", err
+	}
+	err = gokeenrestapi.Interface.UpInterface(createdInterface.Created)
+	if err != nil {
+		return "", err
+	}
+	if !waitUntilUp {
+		return createdInterface.Created, nil
+	}
+	err = gokeenrestapi.Interface.WaitUntilInterfaceIsUp(createdInterface.Created)
+	return createdInterface.Created, err
+}
@@ -3,6 +3,8 @@ package cmd
 import (
 	"net/http/httptest"
 
+	"github.com/noksa/gokeenapi/pkg/gokeenrestapi"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 )
@@ -43,4 +45,47 @@ var _ = Describe("AddAwg", func() {
 
 		Expect(cmd.RunE(cmd, []string{})).To(HaveOccurred())
 	})
+
+	It("should add the interface from a share link", func() {
+		cmd := newAddAwgCmd()
+		_ = cmd.Flags().Set("share-link", testShareLink("vpn.example.com:40125"))
+
+		Expect(cmd.RunE(cmd, []string{})).To(Succeed())
+
+		conf, _, err := gokeenrestapi.AwgConf.ExportConf("Wireguard1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(conf).To(ContainSubstring("Endpoint = vpn.example.com:40125"))
+		Expect(conf).To(ContainSubstring("Jc = 7"))
+
+		iface, err := gokeenrestapi.Interface.GetInterfaceViaRciShowInterfaces("Wireguard1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(iface.Description).To(Equal("Shared server"))
+	})
+
+	It("should require --name for a share link without a description", func() {
+		cmd := newAddAwgCmd()
+		_ = cmd.Flags().Set("share-link", testShareLinkWithDescription("vpn.example.com:40125", ""))
+
+		err := cmd.RunE(cmd, []string{})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("--name is required"))
+
+		cmd = newAddAwgCmd()
+		_ = cmd.Flags().Set("share-link", testShareLinkWithDescription("vpn.example.com:40125", ""))
+		_ = cmd.Flags().Set("name", "MyVPN")
+		Expect(cmd.RunE(cmd, []string{})).To(Succeed())
+
+		iface, err := gokeenrestapi.Interface.GetInterfaceViaRciShowInterfaces("Wireguard1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(iface.Description).To(Equal("MyVPN"))
+	})
+
+	It("should fail with an invalid share link", func() {
+		cmd := newAddAwgCmd()
+		_ = cmd.Flags().Set("share-link", "vpn://!!!")
+
+		err := cmd.RunE(cmd, []string{})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("invalid share link"))
+	})
 })
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"github.com/noksa/gokeenapi/internal/gokeenlog"
 	"github.com/noksa/gokeenapi/pkg/config"
 	"github.com/noksa/gokeenapi/pkg/gokeenrestapi"
 	"github.com/spf13/cobra"
@@ -31,6 +32,9 @@ Examples:
   # Add all DNS-routing rules from config file
   gokeenapi add-dns-routing --config config.yaml
 
+  # Add rules and remove groups that are no longer in config (see prune-dns-routing)
+  gokeenapi add-dns-routing --config config.yaml --prune --force
+
   # Example config entries:
   # dns:
   #   routes:
@@ -45,8 +49,27 @@ The command automatically validates interface IDs and saves the configuration
 after adding rules.`,
 	}
 
+	var prune bool
+	var prunePrefix string
+	var force bool
+	cmd.Flags().BoolVar(&prune, "prune", false,
+		`After adding, remove router groups that match the prune prefix but are
+no longer defined in config. See 'prune-dns-routing' for details.`)
+	cmd.Flags().StringVar(&prunePrefix, "prune-prefix", "",
+		`Only prune router groups whose name starts with this prefix.
+Overrides 'dns.routes.prunePrefix' from the config file.`)
+	cmd.Flags().BoolVar(&force, "force", false,
+		`Skip confirmation prompt when pruning orphaned DNS-routing rules.`)
+
 	cmd.RunE = func(cmd *cobra.Command, args []string) error {
-		return gokeenrestapi.DnsRouting.AddDnsRoutingGroups(config.Cfg.DNS.Routes.Groups)
+		if err := gokeenrestapi.DnsRouting.AddDnsRoutingGroups(config.Cfg.DNS.Routes.Groups); err != nil {
+			return err
+		}
+		if !prune {
+			return nil
+		}
+		gokeenlog.HorizontalLine()
+		return pruneDnsRouting(prunePrefix, force)
 	}
 
 	return cmd
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/noksa/gokeenapi/internal/gokeenlog"
+	"github.com/noksa/gokeenapi/pkg/gokeenrestapi"
+	"github.com/spf13/cobra"
+)
+
+// shareLinkConfName is the file name of the temporary .conf decoded from a share link
+const shareLinkConfName = "share-link.conf"
+
+// addShareLinkFlag registers the --share-link flag shared by AWG commands
+func addShareLinkFlag(cmd *cobra.Command, shareLink *string) {
+	cmd.Flags().StringVar(shareLink, "share-link", "",
+		`AmneziaVPN share link (vpn://...) or path to a file containing one.
+Can be used instead of --conf-file.`)
+}
+
+// resolveAwgConfFile returns the path of the .conf file for --conf-file or --share-link.
+// A share link is decoded into a temporary .conf file readable by the owner only,
+// the returned cleanup function removes it. Also returns the connection description from the link
+func resolveAwgConfFile(confFile, shareLink string) (string, string, func(), error) {
+	noop := func() {}
+	if confFile != "" && shareLink != "" {
+		return "", "", noop, errors.New("--conf-file and --share-link are mutually exclusive")
+	}
+	if shareLink == "" {
+		return confFile, "", noop, nil
+	}
+
+	link := shareLink
+	if !strings.HasPrefix(link, gokeenrestapi.AwgShareLinkPrefix) {
+		if info, err := os.Stat(link); err == nil && info.Mode().IsRegular() {
+			content, err := os.ReadFile(link)
+			if err != nil {
+				return "", "", noop, err
+			}
+			link = string(content)
+		}
+	}
+
+	conf, description, err := gokeenrestapi.AwgConf.ConfFromShareLink(link)
+	if err != nil {
+		return "", "", noop, err
+	}
+
+	tmpDir, err := os.MkdirTemp("", "gokeenapi-awg-")
+	if err != nil {
+		return "", "", noop, err
+	}
+	cleanup := func() { _ = os.RemoveAll(tmpDir) }
+	confPath := filepath.Join(tmpDir, shareLinkConfName)
+	if err := os.WriteFile(confPath, []byte(conf), 0600); err != nil {
+		cleanup()
+		return "", "", noop, fmt.Errorf("failed to write decoded share link: %w", err)
+	}
+	if description != "" {
+		gokeenlog.InfoSubStepf("Share link: %v", description)
+	}
+	return confPath, description, cleanup, nil
+}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"errors"
 	"strings"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/noksa/gokeenapi/internal/gokeenlog"
@@ -24,6 +25,15 @@ the conf file and applies only the changes.
 Supports standard WireGuard, AmneziaWG 1.0 (Jc, Jmin, Jmax, S1, S2, H1-H4),
 and AWG 2.0 (S3, S4, I1-I5) parameters. ASC fields are optional.
 
+Multiple [Peer] sections are supported. Peers are matched by public key:
+peers on the router that are absent from the conf file are removed.
+
+After the changes are applied, the command waits up to --verify-timeout for the
+interface to reconnect with a fresh handshake. If it does not, the previous
+configuration is restored automatically, so a wrong endpoint or key does not
+cut off remote access. Interfaces that are down or have no peers are not verified.
+Use --verify-timeout 0 to skip the check.
+
 Use --dry-run to preview what would be changed without applying.
 
 Examples:
@@ -31,20 +41,35 @@ Examples:
   gokeenapi update-awg --config config.yaml --conf-file /path/to/updated.conf --interface-id Wireguard0
 
   # Preview changes without applying
-  gokeenapi update-awg --config config.yaml --conf-file /path/to/updated.conf --interface-id Wireguard0 --dry-run`,
+  gokeenapi update-awg --config config.yaml --conf-file /path/to/updated.conf --interface-id Wireguard0 --dry-run
+
+  # Give a slow peer more time to reconnect before rolling back
+  gokeenapi update-awg --config config.yaml --conf-file /path/to/updated.conf --interface-id Wireguard0 --verify-timeout 5m
+
+  # Update from an AmneziaVPN share link stored in a file
+  gokeenapi update-awg --config config.yaml --share-link link.txt --interface-id Wireguard0`,
 	}
-	var confFile, interfaceId string
+	var confFile, interfaceId, shareLink string
 	var dryRun bool
+	var verifyTimeout time.Duration
 	cmd.Flags().StringVar(&confFile, "conf-file", "", "Path to WireGuard configuration file (.conf)")
+	addShareLinkFlag(cmd, &shareLink)
 	cmd.Flags().StringVar(&interfaceId, "interface-id", "", "ID of the existing WireGuard interface to update")
 	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would be changed without applying")
+	cmd.Flags().DurationVar(&verifyTimeout, "verify-timeout", 2*time.Minute,
+		"How long to wait for a new handshake before restoring the previous configuration, 0 disables the check")
 	cmd.RunE = func(cmd *cobra.Command, args []string) error {
-		if confFile == "" {
-			return errors.New("--conf-file flag is required")
+		if confFile == "" && shareLink == "" {
+			return errors.New("--conf-file flag is required (or use --share-link)")
 		}
 		if interfaceId == "" {
 			return errors.New("--interface-id flag is required")
 		}
+		confFile, _, cleanup, err := resolveAwgConfFile(confFile, shareLink)
+		if err != nil {
+			return err
+		}
+		defer cleanup()
 		if dryRun {
 			diff, err := gokeenrestapi.AwgConf.DiffUpdate(confFile, interfaceId)
 			if err != nil {
@@ -58,7 +83,10 @@ Examples:
 			printColoredDiff(diff)
 			return nil
 		}
-		return gokeenrestapi.AwgConf.ConfigureOrUpdateInterface(confFile, interfaceId)
+		if verifyTimeout <= 0 {
+			return gokeenrestapi.AwgConf.ConfigureOrUpdateInterface(confFile, interfaceId)
+		}
+		return gokeenrestapi.AwgConf.UpdateInterfaceWithRollback(confFile, interfaceId, verifyTimeout)
 	}
 	return cmd
 }
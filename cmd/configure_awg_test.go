@@ -1,9 +1,16 @@
 package cmd
 
 import (
+	"bytes"
+	"compress/zlib"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
 	"net/http/httptest"
 	"os"
 
+	"github.com/noksa/gokeenapi/pkg/gokeenrestapi"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 )
@@ -29,6 +36,7 @@ var _ = Describe("ConfigureAwg", func() {
 		Expect(cmd.Flags().Lookup("conf-file")).NotTo(BeNil())
 		Expect(cmd.Flags().Lookup("interface-id")).NotTo(BeNil())
 		Expect(cmd.Flags().Lookup("dry-run")).NotTo(BeNil())
+		Expect(cmd.Flags().Lookup("verify-timeout")).NotTo(BeNil())
 	})
 
 	It("should fail when conf-file is missing", func() {
@@ -81,4 +89,73 @@ PersistentKeepalive = 25`
 		err := cmd.RunE(cmd, []string{})
 		Expect(err).NotTo(HaveOccurred())
 	})
+
+	It("should update the interface from a share link file", func() {
+		linkPath := writeTempFile(GinkgoT().TempDir(), "link.txt", testShareLink("example.org:51820")+"\n")
+
+		cmd := newUpdateAwgCmd()
+		_ = cmd.Flags().Set("share-link", linkPath)
+		_ = cmd.Flags().Set("interface-id", "Wireguard0")
+		Expect(cmd.RunE(cmd, []string{})).To(Succeed())
+
+		conf, _, err := gokeenrestapi.AwgConf.ExportConf("Wireguard0")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(conf).To(ContainSubstring("Endpoint = example.org:51820"))
+		Expect(conf).To(ContainSubstring("Jc = 7"))
+	})
+
+	It("should reject --conf-file together with --share-link", func() {
+		cmd := newUpdateAwgCmd()
+		_ = cmd.Flags().Set("conf-file", "/tmp/test.conf")
+		_ = cmd.Flags().Set("share-link", testShareLink("example.org:51820"))
+		_ = cmd.Flags().Set("interface-id", "Wireguard0")
+
+		err := cmd.RunE(cmd, []string{})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("mutually exclusive"))
+	})
 })
+
+// testShareLink builds an AmneziaVPN vpn:// link for a single-peer AWG config with the given endpoint
+func testShareLink(endpoint string) string {
+	return testShareLinkWithDescription(endpoint, "Shared server")
+}
+
+// testShareLinkWithDescription builds a share link like testShareLink with the given description and no host name
+func testShareLinkWithDescription(endpoint, description string) string {
+	conf := `[Interface]
+PrivateKey = cOFA+3p5IjkzIjkzIjkzIjkzIjkzIjkzIjkzIjkzIjk=
+Address = 10.0.0.2/24
+DNS = $PRIMARY_DNS
+Jc = 7
+Jmin = 50
+Jmax = 1000
+S1 = 86
+S2 = 3
+H1 = 1
+H2 = 2
+H3 = 3
+H4 = 4
+
+[Peer]
+PublicKey = gN65BkIKy1eCE9pP1wdc8ROUunkiVXrBvGAKBEKdOQI=
+Endpoint = ` + endpoint + `
+AllowedIPs = 0.0.0.0/0
+PersistentKeepalive = 25
+`
+	lastConfig, err := json.Marshal(map[string]string{"config": conf})
+	Expect(err).NotTo(HaveOccurred())
+	payload, err := json.Marshal(map[string]any{
+		"containers":       []any{map[string]any{"container": "amnezia-awg", "awg": map[string]any{"last_config": string(lastConfig)}}},
+		"defaultContainer": "amnezia-awg",
+		"description":      description,
+	})
+	Expect(err).NotTo(HaveOccurred())
+
+	var buf bytes.Buffer
+	_ = binary.Write(&buf, binary.BigEndian, uint32(len(payload)))
+	writer := zlib.NewWriter(&buf)
+	_, _ = writer.Write(payload)
+	_ = writer.Close()
+	return gokeenrestapi.AwgShareLinkPrefix + base64.RawURLEncoding.EncodeToString(buf.Bytes())
+}
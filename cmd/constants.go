@@ -5,19 +5,43 @@ const (
 	CmdAddRoutes        = "add-routes"
 	CmdDeleteRoutes     = "delete-routes"
 	CmdShowInterfaces   = "show-interfaces"
+	CmdStatsInterfaces  = "stats-interfaces"
 	CmdAddAwg           = "add-awg"
+	CmdCreateAwg        = "create-awg"
 	CmdUpdateAwg        = "update-awg"
+	CmdExportAwg        = "export-awg"
+	CmdDeleteAwg        = "delete-awg"
+	CmdSyncAwg          = "sync-awg"
+	CmdValidateAwg      = "validate-awg"
+	CmdRotateAwg        = "rotate-awg"
+	CmdMonitorAwg       = "monitor-awg"
+	CmdInterface        = "interface"
 	CmdAddDnsRecords    = "add-dns-records"
 	CmdDeleteDnsRecords = "delete-dns-records"
 	CmdAddDnsRouting    = "add-dns-routing"
 	CmdDeleteDnsRouting = "delete-dns-routing"
+	CmdPruneDnsRouting  = "prune-dns-routing"
+	CmdExportDnsRouting = "export-dns-routing"
+	CmdShowDnsRouting   = "show-dns-routing"
+	CmdShowHosts        = "show-hosts"
 	CmdDeleteKnownHosts = "delete-known-hosts"
+	CmdSyncHosts        = "sync-hosts"
+	CmdSyncPolicies     = "sync-policies"
+	CmdDeletePolicies   = "delete-policies"
 	CmdDeleteAllRoutes  = "delete-all-routes"
 	CmdExec             = "exec"
 	CmdScheduler        = "scheduler"
 	CmdVersion          = "version"
 )
 
+// Subcommands of the interface command
+const (
+	CmdInterfaceUp        = "up"
+	CmdInterfaceDown      = "down"
+	CmdInterfaceSetGlobal = "set-global"
+	CmdInterfaceWait      = "wait"
+)
+
 // Built-in commands that should skip initialization
 const (
 	CmdCompletion = "completion"
@@ -35,14 +59,30 @@ var (
 	AliasesAddRoutes        = []string{"addroutes", "ar"}
 	AliasesDeleteRoutes     = []string{"deleteroutes", "dr"}
 	AliasesShowInterfaces   = []string{"showinterfaces", "si", "showinterface", "show-interface"}
+	AliasesStatsInterfaces  = []string{"statsinterfaces", "stats", "stats-interface"}
 	AliasesAddAwg           = []string{"addawg", "aawg"}
+	AliasesCreateAwg        = []string{"createawg", "cawg"}
 	AliasesUpdateAwg        = []string{"updateawg", "uawg"}
+	AliasesExportAwg        = []string{"exportawg", "eawg"}
+	AliasesDeleteAwg        = []string{"deleteawg", "dawg"}
+	AliasesSyncAwg          = []string{"syncawg", "sawg"}
+	AliasesValidateAwg      = []string{"validateawg", "vawg"}
+	AliasesRotateAwg        = []string{"rotateawg", "rawg"}
+	AliasesMonitorAwg       = []string{"monitorawg", "mawg"}
+	AliasesInterface        = []string{"iface", "if"}
 	AliasesAddDnsRecords    = []string{"adddnsrecords", "adr"}
 	AliasesDeleteDnsRecords = []string{"deletednsrecords", "ddr"}
 	AliasesAddDnsRouting    = []string{"adddnsrouting", "adnsr", "adddnsroutes", "add-dns-routes"}
 	AliasesDeleteDnsRouting = []string{"deletednsrouting", "ddnsr", "deletednsroutes", "delete-dns-routes"}
+	AliasesPruneDnsRouting  = []string{"prunednsrouting", "pdnsr", "prunednsroutes", "prune-dns-routes"}
+	AliasesExportDnsRouting = []string{"exportdnsrouting", "ednsr", "exportdnsroutes", "export-dns-routes"}
+	AliasesShowDnsRouting   = []string{"showdnsrouting", "sdnsr", "showdnsroutes", "show-dns-routes"}
 	AliasesDeleteAllRoutes  = []string{"deleteallroutes", "dar"}
+	AliasesShowHosts        = []string{"showhosts", "hosts"}
 	AliasesDeleteKnownHosts = []string{"deleteknownhosts", "dkh"}
+	AliasesSyncHosts        = []string{"synchosts", "shosts"}
+	AliasesSyncPolicies     = []string{"syncpolicies", "spol"}
+	AliasesDeletePolicies   = []string{"deletepolicies", "dpol"}
 	AliasesExec             = []string{"e"}
 	AliasesScheduler        = []string{"schedule", "sched"}
 )
@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/fatih/color"
+	"github.com/noksa/gokeenapi/internal/gokeenlog"
+	"github.com/noksa/gokeenapi/pkg/gokeenrestapi"
+	"github.com/spf13/cobra"
+)
+
+func newCreateAwgCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     CmdCreateAwg,
+		Aliases: AliasesCreateAwg,
+		Short:   "Create a new WireGuard VPN connection from scratch",
+		Long: `Create a new WireGuard (AWG) VPN connection on your Keenetic (Netcraze) router
+without a .conf file from a VPN provider. Useful for self-hosted servers.
+
+A Curve25519 keypair is generated locally and AmneziaWG obfuscation parameters
+(Jc, Jmin, Jmax, S1, S2, H1-H4 and, with --awg2, S3 and S4) are randomized within
+the protocol constraints. The private key never leaves your machine except for the
+router it is imported into.
+
+Once the interface is created, the matching server-side stanza is printed: the AmneziaWG
+parameters for the server [Interface] section and a [Peer] section with the public key
+of the router. The server does not know the router yet, so the command does not wait for
+a handshake. Add the stanza to the server configuration to complete the tunnel.
+
+Process:
+1. Generates a keypair and AmneziaWG parameters
+2. Creates a new WireGuard interface
+3. Applies the configuration
+4. Enables global IP routing
+5. Brings the interface up
+6. Prints the server-side stanza
+
+Examples:
+  # Create a tunnel to a self-hosted server
+  gokeenapi create-awg --config config.yaml --endpoint vpn.example.com:51820 \
+    --peer-public-key <server public key> --address 10.8.0.2
+
+  # Use AWG 2.0 parameters and a preshared key
+  gokeenapi create-awg --config config.yaml --endpoint vpn.example.com:51820 \
+    --peer-public-key <server public key> --address 10.8.0.2/24 --awg2 --preshared-key`,
+	}
+
+	var opts gokeenrestapi.AwgGenerateOptions
+	var name string
+	cmd.Flags().StringVar(&opts.Endpoint, "endpoint", "",
+		`Server endpoint in host:port format.
+This flag is required.`)
+	cmd.Flags().StringVar(&opts.PeerPublicKey, "peer-public-key", "",
+		`Public key of the server.
+This flag is required.`)
+	cmd.Flags().StringVar(&opts.Address, "address", "",
+		`Tunnel address of the router (e.g., 10.8.0.2 or 10.8.0.2/24).
+This flag is required.`)
+	cmd.Flags().StringSliceVar(&opts.AllowedIPs, "allowed-ips", []string{"0.0.0.0/0"},
+		`Networks routed through the tunnel.`)
+	cmd.Flags().IntVar(&opts.PersistentKeepalive, "keepalive", 25,
+		`Persistent keepalive interval in seconds, 0 disables it.`)
+	cmd.Flags().IntVar(&opts.MTU, "mtu", 0,
+		`Interface MTU. If not specified, the router default is used.`)
+	cmd.Flags().BoolVar(&opts.PresharedKey, "preshared-key", false,
+		`Generate a preshared key for the peer.`)
+	cmd.Flags().BoolVar(&opts.AWG2, "awg2", false,
+		`Generate AWG 2.0 parameters S3 and S4 (requires KeeneticOS 5.1+ on the router and server).`)
+	cmd.Flags().StringVar(&name, "name", "",
+		`Custom name for the new WireGuard interface.
+If not specified, Keenetic (Netcraze) will auto-generate a name (e.g., Wireguard0).
+The name is also used as the peer comment in the server-side stanza.`)
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if opts.Endpoint == "" {
+			return errors.New("--endpoint flag is required")
+		}
+		if opts.PeerPublicKey == "" {
+			return errors.New("--peer-public-key flag is required")
+		}
+		if opts.Address == "" {
+			return errors.New("--address flag is required")
+		}
+		opts.Name = name
+
+		generated, err := gokeenrestapi.AwgConf.GenerateConf(opts)
+		if err != nil {
+			return err
+		}
+
+		// The conf contains the private key, keep it readable by the owner only and remove it afterwards
+		tmpDir, err := os.MkdirTemp("", "gokeenapi-awg-")
+		if err != nil {
+			return err
+		}
+		defer func() { _ = os.RemoveAll(tmpDir) }()
+		confPath := filepath.Join(tmpDir, "awg.conf")
+		if err := os.WriteFile(confPath, []byte(generated.Conf), 0600); err != nil {
+			return fmt.Errorf("failed to write generated configuration: %w", err)
+		}
+
+		// The tunnel cannot connect before the server knows the router's public key,
+		// waiting here would only time out and hide the stanza
+		interfaceId, err := addAwgInterface(confPath, name, false)
+		if err != nil {
+			return err
+		}
+
+		gokeenlog.HorizontalLine()
+		gokeenlog.Infof("Interface %v created, public key: %v",
+			color.CyanString(interfaceId), color.GreenString(generated.PublicKey))
+		gokeenlog.Info("Add the following to the server configuration:")
+		fmt.Println()
+		fmt.Print(generated.ServerStanza)
+		return nil
+	}
+	return cmd
+}
@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"net/http/httptest"
+	"time"
+
+	"github.com/noksa/gokeenapi/pkg/gokeenrestapi"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CreateAwg", func() {
+	const serverPublicKey = "SeRvErPuBlIcKeY0000000000000000000000000000="
+
+	var server *httptest.Server
+
+	BeforeEach(func() {
+		server = setupMockRouter()
+	})
+
+	AfterEach(func() {
+		cleanupMockRouter(server)
+	})
+
+	It("should create command with correct attributes and flags", func() {
+		cmd := newCreateAwgCmd()
+
+		Expect(cmd.Use).To(Equal(CmdCreateAwg))
+		Expect(cmd.Aliases).To(Equal(AliasesCreateAwg))
+		Expect(cmd.Short).NotTo(BeEmpty())
+		Expect(cmd.RunE).NotTo(BeNil())
+		Expect(cmd.Flags().Lookup("endpoint")).NotTo(BeNil())
+		Expect(cmd.Flags().Lookup("peer-public-key")).NotTo(BeNil())
+		Expect(cmd.Flags().Lookup("address")).NotTo(BeNil())
+		Expect(cmd.Flags().Lookup("allowed-ips").DefValue).To(Equal("[0.0.0.0/0]"))
+		Expect(cmd.Flags().Lookup("keepalive").DefValue).To(Equal("25"))
+		Expect(cmd.Flags().Lookup("awg2").DefValue).To(Equal("false"))
+	})
+
+	It("should fail when required flags are missing", func() {
+		cmd := newCreateAwgCmd()
+		err := cmd.RunE(cmd, []string{})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("--endpoint flag is required"))
+
+		_ = cmd.Flags().Set("endpoint", "vpn.example.com:51820")
+		err = cmd.RunE(cmd, []string{})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("--peer-public-key flag is required"))
+
+		_ = cmd.Flags().Set("peer-public-key", serverPublicKey)
+		err = cmd.RunE(cmd, []string{})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("--address flag is required"))
+	})
+
+	It("should reject an invalid peer public key", func() {
+		cmd := newCreateAwgCmd()
+		_ = cmd.Flags().Set("endpoint", "vpn.example.com:51820")
+		_ = cmd.Flags().Set("peer-public-key", "not-a-key")
+		_ = cmd.Flags().Set("address", "10.8.0.2")
+
+		err := cmd.RunE(cmd, []string{})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("invalid peer public key"))
+	})
+
+	It("should create the interface and print the server-side stanza", func() {
+		cmd := newCreateAwgCmd()
+		_ = cmd.Flags().Set("endpoint", "vpn.example.com:51820")
+		_ = cmd.Flags().Set("peer-public-key", serverPublicKey)
+		_ = cmd.Flags().Set("address", "10.8.0.2")
+		_ = cmd.Flags().Set("name", "home-router")
+
+		output, err := captureOutput(cmd, []string{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(output).To(ContainSubstring("[Peer]\n# home-router\nPublicKey = "))
+		Expect(output).To(ContainSubstring("AllowedIPs = 10.8.0.2/32\n"))
+		Expect(output).To(MatchRegexp(`Jc = \d+\n`))
+
+		conf, _, err := gokeenrestapi.AwgConf.ExportConf("Wireguard1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(conf).To(ContainSubstring("PublicKey = " + serverPublicKey))
+		Expect(conf).To(ContainSubstring("Endpoint = vpn.example.com:51820"))
+		Expect(conf).NotTo(ContainSubstring("Jc = 0"))
+	})
+	It("should print the server-side stanza without waiting for a handshake", func() {
+		cleanupMockRouter(server)
+		server = setupMockRouter(gokeenrestapi.WithPeersUnreachable())
+
+		cmd := newCreateAwgCmd()
+		_ = cmd.Flags().Set("endpoint", "vpn.example.com:51820")
+		_ = cmd.Flags().Set("peer-public-key", serverPublicKey)
+		_ = cmd.Flags().Set("address", "10.8.0.2")
+
+		start := time.Now()
+		output, err := captureOutput(cmd, []string{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(time.Since(start)).To(BeNumerically("<", 10*time.Second))
+		Expect(output).To(MatchRegexp(`public key: \S+=`))
+		Expect(output).To(ContainSubstring("[Peer]\nPublicKey = "))
+		Expect(output).To(ContainSubstring("AllowedIPs = 10.8.0.2/32\n"))
+	})
+})
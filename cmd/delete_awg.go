@@ -0,0 +1,193 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/fatih/color"
+	"github.com/noksa/gokeenapi/internal/gokeenlog"
+	"github.com/noksa/gokeenapi/pkg/config"
+	"github.com/noksa/gokeenapi/pkg/gokeenrestapi"
+	"github.com/spf13/cobra"
+)
+
+func newDeleteAwgCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     CmdDeleteAwg,
+		Aliases: AliasesDeleteAwg,
+		Short:   "Remove a WireGuard VPN connection together with its dependencies",
+		Long: `Delete a WireGuard (AWG) interface from your Keenetic (Netcraze) router.
+
+Removing a connection in the web interface leaves static routes and DNS-routing
+groups pointing at an interface that no longer exists. This command takes care of them.
+
+The command will:
+1. Find static routes and DNS-routing groups (dns-proxy routes) that use the interface
+2. List the interface and its dependencies
+3. Ask for confirmation (unless --force is used)
+4. Remove the dependencies, or re-point them to another interface with --move-to
+5. Remove the interface and save router configuration
+
+Only the dns-proxy routes of DNS-routing groups are removed, their domain lists are kept.
+Use --delete-dns-groups to delete the groups as well.
+DNS-routing groups are only checked on firmware 5.0.1 or higher.
+
+Examples:
+  # Delete the interface, its static routes and the dns-proxy routes to it
+  gokeenapi delete-awg --config config.yaml --interface-id Wireguard1
+
+  # Also delete the DNS-routing groups with their domain lists
+  gokeenapi delete-awg --config config.yaml --interface-id Wireguard1 --delete-dns-groups
+
+  # Delete the interface and route its traffic through another one
+  gokeenapi delete-awg --config config.yaml --interface-id Wireguard1 --move-to Wireguard0
+
+  # Delete without confirmation prompt
+  gokeenapi delete-awg --config config.yaml --interface-id Wireguard1 --force`,
+	}
+
+	var interfaceId, moveTo string
+	var force, deleteDnsGroups bool
+	cmd.Flags().StringVar(&interfaceId, "interface-id", "",
+		`Keenetic (Netcraze) WireGuard interface ID to delete.
+Use 'show-interfaces' to list available interface IDs.`)
+	cmd.Flags().StringVar(&moveTo, "move-to", "",
+		`Re-point dependent static routes and DNS-routing groups to this interface
+instead of removing them. DNS-routing domain lists are kept.`)
+	cmd.Flags().BoolVar(&deleteDnsGroups, "delete-dns-groups", false,
+		`Delete dependent DNS-routing groups with their domain lists
+instead of only removing their dns-proxy routes.`)
+	cmd.Flags().BoolVar(&force, "force", false,
+		`Skip confirmation prompt and delete the interface immediately.
+Use with caution as this bypasses the safety confirmation.`)
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if interfaceId == "" {
+			return errors.New("--interface-id flag is required")
+		}
+		if err := gokeenrestapi.Checks.CheckInterfaceExists(interfaceId); err != nil {
+			return err
+		}
+		iface, err := gokeenrestapi.Interface.GetInterfaceViaRciShowInterfaces(interfaceId)
+		if err != nil {
+			return err
+		}
+		if iface.Type != gokeenrestapi.InterfaceTypeWireguard {
+			return fmt.Errorf("interface %s is not a WireGuard interface (type: %s)", interfaceId, iface.Type)
+		}
+		if moveTo != "" {
+			if moveTo == interfaceId {
+				return errors.New("--move-to must differ from --interface-id")
+			}
+			if err := gokeenrestapi.Checks.CheckInterfaceExists(moveTo); err != nil {
+				return err
+			}
+			if deleteDnsGroups {
+				return errors.New("--move-to and --delete-dns-groups are mutually exclusive")
+			}
+		}
+
+		routes, err := gokeenrestapi.Ip.GetAllUserRoutesRciIpRoute(interfaceId)
+		if err != nil {
+			return err
+		}
+		dnsGroups, err := dnsRoutingGroupsVia(interfaceId)
+		if err != nil {
+			return err
+		}
+
+		action, dnsAction := "delete", "unbind"
+		if moveTo != "" {
+			action = "move to " + moveTo
+			dnsAction = action
+		} else if deleteDnsGroups {
+			dnsAction = "delete"
+		}
+		gokeenlog.InfoSubStepf("Interface to delete: %v (%v)", color.CyanString(interfaceId), iface.Description)
+		for _, route := range routes {
+			msg := ""
+			if route.Host != "" {
+				msg = color.CyanString(route.Host)
+			} else {
+				msg = color.CyanString(route.Network) + "/" + color.BlueString(route.Mask)
+			}
+			gokeenlog.InfoSubStepf("Static route to %v: %v", action, msg)
+		}
+		for _, group := range dnsGroups {
+			gokeenlog.InfoSubStepf("DNS-routing group to %v: %v", dnsAction, color.CyanString(group))
+		}
+
+		if !force {
+			confirmed, err := confirmAction(fmt.Sprintf("\nDelete %v with %v static route(s) and %v DNS-routing group(s) that depend on it?",
+				color.CyanString(interfaceId),
+				color.CyanString("%v", len(routes)),
+				color.CyanString("%v", len(dnsGroups))))
+			if err != nil {
+				return err
+			}
+			if !confirmed {
+				gokeenlog.Info("Deletion cancelled")
+				return nil
+			}
+		}
+
+		if len(routes) > 0 {
+			if moveTo != "" {
+				err = gokeenrestapi.Ip.MoveRoutes(routes, interfaceId, moveTo)
+			} else {
+				err = gokeenrestapi.Ip.DeleteRoutes(routes, interfaceId)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		if len(dnsGroups) > 0 {
+			switch {
+			case moveTo != "":
+				err = gokeenrestapi.DnsRouting.MoveDnsProxyRoutes(dnsGroups, interfaceId, moveTo)
+			case deleteDnsGroups:
+				var groups []config.DnsRoutingGroup
+				for _, group := range dnsGroups {
+					groups = append(groups, config.DnsRoutingGroup{Name: group, InterfaceID: interfaceId})
+				}
+				err = gokeenrestapi.DnsRouting.DeleteDnsRoutingGroups(groups)
+			default:
+				err = gokeenrestapi.DnsRouting.DeleteDnsProxyRoutes(dnsGroups, interfaceId)
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		if err := gokeenrestapi.Interface.DeleteInterface(interfaceId); err != nil {
+			return err
+		}
+		gokeenlog.Infof("Deleted %v interface", color.CyanString(interfaceId))
+		return nil
+	}
+	return cmd
+}
+
+// dnsRoutingGroupsVia returns sorted names of DNS-routing groups routed through the interface.
+// Firmware without DNS-routing support has no such groups
+func dnsRoutingGroupsVia(interfaceId string) ([]string, error) {
+	if err := gokeenrestapi.DnsRouting.CheckDnsRoutingSupport(); err != nil {
+		if errors.Is(err, gokeenrestapi.ErrDnsRoutingUnsupported) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	existingRoutes, err := gokeenrestapi.DnsRouting.GetExistingDnsProxyRoutes()
+	if err != nil {
+		return nil, err
+	}
+	var groups []string
+	for group, routeInterface := range existingRoutes {
+		if routeInterface == interfaceId {
+			groups = append(groups, group)
+		}
+	}
+	sort.Strings(groups)
+	return groups, nil
+}
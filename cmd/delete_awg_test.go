@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"net/http/httptest"
+
+	"github.com/noksa/gokeenapi/internal/gokeencache"
+	"github.com/noksa/gokeenapi/pkg/config"
+	"github.com/noksa/gokeenapi/pkg/gokeenrestapi"
+	"github.com/noksa/gokeenapi/pkg/gokeenrestapimodels"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DeleteAwg", func() {
+	var server *httptest.Server
+
+	BeforeEach(func() {
+		server = setupDnsRoutingMockRouter()
+	})
+
+	AfterEach(func() {
+		cleanupMockRouter(server)
+	})
+
+	interfaceExists := func(interfaceId string) bool {
+		interfaces, err := gokeenrestapi.Interface.GetInterfacesViaRciShowInterfaces(false)
+		ExpectWithOffset(1, err).NotTo(HaveOccurred())
+		_, ok := findInterfaceById(interfaces, interfaceId)
+		return ok
+	}
+
+	It("should create command with correct attributes and flags", func() {
+		cmd := newDeleteAwgCmd()
+
+		Expect(cmd.Use).To(Equal(CmdDeleteAwg))
+		Expect(cmd.Aliases).To(Equal(AliasesDeleteAwg))
+		Expect(cmd.Short).NotTo(BeEmpty())
+		Expect(cmd.RunE).NotTo(BeNil())
+
+		for _, name := range []string{"interface-id", "move-to", "delete-dns-groups", "force"} {
+			Expect(cmd.Flags().Lookup(name)).NotTo(BeNil(), name)
+		}
+	})
+
+	It("should require --interface-id", func() {
+		cmd := newDeleteAwgCmd()
+		err := cmd.RunE(cmd, []string{})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("--interface-id"))
+	})
+
+	It("should refuse to delete a non-WireGuard interface", func() {
+		cmd := newDeleteAwgCmd()
+		Expect(cmd.Flags().Set("interface-id", "ISP")).To(Succeed())
+		Expect(cmd.Flags().Set("force", "true")).To(Succeed())
+
+		err := cmd.RunE(cmd, []string{})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("not a WireGuard interface"))
+		Expect(interfaceExists("ISP")).To(BeTrue())
+	})
+
+	It("should refuse to move dependencies to the deleted interface", func() {
+		cmd := newDeleteAwgCmd()
+		Expect(cmd.Flags().Set("interface-id", "Wireguard0")).To(Succeed())
+		Expect(cmd.Flags().Set("move-to", "Wireguard0")).To(Succeed())
+
+		err := cmd.RunE(cmd, []string{})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("--move-to must differ"))
+		Expect(interfaceExists("Wireguard0")).To(BeTrue())
+	})
+
+	It("should not delete the interface when the DNS-routing check fails", func() {
+		gokeencache.UpdateRuntimeConfig(func(runtime *config.Runtime) {
+			runtime.RouterInfo.Version = gokeenrestapimodels.Version{}
+		})
+		cmd := newDeleteAwgCmd()
+		Expect(cmd.Flags().Set("interface-id", "Wireguard0")).To(Succeed())
+		Expect(cmd.Flags().Set("force", "true")).To(Succeed())
+
+		err := cmd.RunE(cmd, []string{})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("router version information not available"))
+		Expect(interfaceExists("Wireguard0")).To(BeTrue())
+	})
+
+	It("should delete the interface and its routes but keep DNS-routing domain lists", func() {
+		cmd := newDeleteAwgCmd()
+		Expect(cmd.Flags().Set("interface-id", "Wireguard0")).To(Succeed())
+		Expect(cmd.Flags().Set("force", "true")).To(Succeed())
+
+		output, err := captureOutput(cmd, []string{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(output).To(ContainSubstring("social-media"))
+		Expect(interfaceExists("Wireguard0")).To(BeFalse())
+
+		routes, err := gokeenrestapi.Ip.GetAllUserRoutesRciIpRoute("Wireguard0")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(routes).To(BeEmpty())
+
+		dnsRoutes, err := gokeenrestapi.DnsRouting.GetExistingDnsProxyRoutes()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(dnsRoutes).To(Equal(map[string]string{"streaming": "ISP"}))
+
+		groups, err := gokeenrestapi.DnsRouting.GetExistingDnsRoutingGroups()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(groups["social-media"]).To(ConsistOf("facebook.com", "instagram.com", "twitter.com"))
+		Expect(groups).To(HaveKey("streaming"))
+	})
+
+	It("should delete the interface with its routes and DNS-routing groups with --delete-dns-groups", func() {
+		cmd := newDeleteAwgCmd()
+		Expect(cmd.Flags().Set("interface-id", "Wireguard0")).To(Succeed())
+		Expect(cmd.Flags().Set("delete-dns-groups", "true")).To(Succeed())
+		Expect(cmd.Flags().Set("force", "true")).To(Succeed())
+
+		output, err := captureOutput(cmd, []string{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(output).To(ContainSubstring("social-media"))
+		Expect(interfaceExists("Wireguard0")).To(BeFalse())
+
+		routes, err := gokeenrestapi.Ip.GetAllUserRoutesRciIpRoute("Wireguard0")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(routes).To(BeEmpty())
+
+		dnsRoutes, err := gokeenrestapi.DnsRouting.GetExistingDnsProxyRoutes()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(dnsRoutes).To(Equal(map[string]string{"streaming": "ISP"}))
+
+		groups, err := gokeenrestapi.DnsRouting.GetExistingDnsRoutingGroups()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(groups).NotTo(HaveKey("social-media"))
+		Expect(groups).To(HaveKey("streaming"))
+	})
+
+	It("should refuse --move-to together with --delete-dns-groups", func() {
+		cmd := newDeleteAwgCmd()
+		Expect(cmd.Flags().Set("interface-id", "Wireguard0")).To(Succeed())
+		Expect(cmd.Flags().Set("move-to", "ISP")).To(Succeed())
+		Expect(cmd.Flags().Set("delete-dns-groups", "true")).To(Succeed())
+
+		err := cmd.RunE(cmd, []string{})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("mutually exclusive"))
+		Expect(interfaceExists("Wireguard0")).To(BeTrue())
+	})
+
+	It("should re-point routes and DNS-routing groups with --move-to", func() {
+		cmd := newDeleteAwgCmd()
+		Expect(cmd.Flags().Set("interface-id", "Wireguard0")).To(Succeed())
+		Expect(cmd.Flags().Set("move-to", "ISP")).To(Succeed())
+		Expect(cmd.Flags().Set("force", "true")).To(Succeed())
+
+		Expect(cmd.RunE(cmd, []string{})).To(Succeed())
+		Expect(interfaceExists("Wireguard0")).To(BeFalse())
+
+		routes, err := gokeenrestapi.Ip.GetAllUserRoutesRciIpRoute("ISP")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(routes).To(HaveLen(1))
+		Expect(routes[0].Network).To(Equal("192.168.1.0"))
+		Expect(routes[0].Mask).To(Equal("255.255.255.0"))
+
+		dnsRoutes, err := gokeenrestapi.DnsRouting.GetExistingDnsProxyRoutes()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(dnsRoutes).To(Equal(map[string]string{"social-media": "ISP", "streaming": "ISP"}))
+
+		groups, err := gokeenrestapi.DnsRouting.GetExistingDnsRoutingGroups()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(groups["social-media"]).To(ConsistOf("facebook.com", "instagram.com", "twitter.com"))
+	})
+})
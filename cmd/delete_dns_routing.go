@@ -145,6 +145,37 @@ Use with caution as this bypasses the safety confirmation.`)
 			}
 		}
 
+		// Companion groups of per-domain overrides are deleted together with their parent group
+		// Their names are derived from the overrides of the parent in config
+		var companions []config.DnsRoutingGroup
+		for _, parent := range groupsToDelete {
+			for _, group := range config.Cfg.DNS.Routes.Groups {
+				if group.Name != parent.Name {
+					continue
+				}
+				for _, override := range group.Overrides {
+					groupName := config.OverrideGroupName(group.Name, override.InterfaceID)
+					domains, exists := existingGroups[groupName]
+					if !exists {
+						continue
+					}
+					alreadySelected := slices.ContainsFunc(slices.Concat(groupsToDelete, companions), func(group config.DnsRoutingGroup) bool {
+						return group.Name == groupName
+					})
+					if alreadySelected {
+						continue
+					}
+					totalDomains += len(domains)
+					companions = append(companions, config.DnsRoutingGroup{Name: groupName, InterfaceID: existingRoutes[groupName]})
+					gokeenlog.InfoSubStepf("Override group to delete: %v (interface: %v, domains: %v)",
+						color.CyanString(groupName),
+						color.YellowString(existingRoutes[groupName]),
+						color.BlueString("%d", len(domains)))
+				}
+			}
+		}
+		groupsToDelete = append(groupsToDelete, companions...)
+
 		if len(groupsToDelete) == 0 {
 			if interfaceId != "" {
 				gokeenlog.Infof("No DNS-routing groups found for interface %v", color.YellowString(interfaceId))
@@ -177,4 +177,37 @@ var _ = Describe("DeleteDnsRouting", func() {
 		Expect(groups).NotTo(HaveKey("social-media"))
 		Expect(groups).To(HaveKey("streaming"))
 	})
+
+	It("should delete override groups together with their parent", func() {
+		cleanupMockRouter(server)
+		server = setupMockRouter(
+			gokeenrestapi.WithVersion("5.0.1"),
+			gokeenrestapi.WithDnsRoutingGroups(
+				[]gokeenrestapi.MockDnsRoutingGroup{
+					{Name: "video", Domains: []string{"youtube.com"}},
+					{Name: "video-ovr-ISP", Domains: []string{"googlevideo.com"}},
+				},
+				[]gokeenrestapi.MockDnsProxyRoute{
+					{GroupName: "video", InterfaceID: "Wireguard0", Mode: "auto"},
+					{GroupName: "video-ovr-ISP", InterfaceID: "ISP", Mode: "auto"},
+				},
+			),
+		)
+		config.Cfg.DNS = config.DNS{
+			Routes: config.DnsRoutes{
+				Groups: []config.DnsRoutingGroup{{
+					Name: "video", InterfaceID: "Wireguard0",
+					Overrides: []config.DnsRoutingOverride{{Domain: "googlevideo.com", InterfaceID: "ISP"}},
+				}},
+			},
+		}
+
+		cmd := newDeleteDnsRoutingCmd()
+		_ = cmd.Flags().Set("force", "true")
+		Expect(cmd.RunE(cmd, []string{})).To(Succeed())
+
+		groups, err := gokeenrestapi.DnsRouting.GetExistingDnsRoutingGroups()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(groups).To(BeEmpty())
+	})
 })
@@ -1,33 +1,50 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
-	"regexp"
 
 	"github.com/fatih/color"
 	"github.com/noksa/gokeenapi/internal/gokeenlog"
 	"github.com/noksa/gokeenapi/pkg/gokeenrestapi"
+	"github.com/noksa/gokeenapi/pkg/gokeenrestapimodels"
 	"github.com/spf13/cobra"
 )
 
+// knownHostsReport describes hosts removed by delete-known-hosts, or that would be removed in dry-run mode
+type knownHostsReport struct {
+	DryRun bool                       `json:"dryRun"`
+	Hosts  []gokeenrestapimodels.Host `json:"hosts"`
+}
+
 func newDeleteKnownHostsCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     CmdDeleteKnownHosts,
 		Aliases: AliasesDeleteKnownHosts,
-		Short:   "Clean up device list using name or MAC patterns",
-		Long: `Delete known hosts from your Keenetic (Netcraze) router using regex pattern matching.
-
-This command removes devices from the router's known hosts list (hotspot database)
-based on either hostname or MAC address patterns. Use regex patterns to match
-multiple hosts at once or target specific devices.
+		Short:   "Clean up device list using name, MAC, activity and network filters",
+		Long: `Delete known hosts from your Keenetic (Netcraze) router.
+
+This command removes devices from the router's known hosts list (hotspot database).
+Hosts are selected by filters, at least one filter is required and all specified
+filters must match:
+- --name-pattern / --mac-pattern: regex on the host name or MAC address
+- --offline: hosts that are not online
+- --unregistered: hosts that are not registered in the router
+- --not-seen-for: offline hosts last seen at least this long ago (e.g. 12h, 30d)
+- --random-mac: hosts with randomized (locally administered) MAC addresses
+- --via: hosts connected via the given interface
+- --ip-in: hosts with an IP address inside the given network
 
 The command will:
 1. Retrieve all known hosts from the router
-2. Apply the regex pattern to match hosts
+2. Apply the filters to match hosts
 3. Display matching hosts for review
-4. Ask for confirmation (unless --force is used)
+4. Ask for confirmation (unless --force or --dry-run is used)
 5. Delete the confirmed hosts
 
+Use --dry-run to only list matching hosts and --output json to get a report of
+removed hosts for scripts.
+
 Examples:
   # Delete hosts with names containing "guest"
   gokeenapi delete-known-hosts --config config.yaml --name-pattern ".*guest.*"
@@ -38,78 +55,88 @@ Examples:
   # Delete without confirmation
   gokeenapi delete-known-hosts --config config.yaml --name-pattern "temp.*" --force
 
-  # Delete hosts with exact name match
-  gokeenapi delete-known-hosts --config config.yaml --name-pattern "^old-device$"
+  # Show unregistered phones with randomized MACs not seen for a month
+  gokeenapi delete-known-hosts --config config.yaml --unregistered --random-mac --not-seen-for 30d --dry-run
 
-Note: Exactly one of --name-pattern or --mac-pattern must be specified.`,
+  # Delete offline guests and print a JSON report
+  gokeenapi delete-known-hosts --config config.yaml --offline --ip-in 10.1.30.0/24 --force --output json`,
 	}
 
-	var namePattern, macPattern string
-	var force bool
-	cmd.Flags().StringVar(&namePattern, "name-pattern", "",
-		`Regex pattern to match against host names for deletion.
-Examples: ".*guest.*" (contains guest), "^temp" (starts with temp)
-Cannot be used together with --mac-pattern.`)
-	cmd.Flags().StringVar(&macPattern, "mac-pattern", "",
-		`Regex pattern to match against host MAC addresses for deletion.
-Examples: "^aa:bb:cc:" (MAC prefix), ".*:.*:.*:dd:ee:ff$" (MAC suffix)
-Cannot be used together with --name-pattern.`)
+	var filterFlags hostFilterFlags
+	var output string
+	var dryRun, force bool
+	addHostFilterFlags(cmd, &filterFlags)
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "List matching hosts without deleting them")
 	cmd.Flags().BoolVar(&force, "force", false,
 		`Skip confirmation prompt and delete hosts immediately.
 Use with caution as this bypasses the safety confirmation.`)
+	addOutputFlag(cmd, &output, OutputTable, OutputJSON)
 
 	cmd.RunE = func(cmd *cobra.Command, args []string) error {
-		if (namePattern == "") == (macPattern == "") {
-			return fmt.Errorf("exactly one of --name-pattern or --mac-pattern must be specified")
-		}
-
-		var regex *regexp.Regexp
-		var err error
-		if namePattern != "" {
-			regex, err = regexp.Compile(namePattern)
-		} else {
-			regex, err = regexp.Compile(macPattern)
-		}
-		if err != nil {
+		if err := validateOutputFormat(output, OutputTable, OutputJSON); err != nil {
 			return err
 		}
 
-		hotspot, err := gokeenrestapi.Ip.GetAllHotspots()
+		filter, err := filterFlags.filter()
 		if err != nil {
 			return err
 		}
-
-		var hostMacsToDelete []string
-		for _, host := range hotspot.Host {
-			var matched bool
-			if namePattern != "" {
-				matched = regex.MatchString(host.Name)
-			} else {
-				matched = regex.MatchString(host.Mac)
-			}
-			if matched {
-				gokeenlog.InfoSubStepf("Matching host: %v (MAC: %v)", color.CyanString(host.Name), color.BlueString(host.Mac))
-				hostMacsToDelete = append(hostMacsToDelete, host.Mac)
-			}
+		if filter.IsEmpty() {
+			return errors.New("at least one filter must be specified, see --help")
 		}
 
-		if len(hostMacsToDelete) == 0 {
-			gokeenlog.Info("No hosts found matching the pattern, no need to delete")
-			return nil
-		}
-
-		if !force {
-			confirmed, err := confirmAction(fmt.Sprintf("\nFound %d host(s) to delete. Do you want to continue?", len(hostMacsToDelete)))
+		report := knownHostsReport{DryRun: dryRun, Hosts: []gokeenrestapimodels.Host{}}
+		run := func() error {
+			hotspot, err := gokeenrestapi.Ip.GetAllHotspots()
 			if err != nil {
 				return err
 			}
-			if !confirmed {
-				gokeenlog.Info("Deletion cancelled")
+
+			hosts := gokeenrestapi.FilterHosts(hotspot.Host, filter)
+			for _, host := range hosts {
+				gokeenlog.InfoSubStepf("Matching host: %v (MAC: %v, IP: %v)",
+					color.CyanString(host.Name), color.BlueString(host.Mac), host.IP)
+			}
+
+			if len(hosts) == 0 {
+				gokeenlog.Info("No hosts found matching the filters, no need to delete")
+				return nil
+			}
+			if dryRun {
+				gokeenlog.Infof("Dry run: %d host(s) would be deleted", len(hosts))
+				report.Hosts = hosts
 				return nil
 			}
+
+			if !force {
+				confirmed, err := confirmAction(fmt.Sprintf("\nFound %d host(s) to delete. Do you want to continue?", len(hosts)))
+				if err != nil {
+					return err
+				}
+				if !confirmed {
+					gokeenlog.Info("Deletion cancelled")
+					return nil
+				}
+			}
+
+			hostMacsToDelete := make([]string, 0, len(hosts))
+			for _, host := range hosts {
+				hostMacsToDelete = append(hostMacsToDelete, host.Mac)
+			}
+			if err := gokeenrestapi.Ip.DeleteKnownHosts(hostMacsToDelete); err != nil {
+				return err
+			}
+			report.Hosts = hosts
+			return nil
 		}
 
-		return gokeenrestapi.Ip.DeleteKnownHosts(hostMacsToDelete)
+		if output == OutputTable {
+			return run()
+		}
+		if err := withProgressOnStderr(run); err != nil {
+			return err
+		}
+		return printJSON(report)
 	}
 	return cmd
 }
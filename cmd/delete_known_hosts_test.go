@@ -1,7 +1,9 @@
 package cmd
 
 import (
+	"encoding/json"
 	"net/http/httptest"
+	"time"
 
 	"github.com/noksa/gokeenapi/pkg/gokeenrestapi"
 	. "github.com/onsi/ginkgo/v2"
@@ -35,22 +37,25 @@ var _ = Describe("DeleteKnownHosts", func() {
 		Expect(forceFlag.Value.Type()).To(Equal("bool"))
 	})
 
-	It("should fail when no pattern is specified", func() {
+	It("should fail when no filter is specified", func() {
 		cmd := newDeleteKnownHostsCmd()
 
 		err := cmd.RunE(cmd, []string{})
 		Expect(err).To(HaveOccurred())
-		Expect(err.Error()).To(ContainSubstring("exactly one of --name-pattern or --mac-pattern must be specified"))
+		Expect(err.Error()).To(ContainSubstring("at least one filter must be specified"))
 	})
 
-	It("should fail when both patterns are specified", func() {
+	It("should require both patterns to match when both are specified", func() {
 		cmd := newDeleteKnownHostsCmd()
-		_ = cmd.Flags().Set("name-pattern", "test")
-		_ = cmd.Flags().Set("mac-pattern", "aa:bb")
+		_ = cmd.Flags().Set("name-pattern", "test-device-.*")
+		_ = cmd.Flags().Set("mac-pattern", "^aa:bb")
+		_ = cmd.Flags().Set("force", "true")
+		Expect(cmd.RunE(cmd, []string{})).To(Succeed())
 
-		err := cmd.RunE(cmd, []string{})
-		Expect(err).To(HaveOccurred())
-		Expect(err.Error()).To(ContainSubstring("exactly one of --name-pattern or --mac-pattern must be specified"))
+		hotspotAfter, err := gokeenrestapi.Ip.GetAllHotspots()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(hotspotAfter.Host).To(HaveLen(1))
+		Expect(hotspotAfter.Host[0].Name).To(Equal("test-device-2"))
 	})
 
 	It("should fail with invalid regex", func() {
@@ -111,4 +116,109 @@ var _ = Describe("DeleteKnownHosts", func() {
 		Expect(err).NotTo(HaveOccurred())
 		Expect(hotspot.Host).To(HaveLen(2))
 	})
+
+	Context("with activity and network filters", func() {
+		BeforeEach(func() {
+			cleanupMockRouter(server)
+			server = setupMockRouter(gokeenrestapi.WithHotspotDevices([]gokeenrestapi.MockHost{
+				// Online registered laptop
+				{Name: "laptop", Mac: "00:11:22:33:44:55", IP: "192.168.1.10", Registered: true, Link: "up", Active: true, Via: "Bridge0"},
+				// Randomized MAC, offline for 40 days
+				{Name: "old-phone", Mac: "da:a1:19:00:00:01", IP: "192.168.1.20", LastSeen: 40 * 24 * 3600, Via: "Bridge0"},
+				// Randomized MAC, offline for 2 hours
+				{Name: "recent-phone", Mac: "da:a1:19:00:00:02", IP: "192.168.1.21", LastSeen: 2 * 3600, Via: "Bridge0"},
+				// Guest network, offline for 10 days
+				{Name: "guest", Mac: "00:aa:bb:cc:dd:ee", IP: "10.1.30.5", LastSeen: 10 * 24 * 3600, Via: "Bridge1"},
+			}))
+		})
+
+		remainingNames := func() []string {
+			hotspot, err := gokeenrestapi.Ip.GetAllHotspots()
+			ExpectWithOffset(1, err).NotTo(HaveOccurred())
+			var names []string
+			for _, host := range hotspot.Host {
+				names = append(names, host.Name)
+			}
+			return names
+		}
+
+		It("should combine filters", func() {
+			cmd := newDeleteKnownHostsCmd()
+			_ = cmd.Flags().Set("unregistered", "true")
+			_ = cmd.Flags().Set("random-mac", "true")
+			_ = cmd.Flags().Set("not-seen-for", "30d")
+			_ = cmd.Flags().Set("force", "true")
+			Expect(cmd.RunE(cmd, []string{})).To(Succeed())
+
+			Expect(remainingNames()).To(Equal([]string{"laptop", "recent-phone", "guest"}))
+		})
+
+		It("should filter by interface and network", func() {
+			cmd := newDeleteKnownHostsCmd()
+			_ = cmd.Flags().Set("offline", "true")
+			_ = cmd.Flags().Set("via", "bridge1")
+			_ = cmd.Flags().Set("ip-in", "10.1.30.0/24")
+			_ = cmd.Flags().Set("force", "true")
+			Expect(cmd.RunE(cmd, []string{})).To(Succeed())
+
+			Expect(remainingNames()).To(Equal([]string{"laptop", "old-phone", "recent-phone"}))
+		})
+
+		It("should only list hosts in dry-run mode", func() {
+			cmd := newDeleteKnownHostsCmd()
+			_ = cmd.Flags().Set("offline", "true")
+			_ = cmd.Flags().Set("dry-run", "true")
+			output, err := captureOutput(cmd, []string{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(output).To(ContainSubstring("3 host(s) would be deleted"))
+
+			Expect(remainingNames()).To(HaveLen(4))
+		})
+
+		It("should print a JSON report of removed hosts", func() {
+			cmd := newDeleteKnownHostsCmd()
+			_ = cmd.Flags().Set("random-mac", "true")
+			_ = cmd.Flags().Set("force", "true")
+			_ = cmd.Flags().Set("output", OutputJSON)
+			output, err := captureOutput(cmd, []string{})
+			Expect(err).NotTo(HaveOccurred())
+
+			var report knownHostsReport
+			Expect(json.Unmarshal([]byte(output), &report)).To(Succeed())
+			Expect(report.DryRun).To(BeFalse())
+			Expect(report.Hosts).To(HaveLen(2))
+			Expect(report.Hosts[0].Name).To(Equal("old-phone"))
+			Expect(report.Hosts[1].Name).To(Equal("recent-phone"))
+
+			Expect(remainingNames()).To(Equal([]string{"laptop", "guest"}))
+		})
+
+		It("should reject invalid filter values", func() {
+			cmd := newDeleteKnownHostsCmd()
+			_ = cmd.Flags().Set("not-seen-for", "a month")
+			Expect(cmd.RunE(cmd, []string{})).To(MatchError(ContainSubstring("invalid --not-seen-for")))
+
+			cmd = newDeleteKnownHostsCmd()
+			_ = cmd.Flags().Set("ip-in", "10.1.30.0")
+			Expect(cmd.RunE(cmd, []string{})).To(MatchError(ContainSubstring("invalid --ip-in")))
+		})
+	})
+
+	DescribeTable("parseAge",
+		func(value string, expected time.Duration, valid bool) {
+			d, err := parseAge(value)
+			if !valid {
+				Expect(err).To(HaveOccurred())
+				return
+			}
+			Expect(err).NotTo(HaveOccurred())
+			Expect(d).To(Equal(expected))
+		},
+		Entry("days", "30d", 30*24*time.Hour, true),
+		Entry("fractional days", "1.5d", 36*time.Hour, true),
+		Entry("go duration", "12h", 12*time.Hour, true),
+		Entry("zero", "0d", time.Duration(0), false),
+		Entry("negative", "-1h", time.Duration(0), false),
+		Entry("garbage", "xd", time.Duration(0), false),
+	)
 })
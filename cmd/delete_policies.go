@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/noksa/gokeenapi/internal/gokeenlog"
+	"github.com/noksa/gokeenapi/pkg/config"
+	"github.com/noksa/gokeenapi/pkg/gokeenrestapi"
+	"github.com/spf13/cobra"
+)
+
+func newDeletePoliciesCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     CmdDeletePolicies,
+		Aliases: AliasesDeletePolicies,
+		Short:   "Delete connection policies and unbind their devices",
+		Long: `Delete IP connection policies from your Keenetic (Netcraze) router.
+
+By default the policies declared in the 'policies' section of your configuration file
+are deleted; use --policy-id to delete specific policies instead. Devices bound to a
+deleted policy are unbound first and use the default policy afterwards. Policies
+missing on the router are skipped.
+
+Examples:
+  # Delete policies from the config
+  gokeenapi delete-policies --config config.yaml
+
+  # Delete a specific policy without confirmation
+  gokeenapi delete-policies --config config.yaml --policy-id Policy1 --force`,
+	}
+
+	var policyIds []string
+	var force bool
+	cmd.Flags().StringSliceVar(&policyIds, "policy-id", []string{},
+		`Policy ID to delete (e.g., Policy0). Can be specified multiple times.
+If not specified, policies from the config file are deleted.`)
+	cmd.Flags().BoolVar(&force, "force", false, "Skip confirmation prompt and delete policies immediately")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if len(policyIds) == 0 {
+			for _, policy := range config.Cfg.Policies {
+				policyIds = append(policyIds, policy.ID)
+			}
+		}
+		if len(policyIds) == 0 {
+			gokeenlog.Info("No policies to delete")
+			return nil
+		}
+
+		changes, err := gokeenrestapi.Policy.PlanPolicyDeletion(policyIds)
+		if err != nil {
+			return err
+		}
+		if len(changes) == 0 {
+			gokeenlog.Info("Policies don't exist on the router, no need to delete")
+			return nil
+		}
+		printPolicyChanges(changes)
+
+		if !force {
+			confirmed, err := confirmAction(fmt.Sprintf("\nFound %d policy(ies) to delete. Do you want to continue?", len(changes)))
+			if err != nil {
+				return err
+			}
+			if !confirmed {
+				gokeenlog.Info("Deletion cancelled")
+				return nil
+			}
+		}
+		return gokeenrestapi.Policy.ApplyPolicyChanges(changes)
+	}
+	return cmd
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"net/http/httptest"
+
+	"github.com/noksa/gokeenapi/pkg/config"
+	"github.com/noksa/gokeenapi/pkg/gokeenrestapi"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DeletePolicies", func() {
+	var (
+		server *httptest.Server
+		oldCfg config.GokeenapiConfig
+	)
+
+	BeforeEach(func() {
+		oldCfg = config.Cfg
+		server = setupMockRouter(
+			gokeenrestapi.WithPolicies([]gokeenrestapi.MockPolicy{
+				{ID: "Policy0", Interfaces: []string{"Wireguard0"}},
+				{ID: "Policy1", Interfaces: []string{"ISP"}},
+			}),
+			gokeenrestapi.WithHotspotDevices([]gokeenrestapi.MockHost{
+				{Name: "tv", Mac: "aa:bb:cc:dd:ee:01", Registered: true, Policy: "Policy0"},
+			}),
+		)
+	})
+
+	AfterEach(func() {
+		cleanupMockRouter(server)
+		config.Cfg = oldCfg
+	})
+
+	It("should create command with correct attributes and flags", func() {
+		cmd := newDeletePoliciesCmd()
+
+		Expect(cmd.Use).To(Equal(CmdDeletePolicies))
+		Expect(cmd.Aliases).To(Equal(AliasesDeletePolicies))
+		Expect(cmd.Short).NotTo(BeEmpty())
+		Expect(cmd.RunE).NotTo(BeNil())
+		Expect(cmd.Flags().Lookup("policy-id")).NotTo(BeNil())
+		Expect(cmd.Flags().Lookup("force")).NotTo(BeNil())
+	})
+
+	It("should delete policies from the config and unbind their hosts", func() {
+		config.Cfg.Policies = []config.ConnectionPolicy{{ID: "Policy0", Interfaces: []string{"Wireguard0"}}}
+
+		cmd := newDeletePoliciesCmd()
+		_ = cmd.Flags().Set("force", "true")
+		Expect(cmd.RunE(cmd, []string{})).To(Succeed())
+
+		policies, err := gokeenrestapi.Policy.GetPolicies()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(policies).NotTo(HaveKey("Policy0"))
+		Expect(policies).To(HaveKey("Policy1"))
+
+		hostPolicies, err := gokeenrestapi.Ip.GetHostPolicies()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(hostPolicies).To(BeEmpty())
+	})
+
+	It("should delete policies given by --policy-id", func() {
+		config.Cfg.Policies = nil
+
+		cmd := newDeletePoliciesCmd()
+		_ = cmd.Flags().Set("policy-id", "Policy1")
+		_ = cmd.Flags().Set("force", "true")
+		Expect(cmd.RunE(cmd, []string{})).To(Succeed())
+
+		policies, err := gokeenrestapi.Policy.GetPolicies()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(policies).To(HaveKey("Policy0"))
+		Expect(policies).NotTo(HaveKey("Policy1"))
+	})
+
+	It("should skip policies missing on the router", func() {
+		cmd := newDeletePoliciesCmd()
+		_ = cmd.Flags().Set("policy-id", "Policy7")
+		Expect(cmd.RunE(cmd, []string{})).To(Succeed())
+	})
+})
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/noksa/gokeenapi/internal/gokeenlog"
+	"github.com/noksa/gokeenapi/pkg/gokeenrestapi"
+	"github.com/spf13/cobra"
+)
+
+func newExportAwgCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     CmdExportAwg,
+		Aliases: AliasesExportAwg,
+		Short:   "Export an existing WireGuard interface into a .conf file",
+		Long: `Export an existing WireGuard (AWG) interface from your Keenetic (Netcraze) router
+into a standard WireGuard configuration file.
+
+The generated file contains the [Interface] section (private key, address, MTU and
+AmneziaWG parameters Jc, Jmin, Jmax, S1, S2, H1-H4, S3, S4, I1-I5) and one [Peer]
+section per peer. It can be imported on another router with 'add-awg' to clone the tunnel.
+
+The private key is taken from the router when the API exposes it. Otherwise the
+file contains the placeholder <PRIVATE_KEY> which must be replaced before use.
+
+Without --output the configuration is printed to stdout.
+
+Examples:
+  # Save Wireguard0 into wg0.conf
+  gokeenapi export-awg --config config.yaml --interface-id Wireguard0 -o wg0.conf
+
+  # Print the configuration to stdout
+  gokeenapi export-awg --config config.yaml --interface-id Wireguard0`,
+	}
+
+	var interfaceId, output string
+	var force bool
+	cmd.Flags().StringVar(&interfaceId, "interface-id", "", "ID of the WireGuard interface to export")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Path of the .conf file to write. Prints to stdout if not specified")
+	cmd.Flags().BoolVar(&force, "force", false, "Overwrite the output file if it already exists")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if interfaceId == "" {
+			return errors.New("--interface-id flag is required")
+		}
+
+		var conf string
+		var placeholder bool
+		err := withProgressOnStderr(func() error {
+			var exportErr error
+			conf, placeholder, exportErr = gokeenrestapi.AwgConf.ExportConf(interfaceId)
+			return exportErr
+		})
+		if err != nil {
+			return err
+		}
+
+		if placeholder {
+			fmt.Fprintf(os.Stderr, "⚠️  %s: the router does not expose the private key of %s, replace %s in the exported file before use\n",
+				color.YellowString("WARNING"), interfaceId, gokeenrestapi.AwgPrivateKeyPlaceholder)
+		}
+
+		if output == "" {
+			_, err = fmt.Fprint(os.Stdout, conf)
+			return err
+		}
+
+		if !force {
+			if _, statErr := os.Stat(output); statErr == nil {
+				return fmt.Errorf("file %s already exists, use --force to overwrite", output)
+			} else if !errors.Is(statErr, os.ErrNotExist) {
+				return statErr
+			}
+		}
+
+		// The file may contain the private key, keep it readable by the owner only
+		if err := os.WriteFile(output, []byte(conf), 0600); err != nil {
+			return fmt.Errorf("failed to write %s: %w", output, err)
+		}
+
+		gokeenlog.Infof("Exported interface %v to %v", color.CyanString(interfaceId), color.GreenString(output))
+		return nil
+	}
+
+	return cmd
+}
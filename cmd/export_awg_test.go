@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	"github.com/noksa/gokeenapi/pkg/gokeenrestapi"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ExportAwg", func() {
+	var server *httptest.Server
+
+	BeforeEach(func() {
+		server = setupMockRouter()
+	})
+
+	AfterEach(func() {
+		cleanupMockRouter(server)
+	})
+
+	It("should create command with correct attributes and flags", func() {
+		cmd := newExportAwgCmd()
+
+		Expect(cmd.Use).To(Equal(CmdExportAwg))
+		Expect(cmd.Aliases).To(Equal(AliasesExportAwg))
+		Expect(cmd.Short).NotTo(BeEmpty())
+		Expect(cmd.RunE).NotTo(BeNil())
+		Expect(cmd.Flags().Lookup("interface-id")).NotTo(BeNil())
+		Expect(cmd.Flags().ShorthandLookup("o").Name).To(Equal("output"))
+		Expect(cmd.Flags().Lookup("force").DefValue).To(Equal("false"))
+	})
+
+	It("should fail when interface-id is missing", func() {
+		cmd := newExportAwgCmd()
+
+		err := cmd.RunE(cmd, []string{})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("--interface-id flag is required"))
+	})
+
+	It("should write the conf file with owner-only permissions", func() {
+		output := filepath.Join(GinkgoT().TempDir(), "wg0.conf")
+
+		cmd := newExportAwgCmd()
+		_ = cmd.Flags().Set("interface-id", "Wireguard0")
+		_ = cmd.Flags().Set("output", output)
+		Expect(cmd.RunE(cmd, []string{})).To(Succeed())
+
+		content, err := os.ReadFile(output)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(content)).To(HavePrefix("[Interface]\nPrivateKey = " + gokeenrestapi.AwgPrivateKeyPlaceholder + "\n"))
+		Expect(string(content)).To(ContainSubstring("Jc = 3\n"))
+
+		info, err := os.Stat(output)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info.Mode().Perm()).To(Equal(os.FileMode(0600)))
+	})
+
+	It("should not overwrite an existing file without --force", func() {
+		output := writeTempFile(GinkgoT().TempDir(), "wg0.conf", "existing\n")
+
+		cmd := newExportAwgCmd()
+		_ = cmd.Flags().Set("interface-id", "Wireguard0")
+		_ = cmd.Flags().Set("output", output)
+		err := cmd.RunE(cmd, []string{})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("already exists"))
+
+		_ = cmd.Flags().Set("force", "true")
+		Expect(cmd.RunE(cmd, []string{})).To(Succeed())
+		content, err := os.ReadFile(output)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(content)).To(HavePrefix("[Interface]\n"))
+	})
+
+	It("should print the conf to stdout without --output", func() {
+		cmd := newExportAwgCmd()
+		_ = cmd.Flags().Set("interface-id", "Wireguard0")
+		out, err := captureOutput(cmd, []string{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(HavePrefix("[Interface]\n"))
+		Expect(out).To(ContainSubstring("Jmax = 1000\n"))
+	})
+})
@@ -0,0 +1,169 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/noksa/gokeenapi/internal/gokeenlog"
+	"github.com/noksa/gokeenapi/pkg/config"
+	"github.com/noksa/gokeenapi/pkg/gokeenrestapi"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// exportDomainsDir is the directory (relative to the output directory) for exported domain files
+	exportDomainsDir = "domains"
+)
+
+func newExportDnsRoutingCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     CmdExportDnsRouting,
+		Aliases: AliasesExportDnsRouting,
+		Short:   "Export existing DNS-routing rules from the router into YAML",
+		Long: `Export DNS-routing rules configured on your Keenetic (Netcraze) router into files
+that can be used with 'add-dns-routing'.
+
+This is useful for routers that were set up by hand in the web UI: the existing
+object-groups and dns-proxy routes are brought under config management without
+retyping any domains.
+
+The command will:
+1. Check router firmware version (requires 5.0.1+)
+2. Fetch DNS-routing groups and dns-proxy routes from the router
+3. Write one '<output-dir>/domains/<group>.txt' file per group
+4. Write '<output-dir>/<file>' with a 'groups:' list referencing these files
+
+Groups without a dns-proxy route are skipped because they have no target interface.
+
+Examples:
+  # Export into the current directory
+  gokeenapi export-dns-routing --config config.yaml
+
+  # Export into a dedicated directory, overwriting existing files
+  gokeenapi export-dns-routing --config config.yaml --output-dir exported --force
+
+The generated file can then be imported in your config:
+  dns:
+    routes:
+      groups:
+        - exported/dns_routing_groups.yaml
+
+Requirements: Keenetic firmware version 5.0.1 or higher`,
+	}
+
+	var outputDir string
+	var fileName string
+	var force bool
+	cmd.Flags().StringVar(&outputDir, "output-dir", ".",
+		`Directory to write the groups YAML and the domains/ directory to.`)
+	cmd.Flags().StringVar(&fileName, "file", "dns_routing_groups.yaml",
+		`Name of the groups YAML file created in the output directory.`)
+	cmd.Flags().BoolVar(&force, "force", false,
+		`Overwrite existing files in the output directory.`)
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if err := gokeenrestapi.DnsRouting.CheckDnsRoutingSupport(); err != nil {
+			return err
+		}
+
+		existingGroups, err := gokeenrestapi.DnsRouting.GetExistingDnsRoutingGroups()
+		if err != nil {
+			return err
+		}
+
+		existingRoutes, err := gokeenrestapi.DnsRouting.GetExistingDnsProxyRoutes()
+		if err != nil {
+			return err
+		}
+
+		groupNames := make([]string, 0, len(existingGroups))
+		for groupName := range existingGroups {
+			groupNames = append(groupNames, groupName)
+		}
+		slices.Sort(groupNames)
+
+		groupsList := config.GroupsList{}
+		domainFiles := make(map[string][]byte)
+		for _, groupName := range groupNames {
+			routeInterface, exists := existingRoutes[groupName]
+			if !exists {
+				gokeenlog.InfoSubStepf("Skipping DNS-routing group %v: no dns-proxy route found",
+					color.CyanString(groupName))
+				continue
+			}
+			domains := existingGroups[groupName]
+			if len(domains) == 0 {
+				gokeenlog.InfoSubStepf("Skipping DNS-routing group %v: group has no domains",
+					color.CyanString(groupName))
+				continue
+			}
+
+			domainFile := filepath.ToSlash(filepath.Join(exportDomainsDir, groupName+".txt"))
+			domainFiles[domainFile] = []byte(strings.Join(domains, "\n") + "\n")
+			groupsList.Groups = append(groupsList.Groups, config.DnsRoutingGroup{
+				Name:        groupName,
+				DomainFile:  []string{domainFile},
+				InterfaceID: routeInterface,
+			})
+
+			gokeenlog.InfoSubStepf("DNS-routing group to export: %v (interface: %v, domains: %v)",
+				color.CyanString(groupName),
+				color.YellowString(routeInterface),
+				color.BlueString("%d", len(domains)))
+		}
+
+		if len(groupsList.Groups) == 0 {
+			gokeenlog.Info("No DNS-routing groups to export")
+			return nil
+		}
+
+		var buf bytes.Buffer
+		encoder := yaml.NewEncoder(&buf)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(groupsList); err != nil {
+			return fmt.Errorf("failed to encode DNS-routing groups: %w", err)
+		}
+		if err := encoder.Close(); err != nil {
+			return fmt.Errorf("failed to encode DNS-routing groups: %w", err)
+		}
+
+		groupsFile := filepath.Join(outputDir, fileName)
+		files := map[string][]byte{groupsFile: buf.Bytes()}
+		for domainFile, content := range domainFiles {
+			files[filepath.Join(outputDir, filepath.FromSlash(domainFile))] = content
+		}
+
+		if !force {
+			for path := range files {
+				if _, statErr := os.Stat(path); statErr == nil {
+					return fmt.Errorf("file %s already exists, use --force to overwrite", path)
+				} else if !errors.Is(statErr, os.ErrNotExist) {
+					return statErr
+				}
+			}
+		}
+
+		if err := os.MkdirAll(filepath.Join(outputDir, exportDomainsDir), 0755); err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+		for path, content := range files {
+			if err := os.WriteFile(path, content, 0644); err != nil {
+				return fmt.Errorf("failed to write %s: %w", path, err)
+			}
+		}
+
+		gokeenlog.Infof("Exported %v DNS-routing group(s) to %v",
+			color.CyanString("%d", len(groupsList.Groups)),
+			color.GreenString(groupsFile))
+		return nil
+	}
+
+	return cmd
+}
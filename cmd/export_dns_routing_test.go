@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	"github.com/noksa/gokeenapi/pkg/config"
+	"github.com/noksa/gokeenapi/pkg/gokeenrestapi"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ExportDnsRouting", func() {
+	var (
+		server *httptest.Server
+		tmpDir string
+	)
+
+	BeforeEach(func() {
+		server = setupMockRouter(
+			gokeenrestapi.WithVersion("5.0.1"),
+			gokeenrestapi.WithDnsRoutingGroups(
+				[]gokeenrestapi.MockDnsRoutingGroup{
+					{Name: "social-media", Domains: []string{"facebook.com", "instagram.com"}},
+					{Name: "streaming", Domains: []string{"youtube.com", "8.8.8.8"}},
+					{Name: "unrouted", Domains: []string{"unrouted.example.com"}},
+				},
+				[]gokeenrestapi.MockDnsProxyRoute{
+					{GroupName: "social-media", InterfaceID: "Wireguard0", Mode: "auto"},
+					{GroupName: "streaming", InterfaceID: "ISP", Mode: "auto"},
+				},
+			),
+		)
+		tmpDir = GinkgoT().TempDir()
+	})
+
+	AfterEach(func() {
+		cleanupMockRouter(server)
+	})
+
+	It("should create command with correct attributes and flags", func() {
+		cmd := newExportDnsRoutingCmd()
+
+		Expect(cmd.Use).To(Equal(CmdExportDnsRouting))
+		Expect(cmd.Aliases).To(Equal(AliasesExportDnsRouting))
+		Expect(cmd.Short).NotTo(BeEmpty())
+		Expect(cmd.RunE).NotTo(BeNil())
+
+		Expect(cmd.Flags().Lookup("output-dir")).NotTo(BeNil())
+		Expect(cmd.Flags().Lookup("file").DefValue).To(Equal("dns_routing_groups.yaml"))
+		Expect(cmd.Flags().Lookup("force").DefValue).To(Equal("false"))
+	})
+
+	It("should write groups YAML and per-group domain files", func() {
+		cmd := newExportDnsRoutingCmd()
+		_ = cmd.Flags().Set("output-dir", tmpDir)
+		Expect(cmd.RunE(cmd, []string{})).To(Succeed())
+
+		content, err := os.ReadFile(filepath.Join(tmpDir, "domains", "social-media.txt"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(content)).To(Equal("facebook.com\ninstagram.com\n"))
+
+		content, err = os.ReadFile(filepath.Join(tmpDir, "domains", "streaming.txt"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(content)).To(Equal("youtube.com\n8.8.8.8\n"))
+
+		Expect(filepath.Join(tmpDir, "domains", "unrouted.txt")).NotTo(BeAnExistingFile())
+
+		content, err = os.ReadFile(filepath.Join(tmpDir, "dns_routing_groups.yaml"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(content)).To(Equal(`groups:
+  - name: social-media
+    domain-file:
+      - domains/social-media.txt
+    interfaceId: Wireguard0
+  - name: streaming
+    domain-file:
+      - domains/streaming.txt
+    interfaceId: ISP
+`))
+	})
+
+	It("should produce a file that loads back as the same groups", func() {
+		cmd := newExportDnsRoutingCmd()
+		_ = cmd.Flags().Set("output-dir", filepath.Join(tmpDir, "exported"))
+		Expect(cmd.RunE(cmd, []string{})).To(Succeed())
+
+		configPath := writeTempFile(tmpDir, "config.yaml", `keenetic:
+  url: "http://192.168.1.1"
+  login: "admin"
+  password: "password"
+dns:
+  routes:
+    groups:
+      - exported/dns_routing_groups.yaml
+`)
+		oldCfg := config.Cfg
+		defer func() { config.Cfg = oldCfg }()
+		Expect(config.LoadConfig(configPath)).To(Succeed())
+
+		groups := config.Cfg.DNS.Routes.Groups
+		Expect(groups).To(HaveLen(2))
+		Expect(groups[0].Name).To(Equal("social-media"))
+		Expect(groups[0].InterfaceID).To(Equal("Wireguard0"))
+
+		domains, err := gokeenrestapi.DnsRouting.LoadDomainsFromFile(groups[0].DomainFile[0])
+		Expect(err).NotTo(HaveOccurred())
+		Expect(domains).To(Equal([]string{"facebook.com", "instagram.com"}))
+	})
+
+	It("should not overwrite existing files without --force", func() {
+		existing := writeTempFile(tmpDir, "dns_routing_groups.yaml", "groups: []\n")
+
+		cmd := newExportDnsRoutingCmd()
+		_ = cmd.Flags().Set("output-dir", tmpDir)
+		err := cmd.RunE(cmd, []string{})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("already exists"))
+
+		content, readErr := os.ReadFile(existing)
+		Expect(readErr).NotTo(HaveOccurred())
+		Expect(string(content)).To(Equal("groups: []\n"))
+
+		_ = cmd.Flags().Set("force", "true")
+		Expect(cmd.RunE(cmd, []string{})).To(Succeed())
+		content, readErr = os.ReadFile(existing)
+		Expect(readErr).NotTo(HaveOccurred())
+		Expect(string(content)).To(ContainSubstring("social-media"))
+	})
+})
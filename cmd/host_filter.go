@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+
+	"github.com/noksa/gokeenapi/pkg/gokeenrestapi"
+	"github.com/spf13/cobra"
+)
+
+// hostFilterFlags holds flags selecting known hosts, shared by commands working with the hotspot database
+type hostFilterFlags struct {
+	namePattern, macPattern, notSeenFor, via, ipIn string
+	offline, unregistered, randomMac               bool
+}
+
+// addHostFilterFlags registers known host filter flags on cmd
+func addHostFilterFlags(cmd *cobra.Command, f *hostFilterFlags) {
+	cmd.Flags().StringVar(&f.namePattern, "name-pattern", "",
+		`Regex pattern to match against host names.
+Examples: ".*guest.*" (contains guest), "^temp" (starts with temp)`)
+	cmd.Flags().StringVar(&f.macPattern, "mac-pattern", "",
+		`Regex pattern to match against host MAC addresses.
+Examples: "^aa:bb:cc:" (MAC prefix), ".*:.*:.*:dd:ee:ff$" (MAC suffix)`)
+	cmd.Flags().BoolVar(&f.offline, "offline", false, "Match only hosts that are not online")
+	cmd.Flags().BoolVar(&f.unregistered, "unregistered", false, "Match only hosts that are not registered in the router")
+	cmd.Flags().StringVar(&f.notSeenFor, "not-seen-for", "",
+		`Match only offline hosts last seen at least this long ago.
+Accepts Go durations and days, e.g. 12h, 30d`)
+	cmd.Flags().BoolVar(&f.randomMac, "random-mac", false, "Match only hosts with randomized (locally administered) MAC addresses")
+	cmd.Flags().StringVar(&f.via, "via", "", "Match only hosts connected via this interface")
+	cmd.Flags().StringVar(&f.ipIn, "ip-in", "", "Match only hosts with an IP address inside this network, e.g. 192.168.1.0/24")
+}
+
+// filter builds a host filter from the flags
+func (f hostFilterFlags) filter() (gokeenrestapi.HostFilter, error) {
+	filter := gokeenrestapi.HostFilter{
+		Offline:      f.offline,
+		Unregistered: f.unregistered,
+		RandomMac:    f.randomMac,
+		Via:          f.via,
+	}
+	var err error
+	if f.namePattern != "" {
+		if filter.NamePattern, err = regexp.Compile(f.namePattern); err != nil {
+			return filter, err
+		}
+	}
+	if f.macPattern != "" {
+		if filter.MacPattern, err = regexp.Compile(f.macPattern); err != nil {
+			return filter, err
+		}
+	}
+	if f.notSeenFor != "" {
+		if filter.NotSeenFor, err = parseAge(f.notSeenFor); err != nil {
+			return filter, fmt.Errorf("invalid --not-seen-for: %w", err)
+		}
+	}
+	if f.ipIn != "" {
+		if _, filter.IPIn, err = net.ParseCIDR(f.ipIn); err != nil {
+			return filter, fmt.Errorf("invalid --ip-in: %w", err)
+		}
+	}
+	return filter, nil
+}
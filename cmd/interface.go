@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"errors"
+	"time"
+
+	"github.com/noksa/gokeenapi/internal/gokeenlog"
+	"github.com/noksa/gokeenapi/pkg/gokeenrestapi"
+	"github.com/spf13/cobra"
+)
+
+func newInterfaceCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     CmdInterface,
+		Aliases: AliasesInterface,
+		Short:   "Control interfaces on your router",
+		Long: `Bring interfaces on your Keenetic (Netcraze) router up or down, change their global IP
+setting and wait until they are connected.
+
+Every subcommand exits with a non-zero code on failure, so they can be used in scripts and
+scheduler tasks to bounce or verify tunnels.
+
+Examples:
+  # Restart a WireGuard tunnel and wait until it is connected again
+  gokeenapi interface down --config config.yaml --interface-id Wireguard0
+  gokeenapi interface up --config config.yaml --interface-id Wireguard0 --wait --timeout 2m
+
+  # Use the interface for internet access
+  gokeenapi interface set-global --config config.yaml --interface-id Wireguard0
+
+  # Fail if the interface is not connected within 2 minutes
+  gokeenapi interface wait --config config.yaml --interface-id Wireguard0 --timeout 2m`,
+	}
+
+	cmd.AddCommand(
+		newInterfaceUpCmd(),
+		newInterfaceDownCmd(),
+		newInterfaceSetGlobalCmd(),
+		newInterfaceWaitCmd(),
+	)
+	return cmd
+}
+
+func newInterfaceUpCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   CmdInterfaceUp,
+		Short: "Bring an interface up",
+		Long: `Enable an interface on your router.
+
+With --wait the command also waits up to --timeout until the interface is up and connected.
+
+Examples:
+  gokeenapi interface up --config config.yaml --interface-id Wireguard0
+  gokeenapi interface up --config config.yaml --interface-id Wireguard0 --wait --timeout 2m`,
+	}
+
+	var interfaceId string
+	var wait bool
+	var timeout time.Duration
+	addInterfaceIdFlag(cmd, &interfaceId)
+	cmd.Flags().BoolVar(&wait, "wait", false, "Wait until the interface is up and connected")
+	addInterfaceTimeoutFlag(cmd, &timeout)
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if wait && timeout <= 0 {
+			return errors.New("--timeout must be positive")
+		}
+		if err := checkInterfaceId(interfaceId); err != nil {
+			return err
+		}
+		if err := gokeenrestapi.Interface.UpInterface(interfaceId); err != nil {
+			return err
+		}
+		if !wait {
+			return nil
+		}
+		return gokeenrestapi.Interface.WaitUntilInterfaceIsUpWithTimeout(commandContext(cmd), interfaceId, timeout)
+	}
+	return cmd
+}
+
+func newInterfaceDownCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   CmdInterfaceDown,
+		Short: "Bring an interface down",
+		Long: `Disable an interface on your router.
+
+Examples:
+  gokeenapi interface down --config config.yaml --interface-id Wireguard0`,
+	}
+
+	var interfaceId string
+	addInterfaceIdFlag(cmd, &interfaceId)
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if err := checkInterfaceId(interfaceId); err != nil {
+			return err
+		}
+		return gokeenrestapi.Interface.DownInterface(interfaceId)
+	}
+	return cmd
+}
+
+func newInterfaceSetGlobalCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   CmdInterfaceSetGlobal,
+		Short: "Enable or disable internet access via an interface",
+		Long: `Change the global IP setting of an interface on your router. When enabled the router
+may use the interface for internet access.
+
+Examples:
+  # Enable
+  gokeenapi interface set-global --config config.yaml --interface-id Wireguard0
+
+  # Disable
+  gokeenapi interface set-global --config config.yaml --interface-id Wireguard0 --enabled=false`,
+	}
+
+	var interfaceId string
+	var enabled bool
+	addInterfaceIdFlag(cmd, &interfaceId)
+	cmd.Flags().BoolVar(&enabled, "enabled", true, "Whether the interface can be used for internet access")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if err := checkInterfaceId(interfaceId); err != nil {
+			return err
+		}
+		return gokeenrestapi.Interface.SetGlobalIpInInterface(interfaceId, enabled)
+	}
+	return cmd
+}
+
+func newInterfaceWaitCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   CmdInterfaceWait,
+		Short: "Wait until an interface is up and connected",
+		Long: `Wait until an interface on your router is enabled, has a link and is connected.
+The command exits with a non-zero code if this does not happen within --timeout.
+
+Examples:
+  gokeenapi interface wait --config config.yaml --interface-id Wireguard0 --timeout 2m`,
+	}
+
+	var interfaceId string
+	var timeout time.Duration
+	addInterfaceIdFlag(cmd, &interfaceId)
+	addInterfaceTimeoutFlag(cmd, &timeout)
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if timeout <= 0 {
+			return errors.New("--timeout must be positive")
+		}
+		if err := checkInterfaceId(interfaceId); err != nil {
+			return err
+		}
+		if err := gokeenrestapi.Interface.WaitUntilInterfaceIsUpWithTimeout(commandContext(cmd), interfaceId, timeout); err != nil {
+			return err
+		}
+		gokeenlog.Infof("Interface %v is up and connected", interfaceId)
+		return nil
+	}
+	return cmd
+}
+
+// addInterfaceIdFlag registers the --interface-id flag
+func addInterfaceIdFlag(cmd *cobra.Command, interfaceId *string) {
+	cmd.Flags().StringVar(interfaceId, "interface-id", "", "Interface ID (use show-interfaces to find it)")
+}
+
+// checkInterfaceId verifies that --interface-id is set and the interface exists on the router
+func checkInterfaceId(interfaceId string) error {
+	if interfaceId == "" {
+		return errors.New("--interface-id flag is required")
+	}
+	return gokeenrestapi.Checks.CheckInterfaceExists(interfaceId)
+}
+
+// addInterfaceTimeoutFlag registers the --timeout flag used when waiting for an interface
+func addInterfaceTimeoutFlag(cmd *cobra.Command, timeout *time.Duration) {
+	cmd.Flags().DurationVar(timeout, "timeout", time.Minute, "How long to wait until the interface is up and connected")
+}
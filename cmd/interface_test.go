@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"net/http/httptest"
+
+	"github.com/noksa/gokeenapi/pkg/gokeenrestapi"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+)
+
+var _ = Describe("Interface", func() {
+	var server *httptest.Server
+
+	BeforeEach(func() {
+		server = setupMockRouter(
+			gokeenrestapi.WithInterfaces([]gokeenrestapi.MockInterface{
+				{
+					ID: "Wireguard0", Type: gokeenrestapi.InterfaceTypeWireguard,
+					Connected: gokeenrestapi.StateConnected, Link: gokeenrestapi.StateUp, State: gokeenrestapi.StateUp,
+				},
+				{
+					ID: "Wireguard1", Type: gokeenrestapi.InterfaceTypeWireguard,
+					Connected: gokeenrestapi.StateDisconnected, Link: gokeenrestapi.StateDown, State: gokeenrestapi.StateDown,
+				},
+			}),
+		)
+	})
+
+	AfterEach(func() {
+		cleanupMockRouter(server)
+	})
+
+	subcommand := func(name string) *cobra.Command {
+		for _, sub := range newInterfaceCmd().Commands() {
+			if sub.Name() == name {
+				return sub
+			}
+		}
+		Fail("subcommand " + name + " not found")
+		return nil
+	}
+
+	currentState := func(interfaceId string) string {
+		iface, err := gokeenrestapi.Interface.GetInterfaceViaRciShowInterfaces(interfaceId)
+		ExpectWithOffset(1, err).NotTo(HaveOccurred())
+		return iface.State
+	}
+
+	It("should create command group with subcommands", func() {
+		cmd := newInterfaceCmd()
+
+		Expect(cmd.Use).To(Equal(CmdInterface))
+		Expect(cmd.Aliases).To(Equal(AliasesInterface))
+		Expect(cmd.Short).NotTo(BeEmpty())
+		for _, name := range []string{CmdInterfaceUp, CmdInterfaceDown, CmdInterfaceSetGlobal, CmdInterfaceWait} {
+			sub := subcommand(name)
+			Expect(sub.RunE).NotTo(BeNil(), name)
+			Expect(sub.Flags().Lookup("interface-id")).NotTo(BeNil(), name)
+		}
+		Expect(subcommand(CmdInterfaceWait).Flags().Lookup("timeout")).NotTo(BeNil())
+		Expect(subcommand(CmdInterfaceUp).Flags().Lookup("wait")).NotTo(BeNil())
+		Expect(subcommand(CmdInterfaceSetGlobal).Flags().Lookup("enabled")).NotTo(BeNil())
+	})
+
+	It("should bring an interface down and up again", func() {
+		cmd := subcommand(CmdInterfaceDown)
+		Expect(cmd.Flags().Set("interface-id", "Wireguard0")).To(Succeed())
+		_, err := captureOutput(cmd, []string{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(currentState("Wireguard0")).To(Equal(gokeenrestapi.StateDown))
+
+		cmd = subcommand(CmdInterfaceUp)
+		Expect(cmd.Flags().Set("interface-id", "Wireguard0")).To(Succeed())
+		Expect(cmd.Flags().Set("wait", "true")).To(Succeed())
+		Expect(cmd.Flags().Set("timeout", "5s")).To(Succeed())
+		_, err = captureOutput(cmd, []string{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(currentState("Wireguard0")).To(Equal(gokeenrestapi.StateUp))
+	})
+
+	It("should change the global IP setting", func() {
+		cmd := subcommand(CmdInterfaceSetGlobal)
+		Expect(cmd.Flags().Set("interface-id", "Wireguard0")).To(Succeed())
+		Expect(cmd.Flags().Set("enabled", "false")).To(Succeed())
+		output, err := captureOutput(cmd, []string{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(output).To(ContainSubstring("false"))
+	})
+
+	It("should succeed waiting for a connected interface", func() {
+		cmd := subcommand(CmdInterfaceWait)
+		Expect(cmd.Flags().Set("interface-id", "Wireguard0")).To(Succeed())
+		output, err := captureOutput(cmd, []string{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(output).To(ContainSubstring("is up and connected"))
+	})
+
+	It("should fail waiting for a disconnected interface", func() {
+		cmd := subcommand(CmdInterfaceWait)
+		Expect(cmd.Flags().Set("interface-id", "Wireguard1")).To(Succeed())
+		Expect(cmd.Flags().Set("timeout", "1s")).To(Succeed())
+		_, err := captureOutput(cmd, []string{})
+		Expect(err).To(MatchError(ContainSubstring("interface Wireguard1 is still not up")))
+	})
+
+	It("should require an existing interface", func() {
+		cmd := subcommand(CmdInterfaceDown)
+		Expect(cmd.RunE(cmd, []string{})).To(MatchError("--interface-id flag is required"))
+
+		Expect(cmd.Flags().Set("interface-id", "Wireguard9")).To(Succeed())
+		Expect(cmd.RunE(cmd, []string{})).To(MatchError(ContainSubstring("doesn't have interface with id 'Wireguard9'")))
+	})
+})
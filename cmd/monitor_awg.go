@@ -0,0 +1,204 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/noksa/gokeenapi/internal/gokeenlog"
+	"github.com/noksa/gokeenapi/pkg/gokeenrestapi"
+	"github.com/spf13/cobra"
+)
+
+func newMonitorAwgCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     CmdMonitorAwg,
+		Aliases: AliasesMonitorAwg,
+		Short:   "Check WireGuard tunnels for stale peers",
+		Long: `Monitor the health of WireGuard (AWG) tunnels on your Keenetic (Netcraze) router.
+
+A tunnel can be up but dead. For every peer of enabled WireGuard interfaces the command
+reads the last handshake and rx/tx counters and flags the peer as stale when:
+- it has never completed a handshake
+- the last handshake is older than --stale-after
+- nothing was received from it
+
+WireGuard renews the handshake every 2 minutes only while traffic flows. An idle tunnel
+without persistent keepalive stops handshaking and is reported as stale as well.
+
+By default the command checks once and exits with a non-zero code if any peer is stale,
+which is handy for cron jobs and external monitoring. With --watch it keeps checking
+every --interval until interrupted. With --restart an interface is brought down and up
+again when all of its peers are stale, so one offline client of a server-style interface
+doesn't disconnect the others. The same interface is not restarted again within
+--restart-cooldown. The restart is not saved to the router's startup config.
+
+Examples:
+  # Check all WireGuard interfaces once
+  gokeenapi monitor-awg --config config.yaml
+
+  # Check a single interface and print the result as JSON
+  gokeenapi monitor-awg --config config.yaml --interface-id Wireguard0 --output json
+
+  # Keep watching and restart dead tunnels
+  gokeenapi monitor-awg --config config.yaml --watch --interval 1m --restart`,
+	}
+
+	var interfaceId, output string
+	var staleAfter, interval, restartCooldown time.Duration
+	var watch, restart bool
+	cmd.Flags().StringVar(&interfaceId, "interface-id", "",
+		`WireGuard interface ID to check. If not specified, all WireGuard interfaces are checked.`)
+	cmd.Flags().DurationVar(&staleAfter, "stale-after", 3*time.Minute, "Handshake age after which a peer is considered stale")
+	cmd.Flags().BoolVar(&watch, "watch", false, "Keep checking every --interval until interrupted")
+	cmd.Flags().DurationVar(&interval, "interval", time.Minute, "How often to check in --watch mode")
+	cmd.Flags().BoolVar(&restart, "restart", false, "Bring interfaces whose peers are all stale down and up again")
+	cmd.Flags().DurationVar(&restartCooldown, "restart-cooldown", 10*time.Minute, "Minimum time between restarts of the same interface")
+	addOutputFlag(cmd, &output, OutputTable, OutputJSON)
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if err := validateOutputFormat(output, OutputTable, OutputJSON); err != nil {
+			return err
+		}
+		if staleAfter <= 0 {
+			return errors.New("--stale-after must be positive")
+		}
+		if watch && interval < time.Second {
+			return errors.New("--interval must be at least 1 second")
+		}
+		if restartCooldown <= 0 {
+			return errors.New("--restart-cooldown must be positive")
+		}
+		var interfaceIds []string
+		if interfaceId != "" {
+			interfaceIds = append(interfaceIds, interfaceId)
+		}
+
+		lastRestart := make(map[string]time.Time)
+		check := func() (int, error) {
+			var peers []gokeenrestapi.AwgPeerHealth
+			run := func() error {
+				var err error
+				peers, err = gokeenrestapi.AwgConf.GetPeerHealth(staleAfter, interfaceIds...)
+				if err != nil {
+					return err
+				}
+				if output == OutputTable {
+					printAwgPeerHealth(peers)
+				}
+				if restart {
+					return restartAwgInterfaces(staleAwgInterfaces(peers), lastRestart, restartCooldown)
+				}
+				return nil
+			}
+
+			if output == OutputJSON {
+				if err := withProgressOnStderr(run); err != nil {
+					return 0, err
+				}
+				if peers == nil {
+					peers = []gokeenrestapi.AwgPeerHealth{}
+				}
+				if err := printJSON(peers); err != nil {
+					return 0, err
+				}
+			} else if err := run(); err != nil {
+				return 0, err
+			}
+
+			var staleCount int
+			for _, peer := range peers {
+				if peer.Stale() {
+					staleCount++
+				}
+			}
+			return staleCount, nil
+		}
+
+		if !watch {
+			staleCount, err := check()
+			if err != nil {
+				return err
+			}
+			if staleCount > 0 {
+				return fmt.Errorf("%d stale WireGuard peer(s)", staleCount)
+			}
+			return nil
+		}
+
+		ctx := commandContext(cmd)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			// A failed check must not stop monitoring, the router may be temporarily unreachable
+			if _, err := check(); err != nil {
+				gokeenlog.Infof("⚠️  %s: %v", color.YellowString("WARNING"), err)
+			}
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+			}
+		}
+	}
+	return cmd
+}
+
+// printAwgPeerHealth displays peer health to the console
+func printAwgPeerHealth(peers []gokeenrestapi.AwgPeerHealth) {
+	if len(peers) == 0 {
+		gokeenlog.Info("No WireGuard peers to check")
+		return
+	}
+	for _, peer := range peers {
+		status := color.GreenString("OK")
+		if peer.Stale() {
+			status = color.RedString("STALE") + " (" + peer.Problem + ")"
+		}
+		handshake := "never"
+		if peer.LastHandshake >= 0 {
+			handshake = (time.Duration(peer.LastHandshake) * time.Second).String() + " ago"
+		}
+		remote := peer.Remote
+		if remote == "" {
+			remote = "-"
+		}
+		gokeenlog.Infof("%v peer %v: %v", color.CyanString(peer.InterfaceID), color.BlueString(peer.PublicKey), status)
+		gokeenlog.InfoSubStepf("Remote: %v, handshake: %v, rx: %v, tx: %v",
+			remote, handshake, formatBytes(peer.RxBytes), formatBytes(peer.TxBytes))
+	}
+}
+
+// staleAwgInterfaces returns IDs of interfaces whose peers are all stale in the order of peers
+func staleAwgInterfaces(peers []gokeenrestapi.AwgPeerHealth) []string {
+	var interfaceIds []string
+	healthy := make(map[string]bool)
+	for _, peer := range peers {
+		if _, seen := healthy[peer.InterfaceID]; !seen {
+			interfaceIds = append(interfaceIds, peer.InterfaceID)
+		}
+		healthy[peer.InterfaceID] = healthy[peer.InterfaceID] || !peer.Stale()
+	}
+	return slices.DeleteFunc(interfaceIds, func(interfaceId string) bool {
+		return healthy[interfaceId]
+	})
+}
+
+// restartAwgInterfaces brings every interface down and up again unless it was restarted within cooldown.
+// lastRestart keeps restart times between checks
+func restartAwgInterfaces(interfaceIds []string, lastRestart map[string]time.Time, cooldown time.Duration) error {
+	for _, interfaceId := range interfaceIds {
+		if last, ok := lastRestart[interfaceId]; ok && time.Since(last) < cooldown {
+			gokeenlog.Infof("Skipping restart of %v interface, it was restarted %v ago",
+				color.CyanString(interfaceId), time.Since(last).Round(time.Second))
+			continue
+		}
+		if err := gokeenrestapi.Interface.RestartInterface(interfaceId); err != nil {
+			return err
+		}
+		lastRestart[interfaceId] = time.Now()
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"time"
+
+	"github.com/noksa/gokeenapi/pkg/gokeenrestapi"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("MonitorAwg", func() {
+	const peerKey = "gN65BkIKy1eCE9pP1wdc8ROUunkiVXrBvGAKBEKdOQI="
+
+	var server *httptest.Server
+
+	setup := func(lastHandshake int64) {
+		server = setupMockRouter(
+			gokeenrestapi.WithInterfaces([]gokeenrestapi.MockInterface{{
+				ID: "Wireguard0", Type: gokeenrestapi.InterfaceTypeWireguard,
+				Connected: gokeenrestapi.StateConnected, Link: gokeenrestapi.StateUp, State: gokeenrestapi.StateUp,
+				LastHandshake: lastHandshake, RxBytes: 1536, TxBytes: 1024,
+			}}),
+			gokeenrestapi.WithScInterfaces(map[string]gokeenrestapi.MockScInterface{
+				"Wireguard0": {Wireguard: gokeenrestapi.MockWireguard{
+					Peer: []gokeenrestapi.MockPeer{{Key: peerKey, Endpoint: "vpn.example.com:51820"}},
+				}},
+			}),
+		)
+	}
+
+	AfterEach(func() {
+		cleanupMockRouter(server)
+	})
+
+	It("should create command with correct attributes and flags", func() {
+		cmd := newMonitorAwgCmd()
+
+		Expect(cmd.Use).To(Equal(CmdMonitorAwg))
+		Expect(cmd.Aliases).To(Equal(AliasesMonitorAwg))
+		Expect(cmd.Short).NotTo(BeEmpty())
+		Expect(cmd.RunE).NotTo(BeNil())
+		for _, name := range []string{"interface-id", "stale-after", "watch", "interval", "restart", "restart-cooldown", "output"} {
+			Expect(cmd.Flags().Lookup(name)).NotTo(BeNil(), name)
+		}
+	})
+
+	It("should succeed when all peers are healthy", func() {
+		setup(20)
+
+		cmd := newMonitorAwgCmd()
+		output, err := captureOutput(cmd, []string{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(output).To(ContainSubstring("OK"))
+		Expect(output).To(ContainSubstring("rx: 1.5 KiB"))
+	})
+
+	It("should fail in one-shot mode when a peer is stale", func() {
+		setup(600)
+
+		cmd := newMonitorAwgCmd()
+		output, err := captureOutput(cmd, []string{})
+		Expect(err).To(MatchError("1 stale WireGuard peer(s)"))
+		Expect(output).To(ContainSubstring("last handshake 10m0s ago"))
+	})
+
+	It("should restart interfaces with stale peers", func() {
+		setup(600)
+
+		cmd := newMonitorAwgCmd()
+		Expect(cmd.Flags().Set("restart", "true")).To(Succeed())
+		output, err := captureOutput(cmd, []string{})
+		Expect(err).To(HaveOccurred())
+		Expect(output).To(ContainSubstring("Restarting"))
+
+		iface, err := gokeenrestapi.Interface.GetInterfaceViaRciShowInterfaces("Wireguard0")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(iface.State).To(Equal(gokeenrestapi.StateUp))
+	})
+
+	It("should not restart an interface again within the cooldown", func() {
+		setup(600)
+
+		restartedAt := time.Now().Add(-time.Minute)
+		lastRestart := map[string]time.Time{"Wireguard0": restartedAt}
+		Expect(restartAwgInterfaces([]string{"Wireguard0"}, lastRestart, 10*time.Minute)).To(Succeed())
+		Expect(lastRestart["Wireguard0"]).To(Equal(restartedAt))
+
+		Expect(restartAwgInterfaces([]string{"Wireguard0"}, lastRestart, 30*time.Second)).To(Succeed())
+		Expect(lastRestart["Wireguard0"]).To(BeTemporally(">", restartedAt))
+	})
+
+	It("should print peer health as JSON", func() {
+		setup(20)
+
+		cmd := newMonitorAwgCmd()
+		Expect(cmd.Flags().Set("output", OutputJSON)).To(Succeed())
+		output, err := captureOutput(cmd, []string{})
+		Expect(err).NotTo(HaveOccurred())
+
+		var peers []gokeenrestapi.AwgPeerHealth
+		Expect(json.Unmarshal([]byte(output), &peers)).To(Succeed())
+		Expect(peers).To(HaveLen(1))
+		Expect(peers[0].PublicKey).To(Equal(peerKey))
+		Expect(peers[0].Remote).To(Equal("vpn.example.com:51820"))
+		Expect(peers[0].LastHandshake).To(Equal(int64(20)))
+	})
+
+	It("should reject a non-positive --stale-after", func() {
+		setup(20)
+
+		cmd := newMonitorAwgCmd()
+		Expect(cmd.Flags().Set("stale-after", "0s")).To(Succeed())
+		Expect(cmd.RunE(cmd, []string{})).To(MatchError("--stale-after must be positive"))
+	})
+	It("should reject a non-positive --restart-cooldown", func() {
+		setup(20)
+
+		cmd := newMonitorAwgCmd()
+		Expect(cmd.Flags().Set("restart-cooldown", "0s")).To(Succeed())
+		Expect(cmd.RunE(cmd, []string{})).To(MatchError("--restart-cooldown must be positive"))
+	})
+
+	DescribeTable("staleAwgInterfaces",
+		func(peers []gokeenrestapi.AwgPeerHealth, expected []string) {
+			Expect(staleAwgInterfaces(peers)).To(Equal(expected))
+		},
+		Entry("all peers stale", []gokeenrestapi.AwgPeerHealth{
+			{InterfaceID: "Wireguard0", Problem: "no handshake yet"},
+			{InterfaceID: "Wireguard0", Problem: "nothing received"},
+		}, []string{"Wireguard0"}),
+		Entry("one healthy peer keeps the interface", []gokeenrestapi.AwgPeerHealth{
+			{InterfaceID: "Wireguard0", Problem: "no handshake yet"},
+			{InterfaceID: "Wireguard0"},
+			{InterfaceID: "Wireguard1", Problem: "last handshake 10m0s ago"},
+		}, []string{"Wireguard1"}),
+		Entry("no stale peers", []gokeenrestapi.AwgPeerHealth{
+			{InterfaceID: "Wireguard0"},
+		}, []string{}),
+	)
+})
@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// Output formats for commands supporting --output
+const (
+	OutputTable = "table"
+	OutputJSON  = "json"
+	OutputYAML  = "yaml"
+	OutputCSV   = "csv"
+)
+
+// addOutputFlag registers the --output flag with the given supported formats, the first one is the default
+func addOutputFlag(cmd *cobra.Command, output *string, formats ...string) {
+	cmd.Flags().StringVarP(output, "output", "o", formats[0],
+		fmt.Sprintf("Output format. One of: %s.", strings.Join(formats, ", ")))
+}
+
+// validateOutputFormat checks that output is one of the supported formats
+func validateOutputFormat(output string, formats ...string) error {
+	if !slices.Contains(formats, output) {
+		return fmt.Errorf("unsupported output format '%s': must be one of %s", output, strings.Join(formats, ", "))
+	}
+	return nil
+}
+
+// isStructuredOutput reports whether cmd was asked for machine-readable output via --output
+func isStructuredOutput(cmd *cobra.Command) bool {
+	flag := cmd.Flags().Lookup("output")
+	return flag != nil && flag.Value.String() != OutputTable
+}
+
+// withProgressOnStderr runs f with stdout redirected to stderr, so spinners and logs
+// do not mix with machine-readable output that is printed afterwards
+func withProgressOnStderr(f func() error) error {
+	stdout := os.Stdout
+	os.Stdout = os.Stderr
+	defer func() { os.Stdout = stdout }()
+	return f()
+}
+
+// printJSON prints v to stdout as indented JSON
+func printJSON(v any) error {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode output: %w", err)
+	}
+	_, err = fmt.Fprintln(os.Stdout, string(b))
+	return err
+}
+
+// printYAML prints v to stdout as YAML
+func printYAML(v any) error {
+	b, err := yaml.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode output: %w", err)
+	}
+	_, err = fmt.Fprint(os.Stdout, string(b))
+	return err
+}
+
+// printCSV prints a header and rows to stdout as CSV.
+// Fields are escaped so that spreadsheets don't evaluate them as formulas
+func printCSV(header []string, rows [][]string) error {
+	w := csv.NewWriter(os.Stdout)
+	if err := w.Write(csvSafeRecord(header)); err != nil {
+		return fmt.Errorf("failed to encode output: %w", err)
+	}
+	for _, row := range rows {
+		if err := w.Write(csvSafeRecord(row)); err != nil {
+			return fmt.Errorf("failed to encode output: %w", err)
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return fmt.Errorf("failed to encode output: %w", err)
+	}
+	return nil
+}
+
+// csvSafeRecord returns a copy of record with every field that starts with =, +, -, @, a tab or
+// a carriage return prefixed with a single quote. Host names come from the network and could
+// otherwise inject spreadsheet formulas
+func csvSafeRecord(record []string) []string {
+	safe := make([]string, len(record))
+	for i, field := range record {
+		if field != "" && strings.ContainsRune("=+-@\t\r", rune(field[0])) {
+			field = "'" + field
+		}
+		safe[i] = field
+	}
+	return safe
+}
+
+// formatBytes renders a byte count with a binary unit, e.g. 1.5 MiB
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/noksa/gokeenapi/internal/gokeenlog"
+	"github.com/noksa/gokeenapi/pkg/config"
+	"github.com/noksa/gokeenapi/pkg/gokeenrestapi"
+	"github.com/spf13/cobra"
+)
+
+func newPruneDnsRoutingCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     CmdPruneDnsRouting,
+		Aliases: AliasesPruneDnsRouting,
+		Short:   "Remove DNS-routing groups that are no longer defined in config",
+		Long: `Remove orphaned DNS-routing rules from your Keenetic (Netcraze) router.
+
+When a group is renamed or removed from 'dns.routes.groups', its object-group and
+dns-proxy route stay on the router. This command finds such leftovers and removes them.
+
+Only groups whose name starts with the prune prefix are considered, so groups created
+by hand stay safe. The prefix is taken from 'dns.routes.prunePrefix' in config or from
+the --prune-prefix flag.
+
+The command will:
+1. Check router firmware version (requires 5.0.1+)
+2. Fetch DNS-routing groups and dns-proxy routes from the router
+3. List groups that match the prefix but are not defined in config
+4. Ask for confirmation (unless --force is used)
+5. Remove dns-proxy routes first, then object-groups
+6. Save router configuration
+
+Examples:
+  # Prune groups using prefix from config
+  gokeenapi prune-dns-routing --config config.yaml
+
+  # Prune groups with an explicit prefix
+  gokeenapi prune-dns-routing --config config.yaml --prune-prefix gk-
+
+  # Prune without confirmation prompt
+  gokeenapi prune-dns-routing --config config.yaml --force
+
+Requirements: Keenetic firmware version 5.0.1 or higher`,
+	}
+
+	var prefix string
+	var force bool
+	cmd.Flags().StringVar(&prefix, "prune-prefix", "",
+		`Only prune router groups whose name starts with this prefix.
+Overrides 'dns.routes.prunePrefix' from the config file.`)
+	cmd.Flags().BoolVar(&force, "force", false,
+		`Skip confirmation prompt and prune DNS-routing rules immediately.
+Use with caution as this bypasses the safety confirmation.`)
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		return pruneDnsRouting(prefix, force)
+	}
+
+	return cmd
+}
+
+// pruneDnsRouting removes router groups matching the prune prefix that are not defined in config
+// An empty prefix falls back to 'dns.routes.prunePrefix'
+func pruneDnsRouting(prefix string, force bool) error {
+	if prefix == "" {
+		prefix = config.Cfg.DNS.Routes.PrunePrefix
+	}
+
+	orphans, err := gokeenrestapi.DnsRouting.FindOrphanedDnsRoutingGroups(config.Cfg.DNS.Routes.Groups, prefix)
+	if err != nil {
+		return err
+	}
+
+	if len(orphans) == 0 {
+		gokeenlog.Infof("No orphaned DNS-routing groups found with prefix %v", color.CyanString(prefix))
+		return nil
+	}
+
+	for _, group := range orphans {
+		routeInterface := group.InterfaceID
+		if routeInterface == "" {
+			routeInterface = "none"
+		}
+		gokeenlog.InfoSubStepf("Orphaned DNS-routing group to delete: %v (interface: %v)",
+			color.CyanString(group.Name),
+			color.YellowString(routeInterface))
+	}
+
+	if !force {
+		confirmMsg := fmt.Sprintf("\nFound %v orphaned DNS-routing group(s) to delete. Do you want to continue?",
+			color.CyanString("%v", len(orphans)))
+		confirmed, err := confirmAction(confirmMsg)
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			gokeenlog.Info("Pruning cancelled")
+			return nil
+		}
+	}
+
+	return gokeenrestapi.DnsRouting.DeleteDnsRoutingGroups(orphans)
+}
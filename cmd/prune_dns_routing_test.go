@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"net/http/httptest"
+	"os"
+
+	"github.com/noksa/gokeenapi/pkg/config"
+	"github.com/noksa/gokeenapi/pkg/gokeenrestapi"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func setupPruneDnsRoutingMockRouter() *httptest.Server {
+	return setupMockRouter(
+		gokeenrestapi.WithVersion("5.0.1"),
+		gokeenrestapi.WithDnsRoutingGroups(
+			[]gokeenrestapi.MockDnsRoutingGroup{
+				{Name: "gk-social", Domains: []string{"facebook.com"}},
+				{Name: "gk-old", Domains: []string{"old.example.com"}},
+				{Name: "gk-unrouted", Domains: []string{"unrouted.example.com"}},
+				{Name: "manual", Domains: []string{"manual.example.com"}},
+			},
+			[]gokeenrestapi.MockDnsProxyRoute{
+				{GroupName: "gk-social", InterfaceID: "Wireguard0", Mode: "auto"},
+				{GroupName: "gk-old", InterfaceID: "ISP", Mode: "auto"},
+				{GroupName: "manual", InterfaceID: "Wireguard0", Mode: "auto"},
+			},
+		),
+	)
+}
+
+var _ = Describe("PruneDnsRouting", func() {
+	var server *httptest.Server
+
+	BeforeEach(func() {
+		server = setupPruneDnsRoutingMockRouter()
+		config.Cfg.DNS = config.DNS{
+			Routes: config.DnsRoutes{
+				Groups: []config.DnsRoutingGroup{
+					{Name: "gk-social", InterfaceID: "Wireguard0"},
+				},
+				PrunePrefix: "gk-",
+			},
+		}
+	})
+
+	AfterEach(func() {
+		cleanupMockRouter(server)
+	})
+
+	It("should create command with correct attributes and flags", func() {
+		cmd := newPruneDnsRoutingCmd()
+
+		Expect(cmd.Use).To(Equal(CmdPruneDnsRouting))
+		Expect(cmd.Aliases).To(Equal(AliasesPruneDnsRouting))
+		Expect(cmd.Short).NotTo(BeEmpty())
+		Expect(cmd.RunE).NotTo(BeNil())
+
+		forceFlag := cmd.Flags().Lookup("force")
+		Expect(forceFlag).NotTo(BeNil())
+		Expect(forceFlag.DefValue).To(Equal("false"))
+
+		prefixFlag := cmd.Flags().Lookup("prune-prefix")
+		Expect(prefixFlag).NotTo(BeNil())
+		Expect(prefixFlag.DefValue).To(Equal(""))
+	})
+
+	It("should remove orphaned groups matching the prefix only", func() {
+		cmd := newPruneDnsRoutingCmd()
+		_ = cmd.Flags().Set("force", "true")
+		Expect(cmd.RunE(cmd, []string{})).To(Succeed())
+
+		groups, err := gokeenrestapi.DnsRouting.GetExistingDnsRoutingGroups()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(groups).To(HaveKey("gk-social"))
+		Expect(groups).To(HaveKey("manual"))
+		Expect(groups).NotTo(HaveKey("gk-old"))
+		Expect(groups).NotTo(HaveKey("gk-unrouted"))
+
+		routes, err := gokeenrestapi.DnsRouting.GetExistingDnsProxyRoutes()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(routes).To(HaveKey("gk-social"))
+		Expect(routes).To(HaveKey("manual"))
+		Expect(routes).NotTo(HaveKey("gk-old"))
+	})
+
+	It("should prefer --prune-prefix over config", func() {
+		cmd := newPruneDnsRoutingCmd()
+		_ = cmd.Flags().Set("force", "true")
+		_ = cmd.Flags().Set("prune-prefix", "man")
+		Expect(cmd.RunE(cmd, []string{})).To(Succeed())
+
+		groups, err := gokeenrestapi.DnsRouting.GetExistingDnsRoutingGroups()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(groups).NotTo(HaveKey("manual"))
+		Expect(groups).To(HaveKey("gk-old"))
+	})
+
+	It("should refuse to prune without a prefix", func() {
+		config.Cfg.DNS.Routes.PrunePrefix = ""
+
+		cmd := newPruneDnsRoutingCmd()
+		_ = cmd.Flags().Set("force", "true")
+		err := cmd.RunE(cmd, []string{})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("prune prefix is required"))
+
+		groups, err := gokeenrestapi.DnsRouting.GetExistingDnsRoutingGroups()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(groups).To(HaveLen(4))
+	})
+
+	It("should keep groups when confirmation is declined", func() {
+		oldStdin := os.Stdin
+		defer func() { os.Stdin = oldStdin }()
+
+		r, w, _ := os.Pipe()
+		os.Stdin = r
+		go func() {
+			defer func() { _ = w.Close() }()
+			_, _ = w.Write([]byte("n\n"))
+		}()
+
+		cmd := newPruneDnsRoutingCmd()
+		Expect(cmd.RunE(cmd, []string{})).To(Succeed())
+
+		groups, err := gokeenrestapi.DnsRouting.GetExistingDnsRoutingGroups()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(groups).To(HaveLen(4))
+	})
+
+	It("should prune after adding when add-dns-routing --prune is used", func() {
+		tmpDir := GinkgoT().TempDir()
+		domainFile := writeTempFile(tmpDir, "social.txt", "facebook.com\n")
+		config.Cfg.DNS.Routes.Groups = []config.DnsRoutingGroup{
+			{Name: "gk-social", DomainFile: []string{domainFile}, InterfaceID: "Wireguard0"},
+		}
+
+		cmd := newAddDnsRoutingCmd()
+		_ = cmd.Flags().Set("prune", "true")
+		_ = cmd.Flags().Set("force", "true")
+		Expect(cmd.RunE(cmd, []string{})).To(Succeed())
+
+		groups, err := gokeenrestapi.DnsRouting.GetExistingDnsRoutingGroups()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(groups).To(HaveKey("gk-social"))
+		Expect(groups).To(HaveKey("manual"))
+		Expect(groups).NotTo(HaveKey("gk-old"))
+	})
+})
@@ -27,6 +27,8 @@ Key features:
 • Manage DNS records for local domain resolution  
 • Configure WireGuard (AWG) VPN connections
 • Clean up known hosts with pattern matching
+• Keep a declarative device inventory in sync
+• Route specific LAN devices through a VPN via connection policies
 • Execute custom router commands directly
 • Works with both local IP and KeenDNS addresses
 
@@ -52,33 +54,20 @@ Contains router connection details and operation settings.
 Can also be set via GOKEENAPI_CONFIG environment variable.`)
 
 	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
-		// scheduler, completion, help and version commands should run without any checks and init
-		commandsToSkip := []string{CmdCompletion, CmdHelp, CmdScheduler, CmdVersion}
+		// scheduler, completion, help, version and validate-awg commands should run without any checks and init
+		commandsToSkip := []string{CmdCompletion, CmdHelp, CmdScheduler, CmdVersion, CmdValidateAwg}
 		for _, commandToSkip := range commandsToSkip {
 			if strings.Contains(cmd.CommandPath(), commandToSkip) {
 				return nil
 			}
 		}
-		err := config.LoadConfig(configFile)
-		if err != nil {
-			return err
+		// Keep stdout clean for machine-readable output, progress goes to stderr
+		if isStructuredOutput(cmd) {
+			return withProgressOnStderr(func() error {
+				return initCommand(cmd, configFile)
+			})
 		}
-
-		// Apply debug flag from command line (overrides config file)
-		debugFlag, _ := cmd.Flags().GetBool("debug")
-		if debugFlag {
-			config.Cfg.Logs.Debug = true
-		}
-
-		err = checkRequiredFields()
-		if err != nil {
-			return err
-		}
-		gokeenlog.Info("🏗️  Configuration loaded:")
-		gokeenlog.InfoSubStepf("%v: %v", color.BlueString("Keenetic URL"), config.Cfg.Keenetic.URL)
-		gokeenlog.InfoSubStepf("%v: %v", color.BlueString("Config"), color.CyanString(configFile))
-		gokeenlog.HorizontalLine()
-		return gokeenrestapi.Common.Auth()
+		return initCommand(cmd, configFile)
 	}
 
 	rootCmd.AddCommand(
@@ -86,16 +75,56 @@ Can also be set via GOKEENAPI_CONFIG environment variable.`)
 		newDeleteRoutesCmd(),
 		newDeleteAllRoutesCmd(),
 		newShowInterfacesCmd(),
+		newStatsInterfacesCmd(),
 		newUpdateAwgCmd(),
 		newAddAwgCmd(),
+		newCreateAwgCmd(),
+		newExportAwgCmd(),
+		newDeleteAwgCmd(),
+		newSyncAwgCmd(),
+		newValidateAwgCmd(),
+		newRotateAwgCmd(),
+		newMonitorAwgCmd(),
+		newInterfaceCmd(),
 		newAddDnsRecordsCmd(),
 		newDeleteDnsRecordsCmd(),
 		newAddDnsRoutingCmd(),
 		newDeleteDnsRoutingCmd(),
+		newPruneDnsRoutingCmd(),
+		newExportDnsRoutingCmd(),
+		newShowDnsRoutingCmd(),
+		newShowHostsCmd(),
 		newDeleteKnownHostsCmd(),
+		newSyncHostsCmd(),
+		newSyncPoliciesCmd(),
+		newDeletePoliciesCmd(),
 		newExecCmd(),
 		newSchedulerCmd(),
 		newVersionCmd(),
 	)
 	return rootCmd
 }
+
+// initCommand loads config, applies global flags and authenticates on the router
+func initCommand(cmd *cobra.Command, configFile string) error {
+	err := config.LoadConfig(configFile)
+	if err != nil {
+		return err
+	}
+
+	// Apply debug flag from command line (overrides config file)
+	debugFlag, _ := cmd.Flags().GetBool("debug")
+	if debugFlag {
+		config.Cfg.Logs.Debug = true
+	}
+
+	err = checkRequiredFields()
+	if err != nil {
+		return err
+	}
+	gokeenlog.Info("🏗️  Configuration loaded:")
+	gokeenlog.InfoSubStepf("%v: %v", color.BlueString("Keenetic URL"), config.Cfg.Keenetic.URL)
+	gokeenlog.InfoSubStepf("%v: %v", color.BlueString("Config"), color.CyanString(configFile))
+	gokeenlog.HorizontalLine()
+	return gokeenrestapi.Common.Auth()
+}
@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/noksa/gokeenapi/internal/gokeenlog"
+	"github.com/noksa/gokeenapi/pkg/gokeenrestapi"
+	"github.com/spf13/cobra"
+)
+
+func newRotateAwgCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     CmdRotateAwg,
+		Aliases: AliasesRotateAwg,
+		Short:   "Rotate AmneziaWG obfuscation parameters of a WireGuard interface",
+		Long: `Replace AmneziaWG (AWG) obfuscation parameters (Jc, Jmin, Jmax, S1, S2, H1-H4,
+plus S3 and S4 for AWG 2.0) of a WireGuard interface with a fresh random valid set.
+Static parameters can be fingerprinted by DPI, rotating them regularly makes it harder.
+
+The command will:
+1. Generate a new parameter set within the protocol constraints
+2. Publish it to the paired server with --hook and/or --drop-file
+3. Apply it on the router
+4. Wait for a new handshake within --timeout
+5. Restore the previous set on the router and the server if the handshake does not come back
+
+The hook is run with 'sh -c'. The parameters are passed on stdin in .conf format and as
+environment variables GOKEENAPI_AWG_JC, GOKEENAPI_AWG_JMIN, ... together with
+GOKEENAPI_AWG_INTERFACE and GOKEENAPI_AWG_ROLLBACK (true when restoring the previous set).
+A non-zero exit code of the hook or running longer than --hook-timeout aborts the
+rotation before the router is changed.
+
+The drop file receives the same parameter lines and is replaced atomically.
+
+Signature packets I1-I5 are kept as is.
+
+Examples:
+  # Rotate parameters and update the server over SSH
+  gokeenapi rotate-awg --config config.yaml --interface-id Wireguard0 \
+    --hook 'ssh vpn.example.com /usr/local/bin/apply-awg-params'
+
+  # Drop the parameters into a file watched by the server
+  gokeenapi rotate-awg --config config.yaml --interface-id Wireguard0 --drop-file /srv/awg/wg0.params
+
+  # Only print a new parameter set
+  gokeenapi rotate-awg --config config.yaml --interface-id Wireguard0 --dry-run`,
+	}
+
+	var interfaceId, hook, dropFile string
+	var timeout, hookTimeout time.Duration
+	var awg2, dryRun bool
+	cmd.Flags().StringVar(&interfaceId, "interface-id", "", "ID of the WireGuard interface to rotate")
+	cmd.Flags().StringVar(&hook, "hook", "", "Shell command that publishes the parameters to the paired server")
+	cmd.Flags().StringVar(&dropFile, "drop-file", "", "File to write the parameters to for the paired server")
+	cmd.Flags().DurationVar(&timeout, "timeout", 2*time.Minute, "How long to wait for a new handshake before rolling back")
+	cmd.Flags().DurationVar(&hookTimeout, "hook-timeout", time.Minute, "How long the hook may run before it is killed")
+	cmd.Flags().BoolVar(&awg2, "awg2", false, "Also rotate AWG 2.0 parameters S3 and S4 (KeeneticOS 5.1+)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Only print a new parameter set, do not publish or apply it")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if interfaceId == "" {
+			return errors.New("--interface-id flag is required")
+		}
+		if timeout <= 0 {
+			return errors.New("--timeout must be positive")
+		}
+		if hookTimeout <= 0 {
+			return errors.New("--hook-timeout must be positive")
+		}
+		if hook == "" && dropFile == "" && !dryRun {
+			gokeenlog.Infof("⚠️  %s: neither --hook nor --drop-file is set, the paired server must be updated manually",
+				color.YellowString("WARNING"))
+		}
+
+		params, err := gokeenrestapi.AwgConf.RotateASC(interfaceId, gokeenrestapi.AwgRotateOptions{
+			AWG2:    awg2,
+			Timeout: timeout,
+			DryRun:  dryRun,
+			Publish: func(params gokeenrestapi.AwgParams, rollback bool) error {
+				if dropFile != "" {
+					if err := dropAwgParams(dropFile, params); err != nil {
+						return err
+					}
+				}
+				if hook != "" {
+					return runAwgHook(hook, interfaceId, params, rollback, hookTimeout)
+				}
+				return nil
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		if dryRun {
+			gokeenlog.Info("Dry-run mode: new AWG parameters (not applied)")
+		} else {
+			gokeenlog.Infof("Rotated AWG parameters of %v", color.CyanString(interfaceId))
+		}
+		fmt.Print(params.Stanza)
+		return nil
+	}
+
+	return cmd
+}
+
+// dropAwgParams atomically replaces path with the parameter lines
+func dropAwgParams(path string, params gokeenrestapi.AwgParams) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-")
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err := tmp.WriteString(params.Stanza); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	gokeenlog.InfoSubStepf("Wrote AWG parameters to %v", color.CyanString(path))
+	return nil
+}
+
+// runAwgHook runs the hook with the parameters on stdin and in GOKEENAPI_AWG_* environment variables
+func runAwgHook(hook, interfaceId string, params gokeenrestapi.AwgParams, rollback bool, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	names := make([]string, 0, len(params.Values))
+	for name := range params.Values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	env := append(os.Environ(),
+		"GOKEENAPI_AWG_INTERFACE="+interfaceId,
+		"GOKEENAPI_AWG_ROLLBACK="+strconv.FormatBool(rollback),
+	)
+	for _, name := range names {
+		env = append(env, "GOKEENAPI_AWG_"+strings.ToUpper(name)+"="+params.Values[name])
+	}
+
+	hookCmd := exec.CommandContext(ctx, "sh", "-c", hook)
+	hookCmd.Env = env
+	hookCmd.Stdin = strings.NewReader(params.Stanza)
+	hookCmd.Stdout = os.Stdout
+	hookCmd.Stderr = os.Stderr
+
+	gokeenlog.InfoSubStepf("Running hook %v", color.CyanString(hook))
+	if err := hookCmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return fmt.Errorf("hook failed: did not finish within %v", timeout)
+		}
+		return fmt.Errorf("hook failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	"github.com/noksa/gokeenapi/pkg/gokeenrestapi"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RotateAwg", func() {
+	var server *httptest.Server
+
+	BeforeEach(func() {
+		server = setupMockRouter(gokeenrestapi.WithScInterfaces(map[string]gokeenrestapi.MockScInterface{
+			"Wireguard0": {
+				IP: gokeenrestapi.MockIP{Address: "10.0.0.1/24"},
+				Wireguard: gokeenrestapi.MockWireguard{
+					Asc: gokeenrestapi.MockAsc{Jc: "3", Jmin: "50", Jmax: "1000", S1: "86", S2: "3", H1: "1", H2: "2", H3: "3", H4: "4"},
+					Peer: []gokeenrestapi.MockPeer{
+						{Key: "gN65BkIKy1eCE9pP1wdc8ROUunkiVXrBvGAKBEKdOQI=", Endpoint: "vpn.example.com:51820"},
+					},
+				},
+			},
+		}))
+	})
+
+	AfterEach(func() {
+		cleanupMockRouter(server)
+	})
+
+	It("should create command with correct attributes and flags", func() {
+		cmd := newRotateAwgCmd()
+
+		Expect(cmd.Use).To(Equal(CmdRotateAwg))
+		Expect(cmd.Aliases).To(Equal(AliasesRotateAwg))
+		Expect(cmd.Short).NotTo(BeEmpty())
+		Expect(cmd.RunE).NotTo(BeNil())
+		Expect(cmd.Flags().Lookup("interface-id")).NotTo(BeNil())
+		Expect(cmd.Flags().Lookup("hook")).NotTo(BeNil())
+		Expect(cmd.Flags().Lookup("drop-file")).NotTo(BeNil())
+		Expect(cmd.Flags().Lookup("timeout").DefValue).To(Equal("2m0s"))
+		Expect(cmd.Flags().Lookup("hook-timeout").DefValue).To(Equal("1m0s"))
+		Expect(cmd.Flags().Lookup("dry-run")).NotTo(BeNil())
+	})
+
+	It("should fail when interface-id is missing", func() {
+		cmd := newRotateAwgCmd()
+
+		err := cmd.RunE(cmd, []string{})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("--interface-id flag is required"))
+	})
+
+	It("should publish the new parameters through the hook and the drop file", func() {
+		tmpDir := GinkgoT().TempDir()
+		dropFile := filepath.Join(tmpDir, "wg0.params")
+		hookOutput := filepath.Join(tmpDir, "hook.out")
+
+		cmd := newRotateAwgCmd()
+		_ = cmd.Flags().Set("interface-id", "Wireguard0")
+		_ = cmd.Flags().Set("timeout", "5s")
+		_ = cmd.Flags().Set("drop-file", dropFile)
+		_ = cmd.Flags().Set("hook", `{ echo "$GOKEENAPI_AWG_INTERFACE $GOKEENAPI_AWG_ROLLBACK Jc=$GOKEENAPI_AWG_JC"; cat; } > `+hookOutput)
+
+		output, err := captureOutput(cmd, []string{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(output).To(ContainSubstring("Rotated AWG parameters of"))
+
+		conf, _, err := gokeenrestapi.AwgConf.ExportConf("Wireguard0")
+		Expect(err).NotTo(HaveOccurred())
+
+		dropped, err := os.ReadFile(dropFile)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(conf).To(ContainSubstring(string(dropped)))
+		Expect(string(dropped)).NotTo(ContainSubstring("Jmax = 1000\n"))
+
+		hooked, err := os.ReadFile(hookOutput)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(hooked)).To(HavePrefix("Wireguard0 false Jc="))
+		Expect(string(hooked)).To(HaveSuffix(string(dropped)))
+	})
+
+	It("should not change the router when the hook fails", func() {
+		cmd := newRotateAwgCmd()
+		_ = cmd.Flags().Set("interface-id", "Wireguard0")
+		_ = cmd.Flags().Set("hook", "exit 3")
+
+		err := cmd.RunE(cmd, []string{})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("hook failed"))
+
+		conf, _, err := gokeenrestapi.AwgConf.ExportConf("Wireguard0")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(conf).To(ContainSubstring("Jmax = 1000\n"))
+	})
+	It("should abort the rotation when the hook runs longer than --hook-timeout", func() {
+		cmd := newRotateAwgCmd()
+		_ = cmd.Flags().Set("interface-id", "Wireguard0")
+		_ = cmd.Flags().Set("hook", "exec sleep 5")
+		_ = cmd.Flags().Set("hook-timeout", "100ms")
+
+		err := cmd.RunE(cmd, []string{})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("did not finish within 100ms"))
+
+		conf, _, err := gokeenrestapi.AwgConf.ExportConf("Wireguard0")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(conf).To(ContainSubstring("Jmax = 1000\n"))
+	})
+})
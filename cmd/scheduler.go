@@ -6,6 +6,7 @@ import (
 	"os"
 	"os/exec"
 	"slices"
+	"strings"
 	"sync"
 	"time"
 
@@ -54,6 +55,17 @@ Examples:
       times:
         - "02:00"
 
+    - name: "Bounce the tunnel nightly"
+      commands:
+        - interface down --interface-id Wireguard0
+        - interface up --interface-id Wireguard0 --wait --timeout 2m
+      configs:
+        - /path/to/router1.yaml
+      times:
+        - "04:00"
+
+A command may include subcommands and flags separated by spaces, --config is appended automatically.
+
 Supported interval formats: "30m", "1h", "2h30m", "24h"
 Supported time format: "HH:MM" (24-hour format)
 
@@ -135,6 +147,11 @@ func validateTask(task config.ScheduledTask) error {
 	if len(task.Commands) == 0 {
 		return fmt.Errorf("at least one command is required")
 	}
+	for _, command := range task.Commands {
+		if strings.TrimSpace(command) == "" {
+			return fmt.Errorf("commands must not be empty")
+		}
+	}
 	if len(task.Configs) == 0 {
 		return fmt.Errorf("at least one config is required")
 	}
@@ -302,6 +319,12 @@ func executeTask(ctx context.Context, task config.ScheduledTask) {
 	}
 }
 
+// commandArgs splits a task command into arguments, so commands may carry subcommands and flags
+// (e.g. "interface wait --interface-id Wireguard0"), and appends the router config
+func commandArgs(command, configPath string) []string {
+	return append(strings.Fields(command), "--config", configPath)
+}
+
 func executeCommandsForConfig(ctx context.Context, task config.ScheduledTask, configPath string) error {
 	retryDelay := 1 * time.Minute
 	if task.RetryDelay != "" {
@@ -327,7 +350,7 @@ func executeCommandsForConfig(ctx context.Context, task config.ScheduledTask, co
 			}
 
 			gokeenlog.Info(attemptMsg)
-			cmd := exec.CommandContext(ctx, executable, command, "--config", configPath)
+			cmd := exec.CommandContext(ctx, executable, commandArgs(command, configPath)...)
 			cmd.Stdout = os.Stdout
 			cmd.Stderr = os.Stderr
 			lastErr = cmd.Run()
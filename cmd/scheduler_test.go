@@ -21,6 +21,14 @@ var _ = Describe("Scheduler", func() {
 		Expect(cmd.RunE).NotTo(BeNil())
 	})
 
+	Describe("commandArgs", func() {
+		It("should split subcommands and flags and append the config", func() {
+			Expect(commandArgs("interface  up --interface-id Wireguard0", "router.yaml")).To(Equal(
+				[]string{"interface", "up", "--interface-id", "Wireguard0", "--config", "router.yaml"}))
+			Expect(commandArgs("add-routes", "router.yaml")).To(Equal([]string{"add-routes", "--config", "router.yaml"}))
+		})
+	})
+
 	Describe("validateTask", func() {
 		It("should accept valid task", func() {
 			task := config.ScheduledTask{
@@ -50,6 +58,14 @@ var _ = Describe("Scheduler", func() {
 			Expect(err.Error()).To(ContainSubstring("at least one command is required"))
 		})
 
+		It("should reject blank commands", func() {
+			task := config.ScheduledTask{
+				Name: "Test task", Commands: []string{"add-routes", "  "},
+				Configs: []string{"/path/to/config.yaml"}, Interval: "1h",
+			}
+			Expect(validateTask(task)).To(MatchError("commands must not be empty"))
+		})
+
 		It("should reject missing configs", func() {
 			task := config.ScheduledTask{
 				Name: "Test task", Commands: []string{"add-routes"},
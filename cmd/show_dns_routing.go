@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"github.com/fatih/color"
+	"github.com/noksa/gokeenapi/internal/gokeenlog"
+	"github.com/noksa/gokeenapi/pkg/config"
+	"github.com/noksa/gokeenapi/pkg/gokeenrestapi"
+	"github.com/spf13/cobra"
+)
+
+func newShowDnsRoutingCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     CmdShowDnsRouting,
+		Aliases: AliasesShowDnsRouting,
+		Short:   "Show DNS-routing groups on the router and their status",
+		Long: `Display DNS-routing groups configured on your Keenetic (Netcraze) router.
+
+This is a read-only view that joins object-groups, dns-proxy routes and interface
+state. For each group it shows the number of domains, the target interface, whether
+that interface is up, and how the group compares with 'dns.routes.groups' in config:
+
+- in-sync:   group, route and domains match config
+- drifted:   group exists but its route or domains differ from config
+- unmanaged: group exists on the router but is not defined in config
+- missing:   group is defined in config but not found on the router
+
+Examples:
+  # Show DNS-routing status
+  gokeenapi show-dns-routing --config config.yaml
+
+  # Show DNS-routing status as JSON
+  gokeenapi show-dns-routing --config config.yaml --output json
+
+Requirements: Keenetic firmware version 5.0.1 or higher`,
+	}
+
+	var output string
+	addOutputFlag(cmd, &output, OutputTable, OutputJSON)
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if err := validateOutputFormat(output, OutputTable, OutputJSON); err != nil {
+			return err
+		}
+
+		var statuses []gokeenrestapi.DnsRoutingGroupStatus
+		getStatus := func() error {
+			var err error
+			statuses, err = gokeenrestapi.DnsRouting.GetDnsRoutingStatus(config.Cfg.DNS.Routes.Groups)
+			return err
+		}
+
+		if output == OutputJSON {
+			if err := withProgressOnStderr(getStatus); err != nil {
+				return err
+			}
+			if statuses == nil {
+				statuses = []gokeenrestapi.DnsRoutingGroupStatus{}
+			}
+			return printJSON(statuses)
+		}
+
+		if err := getStatus(); err != nil {
+			return err
+		}
+		printDnsRoutingStatus(statuses)
+		return nil
+	}
+
+	return cmd
+}
+
+// printDnsRoutingStatus displays DNS-routing group statuses to the console
+func printDnsRoutingStatus(statuses []gokeenrestapi.DnsRoutingGroupStatus) {
+	if len(statuses) == 0 {
+		gokeenlog.Info("No DNS-routing groups found on router or in config")
+		return
+	}
+
+	for _, status := range statuses {
+		gokeenlog.Infof("DNS-routing group '%v': %v", color.BlueString(status.Name), colorDnsRoutingStatus(status.Status))
+
+		interfaceState := color.RedString("down")
+		if status.InterfaceUp {
+			interfaceState = color.GreenString("up")
+		}
+		if status.InterfaceID == "" {
+			gokeenlog.InfoSubStepf("Interface: %v", color.RedString("none"))
+		} else {
+			gokeenlog.InfoSubStepf("Interface: %v (%v)", color.CyanString(status.InterfaceID), interfaceState)
+		}
+		gokeenlog.InfoSubStepf("Domains: %v", color.CyanString("%d", status.DomainCount))
+		for _, drift := range status.Drift {
+			gokeenlog.InfoSubStepf("Drift: %v", color.YellowString(drift))
+		}
+		gokeenlog.Infof("")
+	}
+}
+
+// colorDnsRoutingStatus colors a DNS-routing status for console output
+func colorDnsRoutingStatus(status string) string {
+	switch status {
+	case gokeenrestapi.DnsRoutingStatusInSync:
+		return color.GreenString(status)
+	case gokeenrestapi.DnsRoutingStatusUnmanaged:
+		return color.CyanString(status)
+	default:
+		return color.YellowString(status)
+	}
+}
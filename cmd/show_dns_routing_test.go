@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+
+	"github.com/noksa/gokeenapi/pkg/config"
+	"github.com/noksa/gokeenapi/pkg/gokeenrestapi"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ShowDnsRouting", func() {
+	var server *httptest.Server
+
+	BeforeEach(func() {
+		server = setupMockRouter(
+			gokeenrestapi.WithVersion("5.0.1"),
+			gokeenrestapi.WithDnsRoutingGroups(
+				[]gokeenrestapi.MockDnsRoutingGroup{
+					{Name: "social", Domains: []string{"facebook.com", "instagram.com"}},
+					{Name: "manual", Domains: []string{"manual.example.com"}},
+				},
+				[]gokeenrestapi.MockDnsProxyRoute{
+					{GroupName: "social", InterfaceID: "Wireguard0", Mode: "auto"},
+					{GroupName: "manual", InterfaceID: "ISP", Mode: "auto"},
+				},
+			),
+		)
+		domainFile := writeTempFile(GinkgoT().TempDir(), "social.txt", "facebook.com\ninstagram.com\n")
+		config.Cfg.DNS = config.DNS{
+			Routes: config.DnsRoutes{
+				Groups: []config.DnsRoutingGroup{
+					{Name: "social", DomainFile: []string{domainFile}, InterfaceID: "Wireguard0"},
+				},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		cleanupMockRouter(server)
+	})
+
+	It("should create command with correct attributes and flags", func() {
+		cmd := newShowDnsRoutingCmd()
+
+		Expect(cmd.Use).To(Equal(CmdShowDnsRouting))
+		Expect(cmd.Aliases).To(Equal(AliasesShowDnsRouting))
+		Expect(cmd.Short).NotTo(BeEmpty())
+		Expect(cmd.RunE).NotTo(BeNil())
+
+		outputFlag := cmd.Flags().Lookup("output")
+		Expect(outputFlag).NotTo(BeNil())
+		Expect(outputFlag.DefValue).To(Equal(OutputTable))
+	})
+
+	It("should print group status as a table", func() {
+		cmd := newShowDnsRoutingCmd()
+		output, err := captureOutput(cmd, []string{})
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(output).To(ContainSubstring("social"))
+		Expect(output).To(ContainSubstring(gokeenrestapi.DnsRoutingStatusInSync))
+		Expect(output).To(ContainSubstring("manual"))
+		Expect(output).To(ContainSubstring(gokeenrestapi.DnsRoutingStatusUnmanaged))
+	})
+
+	It("should print only JSON to stdout with --output json", func() {
+		cmd := newShowDnsRoutingCmd()
+		_ = cmd.Flags().Set("output", OutputJSON)
+		output, err := captureOutput(cmd, []string{})
+		Expect(err).NotTo(HaveOccurred())
+
+		var statuses []gokeenrestapi.DnsRoutingGroupStatus
+		Expect(json.Unmarshal([]byte(output), &statuses)).To(Succeed())
+		Expect(statuses).To(Equal([]gokeenrestapi.DnsRoutingGroupStatus{
+			{Name: "manual", InterfaceID: "ISP", InterfaceUp: true, DomainCount: 1, Status: gokeenrestapi.DnsRoutingStatusUnmanaged},
+			{Name: "social", InterfaceID: "Wireguard0", InterfaceUp: true, DomainCount: 2, Status: gokeenrestapi.DnsRoutingStatusInSync},
+		}))
+	})
+
+	It("should reject unsupported output formats", func() {
+		cmd := newShowDnsRoutingCmd()
+		_ = cmd.Flags().Set("output", "xml")
+
+		err := cmd.RunE(cmd, []string{})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("unsupported output format"))
+	})
+})
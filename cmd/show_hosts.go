@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/noksa/gokeenapi/internal/gokeenlog"
+	"github.com/noksa/gokeenapi/pkg/gokeenrestapi"
+	"github.com/spf13/cobra"
+)
+
+func newShowHostsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     CmdShowHosts,
+		Aliases: AliasesShowHosts,
+		Short:   "List every device the router has seen",
+		Long: `Display known hosts (hotspot database) of your Keenetic (Netcraze) router: names,
+MAC and IP addresses, connection interface, registration and online status.
+
+Hosts can be narrowed down with the same filters as delete-known-hosts, all specified
+filters must match. Randomized (locally administered) MAC addresses, typical for phones,
+are flagged.
+
+Use --oui-db with a local copy of the IEEE registry (oui.csv from
+https://standards-oui.ieee.org/oui/oui.csv) to show device vendors. Vendors of
+randomized MAC addresses are not looked up.
+
+Use --output csv or --output json to export the list for audits.
+
+Examples:
+  # Show all known hosts
+  gokeenapi show-hosts --config config.yaml
+
+  # Export hosts with vendors to CSV, recently seen first
+  gokeenapi show-hosts --config config.yaml --oui-db oui.csv --sort last-seen --output csv > hosts.csv
+
+  # Show unregistered hosts in the guest network as JSON
+  gokeenapi show-hosts --config config.yaml --unregistered --ip-in 10.1.30.0/24 --output json`,
+	}
+
+	var filterFlags hostFilterFlags
+	var sortBy, ouiDbPath, output string
+	addHostFilterFlags(cmd, &filterFlags)
+	cmd.Flags().StringVar(&sortBy, "sort", gokeenrestapi.HostSortName,
+		"Sort hosts by one of: "+strings.Join(gokeenrestapi.HostSortKeys, ", "))
+	cmd.Flags().StringVar(&ouiDbPath, "oui-db", "", "Path to IEEE OUI registry CSV file used to look up device vendors")
+	addOutputFlag(cmd, &output, OutputTable, OutputJSON, OutputCSV)
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if err := validateOutputFormat(output, OutputTable, OutputJSON, OutputCSV); err != nil {
+			return err
+		}
+		if !slices.Contains(gokeenrestapi.HostSortKeys, sortBy) {
+			return fmt.Errorf("unsupported sort key '%s': must be one of %s", sortBy, strings.Join(gokeenrestapi.HostSortKeys, ", "))
+		}
+		filter, err := filterFlags.filter()
+		if err != nil {
+			return err
+		}
+		var oui *gokeenrestapi.OuiDB
+		if ouiDbPath != "" {
+			if oui, err = gokeenrestapi.LoadOuiDB(ouiDbPath); err != nil {
+				return err
+			}
+		}
+
+		var hosts []gokeenrestapi.HostInfo
+		fetch := func() error {
+			hotspot, err := gokeenrestapi.Ip.GetAllHotspots()
+			if err != nil {
+				return err
+			}
+			hosts = gokeenrestapi.HostsInfo(gokeenrestapi.FilterHosts(hotspot.Host, filter), oui)
+			return gokeenrestapi.SortHostsInfo(hosts, sortBy)
+		}
+		if output == OutputTable {
+			if err := fetch(); err != nil {
+				return err
+			}
+		} else if err := withProgressOnStderr(fetch); err != nil {
+			return err
+		}
+
+		switch output {
+		case OutputJSON:
+			return printJSON(hosts)
+		case OutputCSV:
+			return printHostsCSV(hosts)
+		}
+		printHostsInfo(hosts)
+		return nil
+	}
+	return cmd
+}
+
+// printHostsCSV prints hosts to stdout as CSV, seen times are in seconds
+func printHostsCSV(hosts []gokeenrestapi.HostInfo) error {
+	header := []string{"name", "hostname", "mac", "ip", "via", "registered", "online", "random_mac", "vendor", "first_seen", "last_seen"}
+	rows := make([][]string, 0, len(hosts))
+	for _, host := range hosts {
+		rows = append(rows, []string{
+			host.Name, host.Hostname, host.Mac, host.IP, host.Via,
+			strconv.FormatBool(host.Registered), strconv.FormatBool(host.Online), strconv.FormatBool(host.RandomMac),
+			host.Vendor, strconv.FormatInt(host.FirstSeen, 10), strconv.FormatInt(host.LastSeen, 10),
+		})
+	}
+	return printCSV(header, rows)
+}
+
+// printHostsInfo displays hosts to the console
+func printHostsInfo(hosts []gokeenrestapi.HostInfo) {
+	if len(hosts) == 0 {
+		gokeenlog.Info("No hosts found")
+		return
+	}
+	for _, host := range hosts {
+		name := host.Name
+		if name == "" {
+			name = host.Hostname
+		}
+		gokeenlog.Infof("%v (MAC: %v)", color.CyanString(name), color.BlueString(host.Mac))
+		if host.IP != "" || host.Via != "" {
+			gokeenlog.InfoSubStepf("IP: %v, via: %v", host.IP, host.Via)
+		}
+		status := color.RedString("offline")
+		if host.Online {
+			status = color.GreenString("online")
+		} else if host.LastSeen > 0 {
+			status += ", last seen " + (time.Duration(host.LastSeen) * time.Second).String() + " ago"
+		}
+		registered := "unregistered"
+		if host.Registered {
+			registered = "registered"
+		}
+		gokeenlog.InfoSubStepf("Status: %v, %v", status, registered)
+		if host.Vendor != "" {
+			gokeenlog.InfoSubStepf("Vendor: %v", host.Vendor)
+		}
+		if host.RandomMac {
+			gokeenlog.InfoSubStepf("%v", color.YellowString("Randomized MAC address"))
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+
+	"github.com/noksa/gokeenapi/pkg/gokeenrestapi"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ShowHosts", func() {
+	var server *httptest.Server
+
+	BeforeEach(func() {
+		server = setupMockRouter(gokeenrestapi.WithHotspotDevices([]gokeenrestapi.MockHost{
+			{Name: "nas", Mac: "00:1a:2b:00:00:01", IP: "192.168.1.10", Registered: true, Link: "up", Via: "Bridge0"},
+			{Hostname: "Galaxy", Mac: "da:a1:19:00:00:02", IP: "10.1.30.7", Via: "Bridge1", LastSeen: 7200},
+		}))
+	})
+
+	AfterEach(func() {
+		cleanupMockRouter(server)
+	})
+
+	It("should create command with correct attributes and flags", func() {
+		cmd := newShowHostsCmd()
+
+		Expect(cmd.Use).To(Equal(CmdShowHosts))
+		Expect(cmd.Aliases).To(Equal(AliasesShowHosts))
+		Expect(cmd.Short).NotTo(BeEmpty())
+		Expect(cmd.RunE).NotTo(BeNil())
+
+		for _, flag := range []string{"sort", "oui-db", "output", "name-pattern", "random-mac", "ip-in"} {
+			Expect(cmd.Flags().Lookup(flag)).NotTo(BeNil(), flag)
+		}
+	})
+
+	It("should reject unsupported sort keys and output formats", func() {
+		cmd := newShowHostsCmd()
+		_ = cmd.Flags().Set("sort", "age")
+		Expect(cmd.RunE(cmd, []string{})).To(MatchError(ContainSubstring("unsupported sort key 'age'")))
+
+		cmd = newShowHostsCmd()
+		_ = cmd.Flags().Set("output", "yaml")
+		Expect(cmd.RunE(cmd, []string{})).To(MatchError(ContainSubstring("unsupported output format")))
+	})
+
+	It("should show hosts as a table", func() {
+		cmd := newShowHostsCmd()
+		out, err := captureOutput(cmd, []string{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(ContainSubstring("nas"))
+		Expect(out).To(ContainSubstring("Galaxy"))
+		Expect(out).To(ContainSubstring("Randomized MAC address"))
+	})
+
+	It("should filter hosts and print JSON", func() {
+		cmd := newShowHostsCmd()
+		_ = cmd.Flags().Set("unregistered", "true")
+		_ = cmd.Flags().Set("output", OutputJSON)
+		out, err := captureOutput(cmd, []string{})
+		Expect(err).NotTo(HaveOccurred())
+
+		var hosts []gokeenrestapi.HostInfo
+		Expect(json.Unmarshal([]byte(out), &hosts)).To(Succeed())
+		Expect(hosts).To(HaveLen(1))
+		Expect(hosts[0].Mac).To(Equal("da:a1:19:00:00:02"))
+		Expect(hosts[0].RandomMac).To(BeTrue())
+		Expect(hosts[0].Online).To(BeFalse())
+	})
+
+	It("should export sorted hosts with vendors to CSV", func() {
+		ouiDb := writeTempFile(GinkgoT().TempDir(), "oui.csv",
+			"Registry,Assignment,Organization Name,Organization Address\nMA-L,001A2B,Example Devices,Somewhere\n")
+
+		cmd := newShowHostsCmd()
+		_ = cmd.Flags().Set("oui-db", ouiDb)
+		_ = cmd.Flags().Set("sort", gokeenrestapi.HostSortIP)
+		_ = cmd.Flags().Set("output", OutputCSV)
+		out, err := captureOutput(cmd, []string{})
+		Expect(err).NotTo(HaveOccurred())
+
+		records, err := csv.NewReader(strings.NewReader(out)).ReadAll()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(records).To(HaveLen(3))
+		Expect(records[0][2]).To(Equal("mac"))
+		Expect(records[1][2]).To(Equal("da:a1:19:00:00:02"))
+		Expect(records[1][7]).To(Equal("true"))
+		Expect(records[2][2]).To(Equal("00:1a:2b:00:00:01"))
+		Expect(records[2][8]).To(Equal("Example Devices"))
+	})
+
+	It("should escape fields that spreadsheets would evaluate as formulas", func() {
+		cleanupMockRouter(server)
+		server = setupMockRouter(gokeenrestapi.WithHotspotDevices([]gokeenrestapi.MockHost{
+			{Name: "=HYPERLINK(\"http://evil\")", Hostname: "@SUM(A1)", Mac: "00:1a:2b:00:00:03", IP: "192.168.1.20", Via: "+Bridge0"},
+			{Name: "\t=1+2", Hostname: "\r=3+4", Mac: "00:1a:2b:00:00:04", IP: "192.168.1.21", Via: "-Bridge1"},
+		}))
+
+		cmd := newShowHostsCmd()
+		_ = cmd.Flags().Set("sort", gokeenrestapi.HostSortIP)
+		_ = cmd.Flags().Set("output", OutputCSV)
+		out, err := captureOutput(cmd, []string{})
+		Expect(err).NotTo(HaveOccurred())
+
+		records, err := csv.NewReader(strings.NewReader(out)).ReadAll()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(records).To(HaveLen(3))
+		Expect(records[1][0]).To(Equal("'=HYPERLINK(\"http://evil\")"))
+		Expect(records[1][1]).To(Equal("'@SUM(A1)"))
+		Expect(records[1][2]).To(Equal("00:1a:2b:00:00:03"))
+		Expect(records[1][4]).To(Equal("'+Bridge0"))
+		Expect(records[2][0]).To(Equal("'\t=1+2"))
+		Expect(records[2][1]).To(Equal("'\r=3+4"))
+		Expect(records[2][4]).To(Equal("'-Bridge1"))
+	})
+
+	It("should fail when the OUI database cannot be read", func() {
+		cmd := newShowHostsCmd()
+		_ = cmd.Flags().Set("oui-db", filepath.Join(GinkgoT().TempDir(), "missing.csv"))
+		Expect(cmd.RunE(cmd, []string{})).To(MatchError(ContainSubstring("failed to open OUI database")))
+	})
+})
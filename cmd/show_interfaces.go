@@ -1,6 +1,13 @@
 package cmd
 
 import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/noksa/gokeenapi/internal/gokeenlog"
 	"github.com/noksa/gokeenapi/pkg/gokeenrestapi"
 	"github.com/spf13/cobra"
 )
@@ -12,33 +19,124 @@ func newShowInterfacesCmd() *cobra.Command {
 		Short:   "List available network interfaces on your router",
 		Long: `Display detailed information about network interfaces available on your Keenetic (Netcraze) router.
 
-This command helps you discover interface IDs that can be used with other commands like add-routes 
-and delete-routes. It shows interface names, types, status, and other relevant details.
+This command helps you discover interface IDs that can be used with other commands like add-routes
+and delete-routes. It shows interface names, types, status, configured addresses, MTU and other
+relevant details.
+
+By default interfaces are printed as a table. Use --output json or --output yaml to get
+machine-readable output for scripts.
 
 Examples:
   # Show all interfaces
   gokeenapi show-interfaces --config config.yaml
 
-  # Show only WireGuard interfaces  
+  # Show only WireGuard interfaces
   gokeenapi show-interfaces --config config.yaml --type Wireguard
 
   # Show multiple interface types
-  gokeenapi show-interfaces --config config.yaml --type Wireguard --type Ethernet`,
+  gokeenapi show-interfaces --config config.yaml --type Wireguard --type Ethernet
+
+  # Show enabled and connected WireGuard interfaces as JSON
+  gokeenapi show-interfaces --config config.yaml --type Wireguard --state up --connected --output json`,
 	}
 
 	var interfaceType []string
+	var state, output string
+	var connected bool
 	cmd.Flags().StringSliceVar(&interfaceType, "type", []string{},
 		`Filter interfaces by type (e.g., Wireguard, Ethernet, Bridge).
 Can be specified multiple times to show multiple types.
 If not specified, shows all interface types.`)
+	cmd.Flags().StringVar(&state, "state", "", "Filter interfaces by administrative state (up or down)")
+	cmd.Flags().BoolVar(&connected, "connected", false, "Show only connected interfaces")
+	addOutputFlag(cmd, &output, OutputTable, OutputJSON, OutputYAML)
 
 	cmd.RunE = func(cmd *cobra.Command, args []string) error {
-		interfaces, err := gokeenrestapi.Interface.GetInterfacesViaRciShowInterfaces(true, interfaceType...)
-		if err != nil {
+		if err := validateOutputFormat(output, OutputTable, OutputJSON, OutputYAML); err != nil {
 			return err
 		}
-		gokeenrestapi.Interface.PrintInfoAboutInterfaces(interfaces)
-		return nil
+		state = strings.ToLower(state)
+		if state != "" && state != gokeenrestapi.StateUp && state != gokeenrestapi.StateDown {
+			return fmt.Errorf("unsupported state '%s': must be %s or %s", state, gokeenrestapi.StateUp, gokeenrestapi.StateDown)
+		}
+
+		var interfaces []gokeenrestapi.InterfaceInfo
+		fetch := func() error {
+			var err error
+			interfaces, err = gokeenrestapi.Interface.GetInterfacesInfo(interfaceType...)
+			return err
+		}
+		if output == OutputTable {
+			if err := fetch(); err != nil {
+				return err
+			}
+		} else if err := withProgressOnStderr(fetch); err != nil {
+			return err
+		}
+		interfaces = filterInterfacesInfo(interfaces, state, connected)
+
+		switch output {
+		case OutputJSON:
+			return printJSON(interfaces)
+		case OutputYAML:
+			return printYAML(interfaces)
+		}
+		return printInterfacesInfo(interfaces)
 	}
 	return cmd
 }
+
+// filterInterfacesInfo keeps interfaces in the given administrative state (any when empty) and,
+// if connectedOnly is set, only connected ones
+func filterInterfacesInfo(interfaces []gokeenrestapi.InterfaceInfo, state string, connectedOnly bool) []gokeenrestapi.InterfaceInfo {
+	filtered := make([]gokeenrestapi.InterfaceInfo, 0, len(interfaces))
+	for _, iface := range interfaces {
+		if state != "" && iface.State != state {
+			continue
+		}
+		if connectedOnly && iface.Connected != gokeenrestapi.StateConnected {
+			continue
+		}
+		filtered = append(filtered, iface)
+	}
+	return filtered
+}
+
+// printInterfacesInfo displays interfaces to the console as a table with aligned columns
+func printInterfacesInfo(interfaces []gokeenrestapi.InterfaceInfo) error {
+	if len(interfaces) == 0 {
+		gokeenlog.Info("No interfaces found")
+		return nil
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "ID\tTYPE\tSTATE\tLINK\tCONNECTED\tADDRESS\tMTU\tDEFAULT GW\tDESCRIPTION")
+	for _, iface := range interfaces {
+		address := strings.Join(iface.Addresses, ",")
+		if address == "" {
+			address = iface.Address
+		}
+		mtu := "-"
+		if iface.Mtu > 0 {
+			mtu = strconv.Itoa(iface.Mtu)
+		}
+		defaultGw := "-"
+		if iface.DefaultGw {
+			defaultGw = "yes"
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			iface.Id, iface.Type, orDash(iface.State), orDash(iface.Link), orDash(iface.Connected),
+			orDash(address), mtu, defaultGw, orDash(iface.Description))
+	}
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("failed to encode output: %w", err)
+	}
+	return nil
+}
+
+// orDash returns s, or "-" when it is empty, so that table cells are never blank
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -1,11 +1,14 @@
 package cmd
 
 import (
+	"encoding/json"
 	"net/http/httptest"
+	"strings"
 
 	"github.com/noksa/gokeenapi/pkg/gokeenrestapi"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"gopkg.in/yaml.v3"
 )
 
 var _ = Describe("ShowInterfaces", func() {
@@ -30,6 +33,9 @@ var _ = Describe("ShowInterfaces", func() {
 		typeFlag := cmd.Flags().Lookup("type")
 		Expect(typeFlag).NotTo(BeNil())
 		Expect(typeFlag.Value.Type()).To(Equal("stringSlice"))
+		for _, name := range []string{"state", "connected", "output"} {
+			Expect(cmd.Flags().Lookup(name)).NotTo(BeNil(), name)
+		}
 	})
 
 	It("should execute and show interfaces", func() {
@@ -49,4 +55,96 @@ var _ = Describe("ShowInterfaces", func() {
 		Expect(err).NotTo(HaveOccurred())
 		Expect(output).To(ContainSubstring("Wireguard0"))
 	})
+
+	Context("with structured output and filters", func() {
+		BeforeEach(func() {
+			cleanupMockRouter(server)
+			server = setupMockRouter(
+				gokeenrestapi.WithInterfaces([]gokeenrestapi.MockInterface{
+					{
+						ID: "Wireguard0", Type: gokeenrestapi.InterfaceTypeWireguard, Address: "10.0.0.1/24",
+						Connected: gokeenrestapi.StateConnected, Link: gokeenrestapi.StateUp, State: gokeenrestapi.StateUp,
+					},
+					{
+						ID: "Wireguard1", Type: gokeenrestapi.InterfaceTypeWireguard,
+						Connected: gokeenrestapi.StateDisconnected, Link: gokeenrestapi.StateDown, State: gokeenrestapi.StateDown,
+					},
+					{
+						ID: "Wireguard2", Type: gokeenrestapi.InterfaceTypeWireguard,
+						Connected: gokeenrestapi.StateDisconnected, Link: gokeenrestapi.StateDown, State: gokeenrestapi.StateUp,
+					},
+					{
+						ID: "ISP", Type: gokeenrestapi.InterfaceTypePPPoE,
+						Connected: gokeenrestapi.StateConnected, Link: gokeenrestapi.StateUp, State: gokeenrestapi.StateUp,
+					},
+				}),
+				gokeenrestapi.WithScInterfaces(map[string]gokeenrestapi.MockScInterface{
+					"Wireguard0": {IP: gokeenrestapi.MockIP{Address: "10.0.0.1", Mask: "255.255.255.0", Mtu: "1420"}},
+				}),
+			)
+		})
+
+		It("should print interfaces with configured addresses and MTU as JSON", func() {
+			cmd := newShowInterfacesCmd()
+			Expect(cmd.Flags().Set("output", OutputJSON)).To(Succeed())
+			output, err := captureOutput(cmd, []string{})
+			Expect(err).NotTo(HaveOccurred())
+
+			var interfaces []gokeenrestapi.InterfaceInfo
+			Expect(json.Unmarshal([]byte(output), &interfaces)).To(Succeed())
+			Expect(interfaces).To(HaveLen(4))
+			Expect(interfaces[0].Id).To(Equal("ISP"))
+			Expect(interfaces[1].Id).To(Equal("Wireguard0"))
+			Expect(interfaces[1].Addresses).To(Equal([]string{"10.0.0.1/24"}))
+			Expect(interfaces[1].Mtu).To(Equal(1420))
+		})
+
+		It("should print interfaces as a table with aligned columns", func() {
+			cmd := newShowInterfacesCmd()
+			output, err := captureOutput(cmd, []string{})
+			Expect(err).NotTo(HaveOccurred())
+
+			// Progress of fetching interfaces precedes the table
+			output = output[strings.Index(output, "ID "):]
+			lines := strings.Split(strings.TrimRight(output, "\n"), "\n")
+			Expect(lines).To(HaveLen(5))
+			Expect(strings.Fields(lines[0])).To(Equal([]string{"ID", "TYPE", "STATE", "LINK", "CONNECTED", "ADDRESS", "MTU", "DEFAULT", "GW", "DESCRIPTION"}))
+			Expect(strings.Fields(lines[2])[:7]).To(Equal([]string{"Wireguard0", gokeenrestapi.InterfaceTypeWireguard,
+				gokeenrestapi.StateUp, gokeenrestapi.StateUp, gokeenrestapi.StateConnected, "10.0.0.1/24", "1420"}))
+			for _, line := range lines[1:] {
+				Expect(strings.Index(line, strings.Fields(line)[5])).To(Equal(strings.Index(lines[0], "ADDRESS")), line)
+			}
+		})
+
+		It("should filter by type, state and connection status", func() {
+			cmd := newShowInterfacesCmd()
+			Expect(cmd.Flags().Set("type", gokeenrestapi.InterfaceTypeWireguard)).To(Succeed())
+			Expect(cmd.Flags().Set("state", "up")).To(Succeed())
+			Expect(cmd.Flags().Set("output", OutputYAML)).To(Succeed())
+			output, err := captureOutput(cmd, []string{})
+			Expect(err).NotTo(HaveOccurred())
+
+			var interfaces []gokeenrestapi.InterfaceInfo
+			Expect(yaml.Unmarshal([]byte(output), &interfaces)).To(Succeed())
+			Expect(interfaces).To(HaveLen(2))
+			Expect(interfaces[0].Id).To(Equal("Wireguard0"))
+			Expect(interfaces[1].Id).To(Equal("Wireguard2"))
+
+			cmd = newShowInterfacesCmd()
+			Expect(cmd.Flags().Set("type", gokeenrestapi.InterfaceTypeWireguard)).To(Succeed())
+			Expect(cmd.Flags().Set("connected", "true")).To(Succeed())
+			output, err = captureOutput(cmd, []string{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(output).To(ContainSubstring("Wireguard0"))
+			Expect(output).To(ContainSubstring("1420"))
+			Expect(output).NotTo(ContainSubstring("Wireguard1"))
+			Expect(output).NotTo(ContainSubstring("Wireguard2"))
+		})
+
+		It("should reject an unknown state", func() {
+			cmd := newShowInterfacesCmd()
+			Expect(cmd.Flags().Set("state", "sleeping")).To(Succeed())
+			Expect(cmd.RunE(cmd, []string{})).To(MatchError("unsupported state 'sleeping': must be up or down"))
+		})
+	})
 })
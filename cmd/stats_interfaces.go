@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"errors"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/noksa/gokeenapi/internal/gokeenlog"
+	"github.com/noksa/gokeenapi/pkg/gokeenrestapi"
+	"github.com/spf13/cobra"
+)
+
+func newStatsInterfacesCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     CmdStatsInterfaces,
+		Aliases: AliasesStatsInterfaces,
+		Short:   "Show traffic statistics of interfaces",
+		Long: `Show traffic counters of interfaces on your Keenetic (Netcraze) router: received and sent
+bytes, packets, errors and dropped packets.
+
+By default the command prints the counters once. With --watch it polls the router at the
+given interval and also shows rx/tx rates between polls, so you can see whether traffic
+actually flows through a tunnel. Use --output json to get the counters for scripts.
+
+Examples:
+  # Show counters of all interfaces
+  gokeenapi stats-interfaces --config config.yaml
+
+  # Watch WireGuard tunnels every 5 seconds
+  gokeenapi stats-interfaces --config config.yaml --type Wireguard --watch 5s
+
+  # Print counters of a single interface as JSON
+  gokeenapi stats-interfaces --config config.yaml --interface-id Wireguard0 --output json`,
+	}
+
+	var interfaceIds, interfaceTypes []string
+	var watch time.Duration
+	var output string
+	cmd.Flags().StringSliceVar(&interfaceIds, "interface-id", []string{},
+		`Interface ID to show. Can be specified multiple times.
+If not specified, all interfaces are shown.`)
+	cmd.Flags().StringSliceVar(&interfaceTypes, "type", []string{},
+		`Filter interfaces by type (e.g., Wireguard, PPPoE). Can be specified multiple times.`)
+	cmd.Flags().DurationVar(&watch, "watch", 0, "Poll the router at this interval and show rx/tx rates until interrupted")
+	addOutputFlag(cmd, &output, OutputTable, OutputJSON)
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if err := validateOutputFormat(output, OutputTable, OutputJSON); err != nil {
+			return err
+		}
+		if watch < 0 || (watch > 0 && watch < time.Second) {
+			return errors.New("--watch must be at least 1 second")
+		}
+		if watch > 0 && output != OutputTable {
+			return errors.New("--watch supports only table output")
+		}
+
+		if watch == 0 {
+			var stats []gokeenrestapi.InterfaceStats
+			fetch := func() error {
+				var err error
+				stats, err = gokeenrestapi.Interface.GetInterfacesStats(interfaceTypes, interfaceIds...)
+				return err
+			}
+			if output == OutputJSON {
+				if err := withProgressOnStderr(fetch); err != nil {
+					return err
+				}
+				if stats == nil {
+					stats = []gokeenrestapi.InterfaceStats{}
+				}
+				return printJSON(stats)
+			}
+			if err := fetch(); err != nil {
+				return err
+			}
+			printInterfacesStats(stats, nil)
+			return nil
+		}
+
+		ctx := commandContext(cmd)
+		ticker := time.NewTicker(watch)
+		defer ticker.Stop()
+		var previous map[string]gokeenrestapi.InterfaceStats
+		var previousAt time.Time
+		for {
+			stats, err := gokeenrestapi.Interface.GetInterfacesStats(interfaceTypes, interfaceIds...)
+			if err != nil {
+				// A failed poll must not stop watching, the router may be temporarily unreachable
+				gokeenlog.Infof("⚠️  %s: %v", color.YellowString("WARNING"), err)
+			} else {
+				now := time.Now()
+				rates := make(map[string]gokeenrestapi.InterfaceRate)
+				current := make(map[string]gokeenrestapi.InterfaceStats, len(stats))
+				for _, stat := range stats {
+					if stat.Error != "" {
+						continue
+					}
+					current[stat.InterfaceID] = stat
+					if prev, ok := previous[stat.InterfaceID]; ok {
+						rates[stat.InterfaceID] = stat.RateSince(prev, now.Sub(previousAt))
+					}
+				}
+				gokeenlog.HorizontalLine()
+				printInterfacesStats(stats, rates)
+				previous, previousAt = current, now
+			}
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+			}
+		}
+	}
+	return cmd
+}
+
+// printInterfacesStats displays interface counters to the console, rates are shown for interfaces present in rates
+func printInterfacesStats(stats []gokeenrestapi.InterfaceStats, rates map[string]gokeenrestapi.InterfaceRate) {
+	if len(stats) == 0 {
+		gokeenlog.Info("No interfaces found")
+		return
+	}
+	for _, stat := range stats {
+		gokeenlog.Infof("%v (%v, %v):", color.CyanString(stat.InterfaceID), stat.Type, stat.State)
+		if stat.Error != "" {
+			gokeenlog.InfoSubStepf("Statistics unavailable: %v", color.RedString(stat.Error))
+			continue
+		}
+		gokeenlog.InfoSubStepf("RX: %v, %d packets, %d errors, %d dropped",
+			formatBytes(stat.RxBytes), stat.RxPackets, stat.RxErrors, stat.RxDropped)
+		gokeenlog.InfoSubStepf("TX: %v, %d packets, %d errors, %d dropped",
+			formatBytes(stat.TxBytes), stat.TxPackets, stat.TxErrors, stat.TxDropped)
+		if rate, ok := rates[stat.InterfaceID]; ok {
+			gokeenlog.InfoSubStepf("Rate: rx %v/s, tx %v/s",
+				color.GreenString(formatBytes(int64(rate.RxBytesPerSecond))), color.GreenString(formatBytes(int64(rate.TxBytesPerSecond))))
+		}
+	}
+}
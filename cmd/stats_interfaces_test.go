@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+
+	"github.com/noksa/gokeenapi/pkg/gokeenrestapi"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("StatsInterfaces", func() {
+	var server *httptest.Server
+
+	BeforeEach(func() {
+		server = setupMockRouter(
+			gokeenrestapi.WithInterfaces([]gokeenrestapi.MockInterface{
+				{
+					ID: "Wireguard0", Type: gokeenrestapi.InterfaceTypeWireguard,
+					Connected: gokeenrestapi.StateConnected, Link: gokeenrestapi.StateUp, State: gokeenrestapi.StateUp,
+					RxBytes: 1536, TxBytes: 2048, RxPackets: 12, TxPackets: 16,
+				},
+				{
+					ID: "ISP", Type: gokeenrestapi.InterfaceTypePPPoE,
+					Connected: gokeenrestapi.StateConnected, Link: gokeenrestapi.StateUp, State: gokeenrestapi.StateUp,
+				},
+			}),
+		)
+	})
+
+	AfterEach(func() {
+		cleanupMockRouter(server)
+	})
+
+	It("should create command with correct attributes and flags", func() {
+		cmd := newStatsInterfacesCmd()
+
+		Expect(cmd.Use).To(Equal(CmdStatsInterfaces))
+		Expect(cmd.Aliases).To(Equal(AliasesStatsInterfaces))
+		Expect(cmd.Short).NotTo(BeEmpty())
+		Expect(cmd.RunE).NotTo(BeNil())
+		for _, name := range []string{"interface-id", "type", "watch", "output"} {
+			Expect(cmd.Flags().Lookup(name)).NotTo(BeNil(), name)
+		}
+	})
+
+	It("should print counters once", func() {
+		cmd := newStatsInterfacesCmd()
+		Expect(cmd.Flags().Set("type", gokeenrestapi.InterfaceTypeWireguard)).To(Succeed())
+		output, err := captureOutput(cmd, []string{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(output).To(ContainSubstring("Wireguard0"))
+		Expect(output).To(ContainSubstring("RX: 1.5 KiB, 12 packets"))
+		Expect(output).To(ContainSubstring("TX: 2.0 KiB, 16 packets"))
+		Expect(output).NotTo(ContainSubstring("ISP"))
+		Expect(output).NotTo(ContainSubstring("Rate:"))
+	})
+
+	It("should print counters as JSON", func() {
+		cmd := newStatsInterfacesCmd()
+		Expect(cmd.Flags().Set("interface-id", "Wireguard0")).To(Succeed())
+		Expect(cmd.Flags().Set("output", OutputJSON)).To(Succeed())
+		output, err := captureOutput(cmd, []string{})
+		Expect(err).NotTo(HaveOccurred())
+
+		var stats []gokeenrestapi.InterfaceStats
+		Expect(json.Unmarshal([]byte(output), &stats)).To(Succeed())
+		Expect(stats).To(HaveLen(1))
+		Expect(stats[0].InterfaceID).To(Equal("Wireguard0"))
+		Expect(stats[0].RxBytes).To(Equal(int64(1536)))
+		Expect(stats[0].TxPackets).To(Equal(int64(16)))
+	})
+
+	It("should stop watching when the context is cancelled", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		cmd := newStatsInterfacesCmd()
+		cmd.SetContext(ctx)
+		Expect(cmd.Flags().Set("watch", "1s")).To(Succeed())
+		output, err := captureOutput(cmd, []string{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(output).To(ContainSubstring("Wireguard0"))
+	})
+
+	It("should validate --watch", func() {
+		cmd := newStatsInterfacesCmd()
+		Expect(cmd.Flags().Set("watch", "100ms")).To(Succeed())
+		Expect(cmd.RunE(cmd, []string{})).To(MatchError("--watch must be at least 1 second"))
+
+		cmd = newStatsInterfacesCmd()
+		Expect(cmd.Flags().Set("watch", "5s")).To(Succeed())
+		Expect(cmd.Flags().Set("output", OutputJSON)).To(Succeed())
+		Expect(cmd.RunE(cmd, []string{})).To(MatchError("--watch supports only table output"))
+	})
+})
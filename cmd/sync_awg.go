@@ -0,0 +1,193 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/fatih/color"
+	"github.com/noksa/gokeenapi/internal/gokeenlog"
+	"github.com/noksa/gokeenapi/pkg/config"
+	"github.com/noksa/gokeenapi/pkg/gokeenrestapi"
+	"github.com/noksa/gokeenapi/pkg/gokeenrestapimodels"
+	"github.com/spf13/cobra"
+	"go.uber.org/multierr"
+)
+
+func newSyncAwgCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     CmdSyncAwg,
+		Aliases: AliasesSyncAwg,
+		Short:   "Keep WireGuard VPN connections in sync with the config",
+		Long: `Synchronize WireGuard (AWG) connections declared in the 'awg' section of your
+configuration file with your Keenetic (Netcraze) router.
+
+For every tunnel the command will:
+1. Read the .conf file from 'conf-file' or download it from 'conf-url'
+2. Create the interface if it does not exist, or update it with only the changes
+3. Enable or disable global IP routing ('global', default: true)
+4. Bring the interface up or down ('up', default: true)
+
+Interfaces created by the router may get another ID than configured (Keenetic
+assigns IDs on import). The tunnel then fails with the assigned ID: set it as
+'interfaceId' in the config so the next run updates that interface.
+
+Tunnels are processed independently: a failure of one tunnel is reported at the end
+and does not stop the others. This makes the command safe to run from the scheduler.
+
+Examples:
+  # Sync all tunnels from config file
+  gokeenapi sync-awg --config config.yaml
+
+  # Example config entries:
+  # awg:
+  #   - interfaceId: Wireguard0
+  #     conf-file: wg/office.conf
+  #   - interfaceId: Wireguard1
+  #     conf-url: https://example.com/backup.conf
+  #     name: Backup
+  #     global: false
+  #     up: false`,
+	}
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		tunnels := config.Cfg.Awg
+		if err := config.ValidateAwgTunnels(tunnels); err != nil {
+			return err
+		}
+		if len(tunnels) == 0 {
+			gokeenlog.Info("No AWG tunnels in the config")
+			return nil
+		}
+
+		var mErr error
+		for _, tunnel := range tunnels {
+			gokeenlog.Infof("AWG tunnel %v", color.CyanString(tunnel.InterfaceID))
+			if err := syncAwgTunnel(tunnel); err != nil {
+				gokeenlog.InfoSubStepf("%v: %v", color.RedString("Failed"), err)
+				mErr = multierr.Append(mErr, fmt.Errorf("AWG tunnel %s: %w", tunnel.InterfaceID, err))
+			}
+			gokeenlog.HorizontalLine()
+		}
+		return mErr
+	}
+	return cmd
+}
+
+// syncAwgTunnel creates or updates a single tunnel and applies its global and up settings
+func syncAwgTunnel(tunnel config.AwgTunnel) error {
+	confPath, cleanup, err := awgTunnelConfFile(tunnel)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	interfaces, err := gokeenrestapi.Interface.GetInterfacesViaRciShowInterfaces(false)
+	if err != nil {
+		return err
+	}
+	current, exists := findInterfaceById(interfaces, tunnel.InterfaceID)
+
+	interfaceId := tunnel.InterfaceID
+	if exists {
+		if current.Type != gokeenrestapi.InterfaceTypeWireguard {
+			return fmt.Errorf("interface %s is not a WireGuard interface (type: %s)", interfaceId, current.Type)
+		}
+		if err := gokeenrestapi.AwgConf.ConfigureOrUpdateInterface(confPath, interfaceId); err != nil {
+			return err
+		}
+	} else {
+		// A newly imported interface is down, current stays empty
+		interfaceId, err = createAwgTunnel(confPath, tunnel)
+		if err != nil {
+			return err
+		}
+	}
+
+	// A newly imported interface has no known global state yet
+	if !exists || current.Global != tunnel.IsGlobal() {
+		if err := gokeenrestapi.Interface.SetGlobalIpInInterface(interfaceId, tunnel.IsGlobal()); err != nil {
+			return err
+		}
+	}
+
+	isUp := current.State == gokeenrestapi.StateUp
+	switch {
+	case tunnel.IsUp() && !isUp:
+		if err := gokeenrestapi.Interface.UpInterface(interfaceId); err != nil {
+			return err
+		}
+		return gokeenrestapi.Interface.WaitUntilInterfaceIsUp(interfaceId)
+	case !tunnel.IsUp() && isUp:
+		return gokeenrestapi.Interface.DownInterface(interfaceId)
+	}
+	return nil
+}
+
+// createAwgTunnel imports the .conf file as a new interface and returns the ID assigned by the router
+// It fails when the router assigned another ID than configured, otherwise the next run would not find
+// the interface and try to import the same .conf again
+func createAwgTunnel(confPath string, tunnel config.AwgTunnel) (string, error) {
+	// we must check that wireguard component is installed on the router - otherwise it will fail
+	installed, err := gokeenrestapi.Checks.CheckComponentInstalled("wireguard")
+	if err != nil {
+		return "", err
+	}
+	if installed == "" {
+		return "", fmt.Errorf("wireguard component is not installed. Please install 'WireGuard VPN' component in your Keenetic (Netcraze) router first")
+	}
+	if err := gokeenrestapi.Checks.CheckAWGInterfaceExistsFromConfFile(confPath); err != nil {
+		return "", err
+	}
+
+	createdInterface, err := gokeenrestapi.AwgConf.AddInterface(confPath, tunnel.Name)
+	if err != nil {
+		return "", err
+	}
+	interfaceId := createdInterface.Created
+	gokeenlog.InfoSubStepf("Created interface %v", color.CyanString(interfaceId))
+
+	if err := gokeenrestapi.AwgConf.ConfigureOrUpdateInterface(confPath, interfaceId); err != nil {
+		return "", err
+	}
+	if interfaceId != tunnel.InterfaceID {
+		return "", fmt.Errorf("the router assigned ID %s instead of %s, set 'interfaceId: %s' in the config and run sync-awg again",
+			interfaceId, tunnel.InterfaceID, interfaceId)
+	}
+	return interfaceId, nil
+}
+
+// awgTunnelConfFile returns the path of the tunnel .conf file. A conf-url is downloaded into
+// a temporary file readable by the owner only, the returned cleanup function removes it
+func awgTunnelConfFile(tunnel config.AwgTunnel) (string, func(), error) {
+	noop := func() {}
+	if tunnel.ConfURL == "" {
+		return tunnel.ConfFile, noop, nil
+	}
+
+	content, err := gokeenrestapi.AwgConf.FetchConf(tunnel.ConfURL)
+	if err != nil {
+		return "", noop, err
+	}
+	tmpDir, err := os.MkdirTemp("", "gokeenapi-awg-")
+	if err != nil {
+		return "", noop, err
+	}
+	cleanup := func() { _ = os.RemoveAll(tmpDir) }
+	confPath := filepath.Join(tmpDir, tunnel.InterfaceID+".conf")
+	if err := os.WriteFile(confPath, []byte(content), 0600); err != nil {
+		cleanup()
+		return "", noop, fmt.Errorf("failed to write downloaded conf file: %w", err)
+	}
+	return confPath, cleanup, nil
+}
+
+// findInterfaceById returns the interface with the given ID from the list
+func findInterfaceById(interfaces map[string]gokeenrestapimodels.RciShowInterface, interfaceId string) (gokeenrestapimodels.RciShowInterface, bool) {
+	for _, iface := range interfaces {
+		if iface.Id == interfaceId {
+			return iface, true
+		}
+	}
+	return gokeenrestapimodels.RciShowInterface{}, false
+}
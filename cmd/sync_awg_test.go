@@ -0,0 +1,169 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+
+	"github.com/noksa/gokeenapi/pkg/config"
+	"github.com/noksa/gokeenapi/pkg/gokeenrestapi"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SyncAwg", func() {
+	const syncConf = `[Interface]
+PrivateKey = abc
+Address = 10.0.0.2/24
+Jc = 7
+Jmin = 50
+Jmax = 1000
+S1 = 30
+S2 = 40
+H1 = 11
+H2 = 12
+H3 = 13
+H4 = 14
+
+[Peer]
+PublicKey = gN65BkIKy1eCE9pP1wdc8ROUunkiVXrBvGAKBEKdOQI=
+Endpoint = sync.example.com:51820
+AllowedIPs = 0.0.0.0/0
+PersistentKeepalive = 25`
+
+	var (
+		server *httptest.Server
+		oldCfg config.GokeenapiConfig
+	)
+
+	BeforeEach(func() {
+		oldCfg = config.Cfg
+		server = setupMockRouter()
+	})
+
+	AfterEach(func() {
+		cleanupMockRouter(server)
+		config.Cfg = oldCfg
+	})
+
+	interfaceState := func(interfaceId string) string {
+		interfaces, err := gokeenrestapi.Interface.GetInterfacesViaRciShowInterfaces(false)
+		ExpectWithOffset(1, err).NotTo(HaveOccurred())
+		iface, ok := findInterfaceById(interfaces, interfaceId)
+		ExpectWithOffset(1, ok).To(BeTrue())
+		return iface.State
+	}
+
+	It("should create command with correct attributes", func() {
+		cmd := newSyncAwgCmd()
+
+		Expect(cmd.Use).To(Equal(CmdSyncAwg))
+		Expect(cmd.Aliases).To(Equal(AliasesSyncAwg))
+		Expect(cmd.Short).NotTo(BeEmpty())
+		Expect(cmd.RunE).NotTo(BeNil())
+	})
+
+	It("should succeed when no tunnels are configured", func() {
+		config.Cfg.Awg = nil
+
+		cmd := newSyncAwgCmd()
+		Expect(cmd.RunE(cmd, []string{})).To(Succeed())
+	})
+
+	It("should fail on invalid tunnels", func() {
+		config.Cfg.Awg = []config.AwgTunnel{{InterfaceID: "Wireguard0"}}
+
+		cmd := newSyncAwgCmd()
+		err := cmd.RunE(cmd, []string{})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("must contain conf-file or conf-url"))
+	})
+
+	It("should update an existing interface from conf-file", func() {
+		confPath := writeTempFile(GinkgoT().TempDir(), "office.conf", syncConf)
+		config.Cfg.Awg = []config.AwgTunnel{{InterfaceID: "Wireguard0", ConfFile: confPath}}
+
+		cmd := newSyncAwgCmd()
+		Expect(cmd.RunE(cmd, []string{})).To(Succeed())
+
+		conf, _, err := gokeenrestapi.AwgConf.ExportConf("Wireguard0")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(conf).To(ContainSubstring("Endpoint = sync.example.com:51820"))
+		Expect(conf).To(ContainSubstring("Jc = 7"))
+		Expect(interfaceState("Wireguard0")).To(Equal(gokeenrestapi.StateUp))
+	})
+
+	It("should bring an existing interface down when up is false", func() {
+		confPath := writeTempFile(GinkgoT().TempDir(), "office.conf", syncConf)
+		up := false
+		config.Cfg.Awg = []config.AwgTunnel{{InterfaceID: "Wireguard0", ConfFile: confPath, Up: &up}}
+
+		cmd := newSyncAwgCmd()
+		Expect(cmd.RunE(cmd, []string{})).To(Succeed())
+		Expect(interfaceState("Wireguard0")).To(Equal(gokeenrestapi.StateDown))
+	})
+
+	It("should create a missing interface", func() {
+		confPath := writeTempFile(GinkgoT().TempDir(), "backup.conf", syncConf)
+		config.Cfg.Awg = []config.AwgTunnel{{InterfaceID: "Wireguard1", ConfFile: confPath, Name: "Backup"}}
+
+		cmd := newSyncAwgCmd()
+		output, err := captureOutput(cmd, []string{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(output).To(ContainSubstring("Changing global IP"))
+
+		conf, _, err := gokeenrestapi.AwgConf.ExportConf("Wireguard1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(conf).To(ContainSubstring("Endpoint = sync.example.com:51820"))
+		Expect(interfaceState("Wireguard1")).To(Equal(gokeenrestapi.StateUp))
+
+		// The second run finds the interface and has nothing to create or re-enable
+		output, err = captureOutput(cmd, []string{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(output).NotTo(ContainSubstring("Created interface"))
+		Expect(output).NotTo(ContainSubstring("Changing global IP"))
+	})
+
+	It("should fail with the assigned ID when the router picks another one", func() {
+		confPath := writeTempFile(GinkgoT().TempDir(), "backup.conf", syncConf)
+		config.Cfg.Awg = []config.AwgTunnel{{InterfaceID: "Wireguard5", ConfFile: confPath, Name: "Backup"}}
+
+		cmd := newSyncAwgCmd()
+		err := cmd.RunE(cmd, []string{})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("set 'interfaceId: Wireguard1' in the config"))
+
+		config.Cfg.Awg[0].InterfaceID = "Wireguard1"
+		Expect(cmd.RunE(cmd, []string{})).To(Succeed())
+		Expect(interfaceState("Wireguard1")).To(Equal(gokeenrestapi.StateUp))
+	})
+
+	It("should download the conf from conf-url", func() {
+		confServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(syncConf))
+		}))
+		defer confServer.Close()
+		config.Cfg.Awg = []config.AwgTunnel{{InterfaceID: "Wireguard0", ConfURL: confServer.URL + "/office.conf"}}
+
+		cmd := newSyncAwgCmd()
+		Expect(cmd.RunE(cmd, []string{})).To(Succeed())
+
+		conf, _, err := gokeenrestapi.AwgConf.ExportConf("Wireguard0")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(conf).To(ContainSubstring("Endpoint = sync.example.com:51820"))
+	})
+
+	It("should continue with other tunnels and report failures", func() {
+		confPath := writeTempFile(GinkgoT().TempDir(), "office.conf", syncConf)
+		config.Cfg.Awg = []config.AwgTunnel{
+			{InterfaceID: "ISP", ConfFile: confPath},
+			{InterfaceID: "Wireguard0", ConfFile: filepath.Join(GinkgoT().TempDir(), "missing.conf")},
+		}
+
+		cmd := newSyncAwgCmd()
+		err := cmd.RunE(cmd, []string{})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("AWG tunnel ISP: interface ISP is not a WireGuard interface"))
+		Expect(err.Error()).To(ContainSubstring("AWG tunnel Wireguard0:"))
+	})
+})
@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/noksa/gokeenapi/internal/gokeenlog"
+	"github.com/noksa/gokeenapi/pkg/config"
+	"github.com/noksa/gokeenapi/pkg/gokeenrestapi"
+	"github.com/spf13/cobra"
+)
+
+// syncHostsReport describes changes applied by sync-hosts, or that would be applied in dry-run mode
+type syncHostsReport struct {
+	DryRun  bool                            `json:"dryRun"`
+	Changes []gokeenrestapi.KnownHostChange `json:"changes"`
+}
+
+func newSyncHostsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     CmdSyncHosts,
+		Aliases: AliasesSyncHosts,
+		Short:   "Keep known hosts in sync with the device inventory in the config",
+		Long: `Synchronize the device inventory declared in the 'hosts' section of your
+configuration file with your Keenetic (Netcraze) router.
+
+For every host the command will:
+1. Register it in the router under the configured name, or rename it
+2. Assign the static DHCP lease from 'ip' (optional)
+3. Assign the IP connection policy from 'policy' (optional)
+
+Only differences are applied, running the command again does nothing. Leases and
+policies of hosts without 'ip' or 'policy' are left untouched.
+
+With --prune registered hosts missing from the inventory are removed together with
+their static leases and policies. Pruning asks for confirmation unless --force is used.
+Use --dry-run to only list the changes.
+
+Examples:
+  # Sync the inventory
+  gokeenapi sync-hosts --config config.yaml
+
+  # Preview the changes including hosts to prune
+  gokeenapi sync-hosts --config config.yaml --prune --dry-run
+
+  # Example config entries:
+  # hosts:
+  #   - mac: aa:bb:cc:dd:ee:01
+  #     name: NAS
+  #     ip: 192.168.1.10
+  #   - mac: aa:bb:cc:dd:ee:02
+  #     name: TV
+  #     policy: Policy0`,
+	}
+
+	var prune, dryRun, force bool
+	var output string
+	cmd.Flags().BoolVar(&prune, "prune", false, "Remove registered hosts that are not in the inventory")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "List changes without applying them")
+	cmd.Flags().BoolVar(&force, "force", false, "Skip confirmation prompt when pruning hosts")
+	addOutputFlag(cmd, &output, OutputTable, OutputJSON)
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if err := validateOutputFormat(output, OutputTable, OutputJSON); err != nil {
+			return err
+		}
+		hosts := config.Cfg.Hosts
+		if err := config.ValidateKnownHosts(hosts); err != nil {
+			return err
+		}
+		if len(hosts) == 0 && !prune {
+			gokeenlog.Info("No hosts in the config")
+			return nil
+		}
+
+		report := syncHostsReport{DryRun: dryRun, Changes: []gokeenrestapi.KnownHostChange{}}
+		run := func() error {
+			changes, err := gokeenrestapi.Ip.PlanKnownHosts(hosts, prune)
+			if err != nil {
+				return err
+			}
+			if len(changes) == 0 {
+				gokeenlog.Info("Known hosts are already in sync")
+				return nil
+			}
+			printKnownHostChanges(changes)
+			if dryRun {
+				gokeenlog.Infof("Dry run: %d host(s) would be changed", len(changes))
+				report.Changes = changes
+				return nil
+			}
+
+			pruned := 0
+			for _, change := range changes {
+				if change.Action == gokeenrestapi.KnownHostActionPrune {
+					pruned++
+				}
+			}
+			if pruned > 0 && !force {
+				confirmed, err := confirmAction(fmt.Sprintf("\n%d host(s) will be removed from the router. Do you want to continue?", pruned))
+				if err != nil {
+					return err
+				}
+				if !confirmed {
+					gokeenlog.Info("Sync cancelled")
+					return nil
+				}
+			}
+			if err := gokeenrestapi.Ip.ApplyKnownHostChanges(changes); err != nil {
+				return err
+			}
+			report.Changes = changes
+			return nil
+		}
+
+		if output == OutputTable {
+			return run()
+		}
+		if err := withProgressOnStderr(run); err != nil {
+			return err
+		}
+		return printJSON(report)
+	}
+	return cmd
+}
+
+// printKnownHostChanges displays planned known host changes to the console
+func printKnownHostChanges(changes []gokeenrestapi.KnownHostChange) {
+	for _, change := range changes {
+		action := color.GreenString(change.Action)
+		if change.Action == gokeenrestapi.KnownHostActionPrune {
+			action = color.RedString(change.Action)
+		}
+		details := ""
+		if len(change.Details) > 0 {
+			details = ": " + strings.Join(change.Details, ", ")
+		}
+		gokeenlog.InfoSubStepf("%v %v (MAC: %v)%v", action, color.CyanString(change.Name), color.BlueString(change.Mac), details)
+	}
+}
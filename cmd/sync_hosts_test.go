@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+
+	"github.com/noksa/gokeenapi/pkg/config"
+	"github.com/noksa/gokeenapi/pkg/gokeenrestapi"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SyncHosts", func() {
+	var (
+		server *httptest.Server
+		oldCfg config.GokeenapiConfig
+	)
+
+	BeforeEach(func() {
+		oldCfg = config.Cfg
+		server = setupMockRouter(gokeenrestapi.WithHotspotDevices([]gokeenrestapi.MockHost{
+			{Name: "test-device-1", Mac: "aa:bb:cc:dd:ee:ff", Registered: true},
+			{Name: "test-device-2", Mac: "11:22:33:44:55:66", Registered: true},
+		}))
+	})
+
+	AfterEach(func() {
+		cleanupMockRouter(server)
+		config.Cfg = oldCfg
+	})
+
+	It("should create command with correct attributes and flags", func() {
+		cmd := newSyncHostsCmd()
+
+		Expect(cmd.Use).To(Equal(CmdSyncHosts))
+		Expect(cmd.Aliases).To(Equal(AliasesSyncHosts))
+		Expect(cmd.Short).NotTo(BeEmpty())
+		Expect(cmd.RunE).NotTo(BeNil())
+
+		for _, flag := range []string{"prune", "dry-run", "force", "output"} {
+			Expect(cmd.Flags().Lookup(flag)).NotTo(BeNil(), flag)
+		}
+	})
+
+	It("should succeed when no hosts are configured", func() {
+		config.Cfg.Hosts = nil
+
+		cmd := newSyncHostsCmd()
+		Expect(cmd.RunE(cmd, []string{})).To(Succeed())
+	})
+
+	It("should fail on an invalid inventory", func() {
+		config.Cfg.Hosts = []config.KnownHost{{Mac: "aa:bb:cc:dd:ee:ff"}}
+
+		cmd := newSyncHostsCmd()
+		err := cmd.RunE(cmd, []string{})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("host name cannot be empty"))
+	})
+
+	It("should rename hosts, assign leases and prune unknown hosts", func() {
+		config.Cfg.Hosts = []config.KnownHost{
+			{Mac: "AA:BB:CC:DD:EE:FF", Name: "Laptop", IP: "192.168.1.50"},
+			{Mac: "22:33:44:55:66:77", Name: "Printer"},
+		}
+
+		cmd := newSyncHostsCmd()
+		_ = cmd.Flags().Set("prune", "true")
+		_ = cmd.Flags().Set("force", "true")
+		Expect(cmd.RunE(cmd, []string{})).To(Succeed())
+
+		hotspot, err := gokeenrestapi.Ip.GetAllHotspots()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(hotspot.Host).To(HaveLen(2))
+		Expect(hotspot.Host[0].Name).To(Equal("Laptop"))
+		Expect(hotspot.Host[1].Name).To(Equal("Printer"))
+
+		leases, err := gokeenrestapi.Ip.GetStaticLeases()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(leases).To(Equal(map[string]string{"aa:bb:cc:dd:ee:ff": "192.168.1.50"}))
+	})
+
+	It("should not change anything in dry-run mode and report changes as JSON", func() {
+		config.Cfg.Hosts = []config.KnownHost{{Mac: "aa:bb:cc:dd:ee:ff", Name: "Laptop"}}
+
+		cmd := newSyncHostsCmd()
+		_ = cmd.Flags().Set("prune", "true")
+		_ = cmd.Flags().Set("dry-run", "true")
+		_ = cmd.Flags().Set("output", OutputJSON)
+		out, err := captureOutput(cmd, []string{})
+		Expect(err).NotTo(HaveOccurred())
+
+		var report syncHostsReport
+		Expect(json.Unmarshal([]byte(out), &report)).To(Succeed())
+		Expect(report.DryRun).To(BeTrue())
+		Expect(report.Changes).To(HaveLen(2))
+		Expect(report.Changes[0].Action).To(Equal(gokeenrestapi.KnownHostActionUpdate))
+		Expect(report.Changes[1].Action).To(Equal(gokeenrestapi.KnownHostActionPrune))
+		Expect(report.Changes[1].Mac).To(Equal("11:22:33:44:55:66"))
+
+		hotspot, err := gokeenrestapi.Ip.GetAllHotspots()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(hotspot.Host).To(HaveLen(2))
+		Expect(hotspot.Host[0].Name).To(Equal("test-device-1"))
+	})
+})
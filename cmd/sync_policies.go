@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/noksa/gokeenapi/internal/gokeenlog"
+	"github.com/noksa/gokeenapi/pkg/config"
+	"github.com/noksa/gokeenapi/pkg/gokeenrestapi"
+	"github.com/spf13/cobra"
+)
+
+// policiesReport describes policy changes applied by sync-policies, or that would be applied in dry-run mode
+type policiesReport struct {
+	DryRun  bool                         `json:"dryRun"`
+	Changes []gokeenrestapi.PolicyChange `json:"changes"`
+}
+
+func newSyncPoliciesCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     CmdSyncPolicies,
+		Aliases: AliasesSyncPolicies,
+		Short:   "Route specific LAN devices through chosen interfaces via connection policies",
+		Long: `Synchronize IP connection policies declared in the 'policies' section of your
+configuration file with your Keenetic (Netcraze) router.
+
+Unlike DNS-routing and static routes, which select traffic by destination, a connection
+policy selects it by source: devices bound to a policy reach the internet only through
+the interfaces the policy permits, e.g. "the smart TV always goes via Wireguard0".
+
+For every policy the command will:
+1. Create the policy if it does not exist and set its description
+2. Permit the configured interfaces and deny all others
+3. Bind the devices from 'hosts' by MAC address and unbind other devices
+
+Devices of the 'hosts' inventory with 'policy' set are bound as well. Only differences
+are applied, running the command again does nothing. Policies missing from the config
+are left untouched, use delete-policies to remove them.
+
+Examples:
+  # Sync policies
+  gokeenapi sync-policies --config config.yaml
+
+  # Preview the changes
+  gokeenapi sync-policies --config config.yaml --dry-run
+
+  # Example config entries:
+  # policies:
+  #   - id: Policy0
+  #     description: VPN devices
+  #     interfaces:
+  #       - Wireguard0
+  #     hosts:
+  #       - aa:bb:cc:dd:ee:02`,
+	}
+
+	var dryRun bool
+	var output string
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "List changes without applying them")
+	addOutputFlag(cmd, &output, OutputTable, OutputJSON)
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if err := validateOutputFormat(output, OutputTable, OutputJSON); err != nil {
+			return err
+		}
+		policies := config.Cfg.Policies
+		if err := config.ValidateConnectionPolicies(policies, config.Cfg.Hosts); err != nil {
+			return err
+		}
+		if len(policies) == 0 {
+			gokeenlog.Info("No policies in the config")
+			return nil
+		}
+
+		report := policiesReport{DryRun: dryRun, Changes: []gokeenrestapi.PolicyChange{}}
+		run := func() error {
+			changes, err := gokeenrestapi.Policy.PlanPolicies(policies, config.Cfg.Hosts)
+			if err != nil {
+				return err
+			}
+			if len(changes) == 0 {
+				gokeenlog.Info("Policies are already in sync")
+				return nil
+			}
+			printPolicyChanges(changes)
+			if dryRun {
+				gokeenlog.Infof("Dry run: %d policy(ies) would be changed", len(changes))
+				report.Changes = changes
+				return nil
+			}
+			if err := gokeenrestapi.Policy.ApplyPolicyChanges(changes); err != nil {
+				return err
+			}
+			report.Changes = changes
+			return nil
+		}
+
+		if output == OutputTable {
+			return run()
+		}
+		if err := withProgressOnStderr(run); err != nil {
+			return err
+		}
+		return printJSON(report)
+	}
+	return cmd
+}
+
+// printPolicyChanges displays planned policy changes to the console
+func printPolicyChanges(changes []gokeenrestapi.PolicyChange) {
+	for _, change := range changes {
+		action := color.GreenString(change.Action)
+		if change.Action == gokeenrestapi.PolicyActionDelete {
+			action = color.RedString(change.Action)
+		}
+		details := ""
+		if len(change.Details) > 0 {
+			details = ": " + strings.Join(change.Details, ", ")
+		}
+		gokeenlog.InfoSubStepf("%v %v%v", action, color.CyanString(change.PolicyID), details)
+	}
+}
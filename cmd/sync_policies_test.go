@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+
+	"github.com/noksa/gokeenapi/pkg/config"
+	"github.com/noksa/gokeenapi/pkg/gokeenrestapi"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SyncPolicies", func() {
+	var (
+		server *httptest.Server
+		oldCfg config.GokeenapiConfig
+	)
+
+	BeforeEach(func() {
+		oldCfg = config.Cfg
+		server = setupMockRouter()
+	})
+
+	AfterEach(func() {
+		cleanupMockRouter(server)
+		config.Cfg = oldCfg
+	})
+
+	It("should create command with correct attributes and flags", func() {
+		cmd := newSyncPoliciesCmd()
+
+		Expect(cmd.Use).To(Equal(CmdSyncPolicies))
+		Expect(cmd.Aliases).To(Equal(AliasesSyncPolicies))
+		Expect(cmd.Short).NotTo(BeEmpty())
+		Expect(cmd.RunE).NotTo(BeNil())
+		Expect(cmd.Flags().Lookup("dry-run")).NotTo(BeNil())
+		Expect(cmd.Flags().Lookup("output")).NotTo(BeNil())
+	})
+
+	It("should succeed when no policies are configured", func() {
+		config.Cfg.Policies = nil
+
+		cmd := newSyncPoliciesCmd()
+		Expect(cmd.RunE(cmd, []string{})).To(Succeed())
+	})
+
+	It("should fail on invalid policies", func() {
+		config.Cfg.Policies = []config.ConnectionPolicy{{ID: "Policy0"}}
+
+		cmd := newSyncPoliciesCmd()
+		Expect(cmd.RunE(cmd, []string{})).To(MatchError(ContainSubstring("must permit at least one interface")))
+	})
+
+	It("should create the policy and bind hosts from policies and the inventory", func() {
+		// Mock has: test-device-1 (aa:bb:cc:dd:ee:ff), test-device-2 (11:22:33:44:55:66)
+		config.Cfg.Policies = []config.ConnectionPolicy{
+			{ID: "Policy0", Description: "VPN", Interfaces: []string{"Wireguard0"}, Hosts: []string{"aa:bb:cc:dd:ee:ff"}},
+		}
+		config.Cfg.Hosts = []config.KnownHost{{Mac: "11:22:33:44:55:66", Name: "test-device-2", Policy: "Policy0"}}
+
+		cmd := newSyncPoliciesCmd()
+		Expect(cmd.RunE(cmd, []string{})).To(Succeed())
+
+		policies, err := gokeenrestapi.Policy.GetPolicies()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(policies).To(HaveKey("Policy0"))
+		Expect(policies["Policy0"].Description).To(Equal("VPN"))
+
+		hostPolicies, err := gokeenrestapi.Ip.GetHostPolicies()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(hostPolicies).To(Equal(map[string]string{
+			"aa:bb:cc:dd:ee:ff": "Policy0",
+			"11:22:33:44:55:66": "Policy0",
+		}))
+	})
+
+	It("should not change anything in dry-run mode and report changes as JSON", func() {
+		config.Cfg.Policies = []config.ConnectionPolicy{{ID: "Policy0", Interfaces: []string{"Wireguard0"}}}
+
+		cmd := newSyncPoliciesCmd()
+		_ = cmd.Flags().Set("dry-run", "true")
+		_ = cmd.Flags().Set("output", OutputJSON)
+		out, err := captureOutput(cmd, []string{})
+		Expect(err).NotTo(HaveOccurred())
+
+		var report policiesReport
+		Expect(json.Unmarshal([]byte(out), &report)).To(Succeed())
+		Expect(report.DryRun).To(BeTrue())
+		Expect(report.Changes).To(HaveLen(1))
+		Expect(report.Changes[0].Action).To(Equal(gokeenrestapi.PolicyActionCreate))
+
+		policies, err := gokeenrestapi.Policy.GetPolicies()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(policies).To(BeEmpty())
+	})
+})
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/noksa/gokeenapi/internal/gokeenlog"
+	"github.com/noksa/gokeenapi/pkg/gokeenrestapi"
+	"github.com/spf13/cobra"
+	"go.uber.org/multierr"
+)
+
+func newValidateAwgCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     CmdValidateAwg,
+		Aliases: AliasesValidateAwg,
+		Short:   "Validate AmneziaWG parameters of a WireGuard .conf file",
+		Long: `Validate AmneziaWG (AWG) obfuscation parameters of a WireGuard .conf file
+without connecting to the router. Useful in CI before the file is deployed.
+
+The following rules are checked:
+- Jc is between 0 and 128, Jmin is not greater than Jmax, Jmax is at most 1280
+- Padded handshake messages fit into 1280 bytes and have different sizes (S1 + 56 != S2)
+- H1-H4 values (or AWG 2.0 ranges) are unique and do not overlap
+- I1-I5 consist of valid <b 0x..>, <c>, <t>, <r N>, <rc N> and <rd N> tags
+  (plain numbers from older configs are accepted as well)
+
+The same rules are applied automatically by add-awg, update-awg and sync-awg
+before anything is sent to the router. All problems are reported at once and
+the command exits with a non-zero code if any is found.
+
+Examples:
+  # Validate a .conf file
+  gokeenapi validate-awg --conf-file wg0.conf
+
+  # Validate an AmneziaVPN share link
+  gokeenapi validate-awg --share-link 'vpn://...'`,
+	}
+
+	var confFile, shareLink string
+	cmd.Flags().StringVar(&confFile, "conf-file", "", "Path to a WireGuard .conf file to validate")
+	addShareLinkFlag(cmd, &shareLink)
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if confFile == "" && shareLink == "" {
+			return errors.New("--conf-file flag is required (or use --share-link)")
+		}
+		confPath, _, cleanup, err := resolveAwgConfFile(confFile, shareLink)
+		if err != nil {
+			return err
+		}
+		defer cleanup()
+
+		source := confFile
+		if source == "" {
+			source = "share link"
+		}
+
+		errs := multierr.Errors(gokeenrestapi.AwgConf.ValidateConf(confPath))
+		if len(errs) == 0 {
+			gokeenlog.Infof("✅ %v: AWG parameters are valid", color.CyanString(source))
+			return nil
+		}
+
+		gokeenlog.Infof("%v %v: found %d problem(s)", color.RedString("Invalid"), color.CyanString(source), len(errs))
+		for _, e := range errs {
+			gokeenlog.InfoSubStepf("%v", e)
+		}
+		return fmt.Errorf("%s has %d invalid AWG parameter(s)", source, len(errs))
+	}
+
+	return cmd
+}
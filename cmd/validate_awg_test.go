@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ValidateAwg", func() {
+	It("should create command with correct attributes and flags", func() {
+		cmd := newValidateAwgCmd()
+
+		Expect(cmd.Use).To(Equal(CmdValidateAwg))
+		Expect(cmd.Aliases).To(Equal(AliasesValidateAwg))
+		Expect(cmd.Short).NotTo(BeEmpty())
+		Expect(cmd.RunE).NotTo(BeNil())
+		Expect(cmd.Flags().Lookup("conf-file")).NotTo(BeNil())
+		Expect(cmd.Flags().Lookup("share-link")).NotTo(BeNil())
+	})
+
+	It("should fail when conf-file is missing", func() {
+		cmd := newValidateAwgCmd()
+
+		err := cmd.RunE(cmd, []string{})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("--conf-file flag is required"))
+	})
+
+	It("should accept a valid conf file without a router", func() {
+		confPath := writeTempFile(GinkgoT().TempDir(), "valid.conf", `[Interface]
+PrivateKey = abc
+Address = 10.0.0.2/24
+Jc = 4
+Jmin = 40
+Jmax = 70
+S1 = 15
+S2 = 25
+H1 = 11
+H2 = 22
+H3 = 33
+H4 = 44
+I1 = <b 0xf6ab><r 8>
+
+[Peer]
+PublicKey = gN65BkIKy1eCE9pP1wdc8ROUunkiVXrBvGAKBEKdOQI=
+Endpoint = example.com:51820
+AllowedIPs = 0.0.0.0/0`)
+
+		cmd := newValidateAwgCmd()
+		_ = cmd.Flags().Set("conf-file", confPath)
+
+		output, err := captureOutput(cmd, []string{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(output).To(ContainSubstring("AWG parameters are valid"))
+	})
+
+	It("should report every problem of an invalid conf file", func() {
+		confPath := writeTempFile(GinkgoT().TempDir(), "invalid.conf", `[Interface]
+PrivateKey = abc
+Jc = 4
+Jmin = 100
+Jmax = 50
+S1 = 10
+S2 = 66
+H1 = 1
+H2 = 1
+H3 = 3
+H4 = 4
+I1 = <q 1>
+
+[Peer]
+PublicKey = gN65BkIKy1eCE9pP1wdc8ROUunkiVXrBvGAKBEKdOQI=`)
+
+		cmd := newValidateAwgCmd()
+		_ = cmd.Flags().Set("conf-file", confPath)
+
+		output, err := captureOutput(cmd, []string{})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("has 4 invalid AWG parameter(s)"))
+		Expect(output).To(ContainSubstring("Jmin = 100 must not be greater than Jmax = 50"))
+		Expect(output).To(ContainSubstring("S2 must not equal S1 + 56"))
+		Expect(output).To(ContainSubstring("H2 = 1 overlaps H1 = 1"))
+		Expect(output).To(ContainSubstring("I1: tag 1 <q 1>: unknown tag"))
+	})
+
+	It("should validate a share link", func() {
+		cmd := newValidateAwgCmd()
+		_ = cmd.Flags().Set("share-link", testShareLink("example.org:51820"))
+
+		Expect(cmd.RunE(cmd, []string{})).To(Succeed())
+	})
+})
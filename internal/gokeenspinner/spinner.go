@@ -65,7 +65,12 @@ func WrapWithSpinnerAndOptions(spinnerText string, f func(*SpinnerOptions) error
 	}
 
 	// Interactive terminal: use spinner
-	s := spinner.New(spinner.CharSets[70], 100*time.Millisecond)
+	// Follow stdout when it is redirected to stderr to keep machine-readable output clean
+	var spinnerOpts []spinner.Option
+	if os.Stdout == os.Stderr {
+		spinnerOpts = append(spinnerOpts, spinner.WithWriterFile(os.Stderr))
+	}
+	s := spinner.New(spinner.CharSets[70], 100*time.Millisecond, spinnerOpts...)
 	s.Prefix = fmt.Sprintf("⌛   %v ...", spinnerText)
 	s.PostUpdate = func(s *spinner.Spinner) {
 		s.Prefix = fmt.Sprintf("⌛   %v ... %s	", spinnerText, getPrettyFormatedDuration(time.Since(startTime).Round(time.Millisecond)))
@@ -40,6 +40,12 @@ type GokeenapiConfig struct {
 	Routes []Route `yaml:"routes"`
 	// DNS contains DNS records configuration
 	DNS DNS `yaml:"dns"`
+	// Awg contains WireGuard (AWG) tunnels kept in sync by sync-awg (optional)
+	Awg []AwgTunnel `yaml:"awg,omitempty"`
+	// Hosts contains the device inventory kept in sync by sync-hosts (optional)
+	Hosts []KnownHost `yaml:"hosts,omitempty"`
+	// Policies contains IP connection policies kept in sync by sync-policies (optional)
+	Policies []ConnectionPolicy `yaml:"policies,omitempty"`
 	// Logs contains logging configuration (optional)
 	Logs Logs `yaml:"logs,omitempty"`
 	// Cache contains caching configuration (optional)
@@ -145,6 +151,58 @@ type DnsRecord struct {
 	IP []string `yaml:"ip"`
 }
 
+// AwgTunnel declares a WireGuard (AWG) interface configured from a .conf file
+type AwgTunnel struct {
+	// InterfaceID is the ID of the WireGuard interface on the router (e.g., Wireguard0)
+	InterfaceID string `yaml:"interfaceId"`
+	// ConfFile is the path to the .conf file, relative paths are resolved against the config file directory
+	ConfFile string `yaml:"conf-file,omitempty"`
+	// ConfURL is the URL of the .conf file, used instead of ConfFile
+	ConfURL string `yaml:"conf-url,omitempty"`
+	// Name is the name of the interface created when it does not exist on the router (optional)
+	Name string `yaml:"name,omitempty"`
+	// Global enables global IP routing (NAT) through the interface. Default: true
+	Global *bool `yaml:"global,omitempty"`
+	// Up keeps the interface up when true and down when false. Default: true
+	Up *bool `yaml:"up,omitempty"`
+}
+
+// IsGlobal reports whether global IP routing should be enabled for the tunnel
+func (t AwgTunnel) IsGlobal() bool {
+	return t.Global == nil || *t.Global
+}
+
+// IsUp reports whether the tunnel interface should be up
+func (t AwgTunnel) IsUp() bool {
+	return t.Up == nil || *t.Up
+}
+
+// KnownHost declares a device registered on the router
+type KnownHost struct {
+	// Mac is the MAC address of the device
+	Mac string `yaml:"mac"`
+	// Name is the name the device is registered with
+	Name string `yaml:"name"`
+	// IP is the static DHCP lease of the device (optional, leases are left untouched when empty)
+	IP string `yaml:"ip,omitempty"`
+	// Policy is the ID of the IP connection policy assigned to the device, e.g. Policy0
+	// (optional, policies are left untouched when empty)
+	Policy string `yaml:"policy,omitempty"`
+}
+
+// ConnectionPolicy declares an IP connection policy: devices bound to it reach the internet
+// only through the permitted interfaces
+type ConnectionPolicy struct {
+	// ID is the policy identifier on the router, e.g. Policy0
+	ID string `yaml:"id"`
+	// Description is the policy name shown in the web interface (optional)
+	Description string `yaml:"description,omitempty"`
+	// Interfaces are IDs of interfaces the policy permits, other interfaces are denied
+	Interfaces []string `yaml:"interfaces"`
+	// Hosts are MAC addresses of devices bound to the policy (optional)
+	Hosts []string `yaml:"hosts,omitempty"`
+}
+
 // DNS contains DNS-related configuration
 type DNS struct {
 	// Records contains list of DNS records to manage
@@ -165,28 +223,101 @@ type DnsRoutes struct {
 	//       domain-file: [domains/local.txt]
 	//       interfaceId: Wireguard0
 	Groups []DnsRoutingGroup `yaml:"groups"`
+	// PrunePrefix limits pruning to router groups whose name starts with this prefix
+	// Groups created by hand (without the prefix) are never touched by prune-dns-routing
+	PrunePrefix string `yaml:"prunePrefix"`
+	// ConflictPolicy defines what happens when a domain appears in multiple groups
+	// One of: warn (default), error, first-wins, priority-wins
+	ConflictPolicy string `yaml:"conflictPolicy"`
 }
 
+// DNS-routing conflict policies for domains present in multiple groups
+const (
+	// ConflictPolicyWarn logs conflicting domains and applies groups as is
+	ConflictPolicyWarn = "warn"
+	// ConflictPolicyError aborts add-dns-routing when conflicting domains are found
+	ConflictPolicyError = "error"
+	// ConflictPolicyFirstWins keeps a conflicting domain only in the group defined first in config
+	ConflictPolicyFirstWins = "first-wins"
+	// ConflictPolicyPriorityWins keeps a conflicting domain only in the group with the highest priority
+	ConflictPolicyPriorityWins = "priority-wins"
+)
+
+// Domain list formats supported by domain-file and domain-url sources
+const (
+	// DomainFormatAuto detects the format of every source from its content
+	DomainFormatAuto = "auto"
+	// DomainFormatPlain is one domain per line, v2fly prefixes (full:, domain:) are stripped
+	DomainFormatPlain = "plain"
+	// DomainFormatHosts is the hosts file format: "0.0.0.0 domain [domain...]"
+	DomainFormatHosts = "hosts"
+	// DomainFormatDnsmasq is the dnsmasq format: "server=/domain/..." or "ipset=/domain/..."
+	DomainFormatDnsmasq = "dnsmasq"
+	// DomainFormatAdGuard is the AdGuard/Adblock format: "||domain^"
+	DomainFormatAdGuard = "adguard"
+	// DomainFormatJSON is a JSON array of domain strings
+	DomainFormatJSON = "json"
+)
+
 // DnsRoutingGroup represents a domain group with associated routing policy
 type DnsRoutingGroup struct {
 	// Name is the unique identifier for the object-group
 	Name string `yaml:"name"`
 	// File is an alternative to Name for importing groups from a YAML file with optional interfaceId override
 	// Example: {file: common_dns_groups.yaml, interfaceId: Wireguard4}
-	File string `yaml:"file"`
+	File string `yaml:"file,omitempty"`
 	// DomainFile contains list of local .txt files with domains (one per line)
 	// Can also reference .yaml files containing lists of domain-file paths
-	DomainFile []string `yaml:"domain-file"`
+	DomainFile []string `yaml:"domain-file,omitempty"`
 	// DomainURL contains list of remote URLs serving .txt files with domains
-	DomainURL []string `yaml:"domain-url"`
+	DomainURL []string `yaml:"domain-url,omitempty"`
 	// InterfaceID specifies the target interface for routing
 	InterfaceID string `yaml:"interfaceId"`
+	// CollapseSubdomains drops domains that are already covered by a parent domain in the same group
+	// Keenetic FQDN object-groups match subdomains, so "rr1.googlevideo.com" is redundant next to "googlevideo.com"
+	// When set on a file reference, it is applied to every imported group
+	CollapseSubdomains bool `yaml:"collapseSubdomains,omitempty"`
+	// Priority decides which group keeps a domain present in multiple groups under the priority-wins policy
+	// Higher value wins; groups with equal priority fall back to config order
+	// When set on a file reference, it is applied to every imported group
+	Priority int `yaml:"priority,omitempty"`
+	// Format is the format of every domain-file and domain-url source of the group
+	// One of: auto (default, detected per source), plain, hosts, dnsmasq, adguard, json
+	// When set on a file reference, it is applied to every imported group
+	Format string `yaml:"format,omitempty"`
+	// SourceFormats holds formats forced on single domain-file and domain-url entries, keyed by the resolved entry
+	// Filled from entries written as {path: ..., format: ...} or {url: ..., format: ...}; takes precedence over Format
+	SourceFormats map[string]string `yaml:"-"`
+	// Overrides routes single domains of the group through other interfaces
+	// Each override interface gets a companion object-group named by OverrideGroupName
+	// that is created, updated and deleted together with this group
+	Overrides []DnsRoutingOverride `yaml:"overrides,omitempty"`
 
 	// isFileReference is set to true when this group represents a file reference (string or file: key)
 	// This is used internally by expandGroupLists to identify which groups need expansion
 	isFileReference bool `yaml:"-"`
 }
 
+// DnsRoutingOverride routes a single domain of a DNS-routing group through another interface
+type DnsRoutingOverride struct {
+	// Domain is the domain (or IP address) moved out of the parent group
+	Domain string `yaml:"domain"`
+	// InterfaceID specifies the target interface for this domain
+	InterfaceID string `yaml:"interfaceId"`
+}
+
+// overrideGroupSeparator separates the parent group name from the interface in companion group names
+const overrideGroupSeparator = "-ovr-"
+
+// overrideGroupNameRe matches characters that are not allowed in object-group names
+var overrideGroupNameRe = regexp.MustCompile(`[^A-Za-z0-9_-]`)
+
+// OverrideGroupName returns the name of the companion object-group holding overrides of groupName routed via interfaceId
+// Example: ("youtube", "GigabitEthernet0/Vlan4") -> "youtube-ovr-GigabitEthernet0_Vlan4"
+func OverrideGroupName(groupName, interfaceId string) string {
+	return groupName + overrideGroupSeparator + overrideGroupNameRe.ReplaceAllString(interfaceId, "_")
+}
+
 // UnmarshalYAML implements custom unmarshaling for DnsRoutingGroup to support both
 // string references (file paths) and object definitions (groups)
 func (g *DnsRoutingGroup) UnmarshalYAML(node *yaml.Node) error {
@@ -198,6 +329,12 @@ func (g *DnsRoutingGroup) UnmarshalYAML(node *yaml.Node) error {
 		return nil
 	}
 
+	// Entries of domain-file and domain-url may carry their own format, collect those before decoding the lists
+	sourceFormats, err := extractSourceFormats(node)
+	if err != nil {
+		return err
+	}
+
 	// This is a mapping (object) - unmarshal normally
 	type dnsRoutingGroupAlias DnsRoutingGroup
 	alias := (*dnsRoutingGroupAlias)(g)
@@ -206,6 +343,8 @@ func (g *DnsRoutingGroup) UnmarshalYAML(node *yaml.Node) error {
 		return err
 	}
 
+	g.SourceFormats = sourceFormats
+
 	// If 'file:' key is set, this is a file reference with optional interfaceId override
 	if g.File != "" {
 		g.Name = g.File
@@ -215,6 +354,66 @@ func (g *DnsRoutingGroup) UnmarshalYAML(node *yaml.Node) error {
 	return nil
 }
 
+// domainSourceEntry is a domain-file or domain-url entry written as an object to force its format
+// Example: {path: lists/hosts.txt, format: hosts} or {url: https://example.com/filter.txt, format: adguard}
+type domainSourceEntry struct {
+	Path   string `yaml:"path"`
+	URL    string `yaml:"url"`
+	Format string `yaml:"format"`
+}
+
+// extractSourceFormats replaces object entries of the domain-file and domain-url lists of a group node
+// with their path or URL and returns the formats they force, keyed by that path or URL
+func extractSourceFormats(node *yaml.Node) (map[string]string, error) {
+	if node.Kind != yaml.MappingNode {
+		return nil, nil
+	}
+
+	var formats map[string]string
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, list := node.Content[i].Value, node.Content[i+1]
+		if (key != "domain-file" && key != "domain-url") || list.Kind != yaml.SequenceNode {
+			continue
+		}
+
+		for j, item := range list.Content {
+			if item.Kind != yaml.MappingNode {
+				continue
+			}
+
+			var entry domainSourceEntry
+			if err := item.Decode(&entry); err != nil {
+				return nil, err
+			}
+			field, source := "path", entry.Path
+			if key == "domain-url" {
+				field, source = "url", entry.URL
+			}
+			if source == "" {
+				return nil, fmt.Errorf("%s entry at line %d must set '%s'", key, item.Line, field)
+			}
+
+			if entry.Format != "" {
+				if formats == nil {
+					formats = make(map[string]string)
+				}
+				formats[source] = entry.Format
+			}
+			list.Content[j] = &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: source, Line: item.Line, Column: item.Column}
+		}
+	}
+	return formats, nil
+}
+
+// SourceFormat returns the format of a domain-file or domain-url source of the group
+// A format forced on the entry wins over the format of the group
+func (g DnsRoutingGroup) SourceFormat(source string) string {
+	if format, ok := g.SourceFormats[source]; ok {
+		return format
+	}
+	return g.Format
+}
+
 // Logs contains logging configuration options
 type Logs struct {
 	// Debug enables debug-level logging for troubleshooting
@@ -289,6 +488,16 @@ func isValidDomain(domain string) bool {
 	return true
 }
 
+// isValidDomainFormat reports whether format is a supported domain list format; empty means auto
+func isValidDomainFormat(format string) bool {
+	switch format {
+	case "", DomainFormatAuto, DomainFormatPlain, DomainFormatHosts, DomainFormatDnsmasq, DomainFormatAdGuard, DomainFormatJSON:
+		return true
+	default:
+		return false
+	}
+}
+
 // isValidIP validates an IPv4 address
 func isValidIP(ip string) bool {
 	// Use net.ParseIP for validation
@@ -349,11 +558,183 @@ func ValidateDnsRoutingGroups(groups []DnsRoutingGroup) error {
 		if len(group.InterfaceID) == 0 {
 			return errors.New("interface ID cannot be empty in DNS routing group " + group.Name + " at position " + strconv.Itoa(i))
 		}
+
+		// Check per-domain overrides
+		seenOverrides := make(map[string]bool, len(group.Overrides))
+		for _, override := range group.Overrides {
+			if len(override.Domain) == 0 {
+				return errors.New("override domain cannot be empty in DNS routing group " + group.Name)
+			}
+			if !isValidIP(override.Domain) && !isValidDomain(override.Domain) {
+				return errors.New("invalid override domain or IP address '" + override.Domain + "' in DNS routing group " + group.Name)
+			}
+			if len(override.InterfaceID) == 0 {
+				return errors.New("override interface ID cannot be empty for domain '" + override.Domain + "' in DNS routing group " + group.Name)
+			}
+			if seenOverrides[override.Domain] {
+				return errors.New("duplicate override for domain '" + override.Domain + "' in DNS routing group " + group.Name)
+			}
+			seenOverrides[override.Domain] = true
+		}
+
+		// Check domain list formats of the group and of its single sources
+		if !isValidDomainFormat(group.Format) {
+			return errors.New("invalid domain list format '" + group.Format + "' in DNS routing group " + group.Name +
+				": must be one of auto, plain, hosts, dnsmasq, adguard, json")
+		}
+		for source, format := range group.SourceFormats {
+			if !isValidDomainFormat(format) {
+				return errors.New("invalid domain list format '" + format + "' for " + source + " in DNS routing group " + group.Name +
+					": must be one of auto, plain, hosts, dnsmasq, adguard, json")
+			}
+		}
+	}
+
+	// Check that companion groups of overrides do not clash with configured groups
+	for _, group := range groups {
+		for _, override := range group.Overrides {
+			companion := OverrideGroupName(group.Name, override.InterfaceID)
+			if _, exists := seenNames[companion]; exists {
+				return errors.New("override group name '" + companion + "' of DNS routing group " + group.Name + " clashes with a configured group")
+			}
+		}
+	}
+
+	return nil
+}
+
+// ValidateAwgTunnels validates AWG tunnel configurations
+func ValidateAwgTunnels(tunnels []AwgTunnel) error {
+	seenIds := make(map[string]int)
+
+	for i, tunnel := range tunnels {
+		if len(strings.TrimSpace(tunnel.InterfaceID)) == 0 {
+			return errors.New("interface ID cannot be empty in AWG tunnel at position " + strconv.Itoa(i))
+		}
+
+		if firstIndex, exists := seenIds[tunnel.InterfaceID]; exists {
+			return errors.New("duplicate AWG tunnel interface ID '" + tunnel.InterfaceID + "' found at positions " + strconv.Itoa(firstIndex) + " and " + strconv.Itoa(i))
+		}
+		seenIds[tunnel.InterfaceID] = i
+
+		if len(tunnel.ConfFile) == 0 && len(tunnel.ConfURL) == 0 {
+			return errors.New("AWG tunnel " + tunnel.InterfaceID + " must contain conf-file or conf-url")
+		}
+		if len(tunnel.ConfFile) != 0 && len(tunnel.ConfURL) != 0 {
+			return errors.New("AWG tunnel " + tunnel.InterfaceID + " must contain only one of conf-file or conf-url")
+		}
 	}
 
 	return nil
 }
 
+// ValidateKnownHosts validates the device inventory
+func ValidateKnownHosts(hosts []KnownHost) error {
+	seenMacs := make(map[string]int)
+	seenIPs := make(map[string]int)
+
+	for i, host := range hosts {
+		mac, err := net.ParseMAC(host.Mac)
+		if err != nil || len(mac) != 6 {
+			return errors.New("invalid MAC address '" + host.Mac + "' in host at position " + strconv.Itoa(i))
+		}
+		if firstIndex, exists := seenMacs[mac.String()]; exists {
+			return errors.New("duplicate host MAC address '" + host.Mac + "' found at positions " + strconv.Itoa(firstIndex) + " and " + strconv.Itoa(i))
+		}
+		seenMacs[mac.String()] = i
+
+		if len(strings.TrimSpace(host.Name)) == 0 {
+			return errors.New("host name cannot be empty for host " + host.Mac)
+		}
+		if strings.Contains(host.Name, "\"") {
+			return errors.New("host name '" + host.Name + "' cannot contain double quotes")
+		}
+
+		if host.IP != "" {
+			ip := net.ParseIP(host.IP)
+			if ip == nil || ip.To4() == nil {
+				return errors.New("invalid IPv4 address '" + host.IP + "' for host " + host.Mac)
+			}
+			if firstIndex, exists := seenIPs[ip.String()]; exists {
+				return errors.New("duplicate host IP address '" + host.IP + "' found at positions " + strconv.Itoa(firstIndex) + " and " + strconv.Itoa(i))
+			}
+			seenIPs[ip.String()] = i
+		}
+	}
+
+	return nil
+}
+
+var connectionPolicyIdRe = regexp.MustCompile(`^Policy[0-9]+$`)
+
+// ValidateConnectionPolicies validates IP connection policies. Devices may be bound to a policy
+// either in policies or with 'policy' in hosts, conflicting bindings are rejected
+func ValidateConnectionPolicies(policies []ConnectionPolicy, hosts []KnownHost) error {
+	seenIds := make(map[string]int)
+	boundHosts := make(map[string]string)
+
+	for i, policy := range policies {
+		if !connectionPolicyIdRe.MatchString(policy.ID) {
+			return errors.New("invalid policy ID '" + policy.ID + "' at position " + strconv.Itoa(i) + ": must look like Policy0")
+		}
+		if firstIndex, exists := seenIds[policy.ID]; exists {
+			return errors.New("duplicate policy ID '" + policy.ID + "' found at positions " + strconv.Itoa(firstIndex) + " and " + strconv.Itoa(i))
+		}
+		seenIds[policy.ID] = i
+
+		if strings.Contains(policy.Description, "\"") {
+			return errors.New("description of policy " + policy.ID + " cannot contain double quotes")
+		}
+		if len(policy.Interfaces) == 0 {
+			return errors.New("policy " + policy.ID + " must permit at least one interface")
+		}
+		seenInterfaces := make(map[string]bool)
+		for _, interfaceId := range policy.Interfaces {
+			if len(strings.TrimSpace(interfaceId)) == 0 {
+				return errors.New("interface ID cannot be empty in policy " + policy.ID)
+			}
+			if seenInterfaces[interfaceId] {
+				return errors.New("duplicate interface '" + interfaceId + "' in policy " + policy.ID)
+			}
+			seenInterfaces[interfaceId] = true
+		}
+
+		for _, host := range policy.Hosts {
+			mac, err := net.ParseMAC(host)
+			if err != nil || len(mac) != 6 {
+				return errors.New("invalid MAC address '" + host + "' in policy " + policy.ID)
+			}
+			if other, exists := boundHosts[mac.String()]; exists {
+				return errors.New("host " + host + " is bound to both " + other + " and " + policy.ID)
+			}
+			boundHosts[mac.String()] = policy.ID
+		}
+	}
+
+	for _, host := range hosts {
+		mac, err := net.ParseMAC(host.Mac)
+		if err != nil || host.Policy == "" {
+			continue
+		}
+		if other, exists := boundHosts[mac.String()]; exists && other != host.Policy {
+			return errors.New("host " + host.Mac + " is bound to " + other + " in policies and to " + host.Policy + " in hosts")
+		}
+	}
+
+	return nil
+}
+
+// ValidateConflictPolicy validates the DNS-routing conflict policy
+// An empty policy is valid and behaves as warn
+func ValidateConflictPolicy(policy string) error {
+	switch policy {
+	case "", ConflictPolicyWarn, ConflictPolicyError, ConflictPolicyFirstWins, ConflictPolicyPriorityWins:
+		return nil
+	}
+	return errors.New("invalid DNS routing conflict policy '" + policy + "': must be one of " +
+		strings.Join([]string{ConflictPolicyWarn, ConflictPolicyError, ConflictPolicyFirstWins, ConflictPolicyPriorityWins}, ", "))
+}
+
 // ValidateDomainList validates a list of domains/IPs
 func ValidateDomainList(domains []string, groupName string) error {
 	for j, domain := range domains {
@@ -441,9 +822,21 @@ func LoadConfig(configPath string) error {
 		return err
 	}
 
+	resolveAwgConfFiles(configPath)
+
 	return nil
 }
 
+// resolveAwgConfFiles resolves relative conf-file paths of AWG tunnels against the config file directory
+func resolveAwgConfFiles(configPath string) {
+	configDir := filepath.Dir(configPath)
+	for i, tunnel := range Cfg.Awg {
+		if tunnel.ConfFile != "" && !filepath.IsAbs(tunnel.ConfFile) {
+			Cfg.Awg[i].ConfFile = filepath.Join(configDir, tunnel.ConfFile)
+		}
+	}
+}
+
 // expandBatLists expands .yaml files in bat-file and bat-url arrays to their contained lists
 // This function reads each YAML file only once and extracts both bat-file and bat-url lists
 func expandBatLists(configPath string) error {
@@ -592,11 +985,20 @@ func expandGroupLists(configPath string) error {
 				groupsList = loaded
 			}
 
-			// Append all groups from the file, applying interfaceId override if specified
+			// Append all groups from the file, applying interfaceId, collapseSubdomains, priority and format overrides if specified
 			for _, importedGroup := range groupsList.Groups {
 				if group.InterfaceID != "" {
 					importedGroup.InterfaceID = group.InterfaceID
 				}
+				if group.CollapseSubdomains {
+					importedGroup.CollapseSubdomains = true
+				}
+				if group.Priority != 0 {
+					importedGroup.Priority = group.Priority
+				}
+				if group.Format != "" {
+					importedGroup.Format = group.Format
+				}
 				expandedGroups = append(expandedGroups, importedGroup)
 			}
 		} else {
@@ -665,6 +1067,10 @@ func loadGroupsListFromYAML(listPath, configPath string) (*GroupsList, error) {
 					return nil, errors.New("failed to resolve absolute path for " + joined + ": " + err.Error())
 				}
 				groupsList.Groups[i].DomainFile[j] = absPath
+				if format, ok := groupsList.Groups[i].SourceFormats[domainFile]; ok {
+					delete(groupsList.Groups[i].SourceFormats, domainFile)
+					groupsList.Groups[i].SourceFormats[absPath] = format
+				}
 			}
 		}
 	}
@@ -681,6 +1087,21 @@ func expandDomainLists(configPath string) error {
 	for i := range Cfg.DNS.Routes.Groups {
 		var expandedDomainFiles []string
 		var expandedDomainURLs []string
+		// Formats forced on entries are re-keyed by the expanded sources; a format on a .yaml list applies to every listed source
+		entryFormats := Cfg.DNS.Routes.Groups[i].SourceFormats
+		var sourceFormats map[string]string
+		setFormat := func(entry string, sources ...string) {
+			format, ok := entryFormats[entry]
+			if !ok {
+				return
+			}
+			if sourceFormats == nil {
+				sourceFormats = make(map[string]string)
+			}
+			for _, source := range sources {
+				sourceFormats[source] = format
+			}
+		}
 
 		// Collect all unique YAML file paths from both domain-file and domain-url
 		yamlFiles := make(map[string]bool)
@@ -712,6 +1133,7 @@ func expandDomainLists(configPath string) error {
 				// Load domain files from cached YAML
 				if domainLists, exists := yamlCache[domainFile]; exists {
 					expandedDomainFiles = append(expandedDomainFiles, domainLists.DomainFile...)
+					setFormat(domainFile, domainLists.DomainFile...)
 				}
 			} else {
 				// Regular .txt file - if already absolute (resolved in loadGroupsListFromYAML), keep as is
@@ -722,6 +1144,7 @@ func expandDomainLists(configPath string) error {
 					resolvedPath = filepath.Join(configDir, resolvedPath)
 				}
 				expandedDomainFiles = append(expandedDomainFiles, resolvedPath)
+				setFormat(domainFile, resolvedPath)
 			}
 		}
 
@@ -731,15 +1154,18 @@ func expandDomainLists(configPath string) error {
 				// Load domain URLs from cached YAML
 				if domainLists, exists := yamlCache[domainURL]; exists {
 					expandedDomainURLs = append(expandedDomainURLs, domainLists.DomainURL...)
+					setFormat(domainURL, domainLists.DomainURL...)
 				}
 			} else {
 				// Regular URL, keep as is
 				expandedDomainURLs = append(expandedDomainURLs, domainURL)
+				setFormat(domainURL, domainURL)
 			}
 		}
 
 		Cfg.DNS.Routes.Groups[i].DomainFile = expandedDomainFiles
 		Cfg.DNS.Routes.Groups[i].DomainURL = expandedDomainURLs
+		Cfg.DNS.Routes.Groups[i].SourceFormats = sourceFormats
 	}
 	return nil
 }
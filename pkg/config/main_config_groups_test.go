@@ -252,4 +252,145 @@ dns:
 		Expect(Cfg.DNS.Routes.Groups).To(HaveLen(1))
 		Expect(Cfg.DNS.Routes.Groups[0].InterfaceID).To(Equal("Wireguard0"))
 	})
+
+	It("should apply collapseSubdomains to imported groups when using file: syntax", func() {
+		tmpDir := GinkgoT().TempDir()
+		domainsDir := filepath.Join(tmpDir, "domains")
+		Expect(os.MkdirAll(domainsDir, 0755)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(domainsDir, "video.txt"), []byte("googlevideo.com"), 0644)).To(Succeed())
+
+		Expect(os.WriteFile(filepath.Join(tmpDir, "common.yaml"), []byte(`groups:
+  - name: video
+    domain-file:
+      - domains/video.txt
+    interfaceId: Wireguard0`), 0644)).To(Succeed())
+
+		configPath := filepath.Join(tmpDir, "config.yaml")
+		Expect(os.WriteFile(configPath, []byte(`keenetic:
+  url: "http://192.168.1.1"
+  login: "admin"
+  password: "password"
+dns:
+  routes:
+    groups:
+      - file: common.yaml
+        collapseSubdomains: true`), 0644)).To(Succeed())
+
+		Expect(LoadConfig(configPath)).To(Succeed())
+		Expect(Cfg.DNS.Routes.Groups).To(HaveLen(1))
+		Expect(Cfg.DNS.Routes.Groups[0].CollapseSubdomains).To(BeTrue())
+		Expect(Cfg.DNS.Routes.Groups[0].InterfaceID).To(Equal("Wireguard0"))
+	})
+
+	It("should load conflictPolicy and apply priority to imported groups", func() {
+		tmpDir := GinkgoT().TempDir()
+		domainsDir := filepath.Join(tmpDir, "domains")
+		Expect(os.MkdirAll(domainsDir, 0755)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(domainsDir, "video.txt"), []byte("youtube.com"), 0644)).To(Succeed())
+
+		Expect(os.WriteFile(filepath.Join(tmpDir, "common.yaml"), []byte(`groups:
+  - name: video
+    domain-file:
+      - domains/video.txt
+    interfaceId: Wireguard0`), 0644)).To(Succeed())
+
+		configPath := filepath.Join(tmpDir, "config.yaml")
+		Expect(os.WriteFile(configPath, []byte(`keenetic:
+  url: "http://192.168.1.1"
+  login: "admin"
+  password: "password"
+dns:
+  routes:
+    conflictPolicy: priority-wins
+    groups:
+      - file: common.yaml
+        priority: 10
+      - name: local
+        domain-file:
+          - domains/video.txt
+        interfaceId: ISP`), 0644)).To(Succeed())
+
+		Expect(LoadConfig(configPath)).To(Succeed())
+		Expect(Cfg.DNS.Routes.ConflictPolicy).To(Equal(ConflictPolicyPriorityWins))
+		Expect(Cfg.DNS.Routes.Groups).To(HaveLen(2))
+		Expect(Cfg.DNS.Routes.Groups[0].Name).To(Equal("video"))
+		Expect(Cfg.DNS.Routes.Groups[0].Priority).To(Equal(10))
+		Expect(Cfg.DNS.Routes.Groups[1].Priority).To(BeZero())
+	})
+
+	It("should keep formats forced on single domain-file and domain-url entries", func() {
+		tmpDir := GinkgoT().TempDir()
+		domainsDir := filepath.Join(tmpDir, "domains")
+		Expect(os.MkdirAll(domainsDir, 0755)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(domainsDir, "hosts.txt"), []byte("0.0.0.0 ads.com"), 0644)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(domainsDir, "plain.txt"), []byte("plain.com"), 0644)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(domainsDir, "filters.yaml"), []byte(`domain-url:
+  - https://example.com/one.txt
+  - https://example.com/two.txt`), 0644)).To(Succeed())
+
+		Expect(os.WriteFile(filepath.Join(tmpDir, "common.yaml"), []byte(`groups:
+  - name: imported
+    domain-file:
+      - path: domains/hosts.txt
+        format: hosts
+    interfaceId: Wireguard0`), 0644)).To(Succeed())
+
+		configPath := filepath.Join(tmpDir, "config.yaml")
+		Expect(os.WriteFile(configPath, []byte(`keenetic:
+  url: "http://192.168.1.1"
+  login: "admin"
+  password: "password"
+dns:
+  routes:
+    groups:
+      - common.yaml
+      - name: mixed
+        format: plain
+        domain-file:
+          - {path: domains/hosts.txt, format: hosts}
+          - domains/plain.txt
+        domain-url:
+          - url: https://example.com/adguard.txt
+            format: adguard
+          - {url: domains/filters.yaml, format: adguard}
+        interfaceId: Wireguard0`), 0644)).To(Succeed())
+
+		Expect(LoadConfig(configPath)).To(Succeed())
+		Expect(Cfg.DNS.Routes.Groups).To(HaveLen(2))
+
+		hostsPath := filepath.Join(domainsDir, "hosts.txt")
+		imported := Cfg.DNS.Routes.Groups[0]
+		Expect(imported.DomainFile).To(Equal([]string{hostsPath}))
+		Expect(imported.SourceFormat(hostsPath)).To(Equal(DomainFormatHosts))
+
+		mixed := Cfg.DNS.Routes.Groups[1]
+		Expect(mixed.DomainFile).To(Equal([]string{hostsPath, filepath.Join(domainsDir, "plain.txt")}))
+		Expect(mixed.DomainURL).To(Equal([]string{"https://example.com/adguard.txt", "https://example.com/one.txt", "https://example.com/two.txt"}))
+		Expect(mixed.SourceFormat(hostsPath)).To(Equal(DomainFormatHosts))
+		Expect(mixed.SourceFormat(filepath.Join(domainsDir, "plain.txt"))).To(Equal(DomainFormatPlain))
+		Expect(mixed.SourceFormat("https://example.com/adguard.txt")).To(Equal(DomainFormatAdGuard))
+		Expect(mixed.SourceFormat("https://example.com/one.txt")).To(Equal(DomainFormatAdGuard))
+		Expect(mixed.SourceFormat("https://example.com/two.txt")).To(Equal(DomainFormatAdGuard))
+	})
+
+	It("should reject a domain-url entry object without url", func() {
+		tmpDir := GinkgoT().TempDir()
+		configPath := filepath.Join(tmpDir, "config.yaml")
+		Expect(os.WriteFile(configPath, []byte(`keenetic:
+  url: "http://192.168.1.1"
+  login: "admin"
+  password: "password"
+dns:
+  routes:
+    groups:
+      - name: broken
+        domain-url:
+          - path: https://example.com/list.txt
+            format: hosts
+        interfaceId: Wireguard0`), 0644)).To(Succeed())
+
+		err := LoadConfig(configPath)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("domain-url entry at line 10 must set 'url'"))
+	})
 })
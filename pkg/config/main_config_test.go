@@ -189,4 +189,76 @@ dns:
 			Expect(buf.String()).To(BeEmpty())
 		})
 	})
+
+	Context("awg tunnels", func() {
+		It("should resolve relative conf-file paths against the config directory", func() {
+			tmpDir := GinkgoT().TempDir()
+			configPath := filepath.Join(tmpDir, "config.yaml")
+			Expect(os.WriteFile(configPath, []byte(`keenetic:
+  url: "http://192.168.1.1"
+awg:
+  - interfaceId: Wireguard0
+    conf-file: wg/office.conf
+  - interfaceId: Wireguard1
+    conf-file: /etc/wg/home.conf
+    global: false
+    up: false
+  - interfaceId: Wireguard2
+    conf-url: https://example.com/backup.conf`), 0o600)).To(Succeed())
+
+			Expect(LoadConfig(configPath)).To(Succeed())
+			Expect(Cfg.Awg).To(HaveLen(3))
+			Expect(Cfg.Awg[0].ConfFile).To(Equal(filepath.Join(tmpDir, "wg", "office.conf")))
+			Expect(Cfg.Awg[0].IsGlobal()).To(BeTrue())
+			Expect(Cfg.Awg[0].IsUp()).To(BeTrue())
+			Expect(Cfg.Awg[1].ConfFile).To(Equal("/etc/wg/home.conf"))
+			Expect(Cfg.Awg[1].IsGlobal()).To(BeFalse())
+			Expect(Cfg.Awg[1].IsUp()).To(BeFalse())
+			Expect(Cfg.Awg[2].ConfFile).To(BeEmpty())
+			Expect(Cfg.Awg[2].ConfURL).To(Equal("https://example.com/backup.conf"))
+		})
+	})
+
+	Context("hosts", func() {
+		It("should load the device inventory", func() {
+			tmpDir := GinkgoT().TempDir()
+			configPath := filepath.Join(tmpDir, "config.yaml")
+			Expect(os.WriteFile(configPath, []byte(`keenetic:
+  url: "http://192.168.1.1"
+hosts:
+  - mac: aa:bb:cc:dd:ee:01
+    name: NAS
+    ip: 192.168.1.10
+  - mac: aa:bb:cc:dd:ee:02
+    name: TV
+    policy: Policy0`), 0o600)).To(Succeed())
+
+			Expect(LoadConfig(configPath)).To(Succeed())
+			Expect(Cfg.Hosts).To(Equal([]KnownHost{
+				{Mac: "aa:bb:cc:dd:ee:01", Name: "NAS", IP: "192.168.1.10"},
+				{Mac: "aa:bb:cc:dd:ee:02", Name: "TV", Policy: "Policy0"},
+			}))
+		})
+	})
+
+	Context("policies", func() {
+		It("should load connection policies", func() {
+			tmpDir := GinkgoT().TempDir()
+			configPath := filepath.Join(tmpDir, "config.yaml")
+			Expect(os.WriteFile(configPath, []byte(`keenetic:
+  url: "http://192.168.1.1"
+policies:
+  - id: Policy0
+    description: VPN devices
+    interfaces:
+      - Wireguard0
+    hosts:
+      - aa:bb:cc:dd:ee:02`), 0o600)).To(Succeed())
+
+			Expect(LoadConfig(configPath)).To(Succeed())
+			Expect(Cfg.Policies).To(Equal([]ConnectionPolicy{
+				{ID: "Policy0", Description: "VPN devices", Interfaces: []string{"Wireguard0"}, Hosts: []string{"aa:bb:cc:dd:ee:02"}},
+			}))
+		})
+	})
 })
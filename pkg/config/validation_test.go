@@ -66,6 +66,132 @@ var _ = Describe("ValidateDnsRoutingGroups", func() {
 	})
 })
 
+var _ = Describe("ValidateAwgTunnels", func() {
+	It("should accept valid tunnels", func() {
+		tunnels := []AwgTunnel{
+			{InterfaceID: "Wireguard0", ConfFile: "/path/to/office.conf"},
+			{InterfaceID: "Wireguard1", ConfURL: "https://example.com/backup.conf"},
+		}
+		Expect(ValidateAwgTunnels(tunnels)).To(Succeed())
+	})
+
+	It("should reject empty interface ID", func() {
+		err := ValidateAwgTunnels([]AwgTunnel{{InterfaceID: " ", ConfFile: "/path/to/office.conf"}})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("interface ID cannot be empty"))
+	})
+
+	It("should reject duplicate interface IDs", func() {
+		tunnels := []AwgTunnel{
+			{InterfaceID: "Wireguard0", ConfFile: "/path/to/office.conf"},
+			{InterfaceID: "Wireguard0", ConfURL: "https://example.com/backup.conf"},
+		}
+		err := ValidateAwgTunnels(tunnels)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("duplicate AWG tunnel interface ID 'Wireguard0'"))
+	})
+
+	It("should reject tunnels without conf source", func() {
+		err := ValidateAwgTunnels([]AwgTunnel{{InterfaceID: "Wireguard0"}})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("must contain conf-file or conf-url"))
+	})
+
+	It("should reject tunnels with both conf-file and conf-url", func() {
+		err := ValidateAwgTunnels([]AwgTunnel{{InterfaceID: "Wireguard0", ConfFile: "/path/to/office.conf", ConfURL: "https://example.com/office.conf"}})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("only one of conf-file or conf-url"))
+	})
+})
+
+var _ = Describe("ValidateKnownHosts", func() {
+	It("should accept valid hosts", func() {
+		hosts := []KnownHost{
+			{Mac: "aa:bb:cc:dd:ee:01", Name: "NAS", IP: "192.168.1.10"},
+			{Mac: "AA:BB:CC:DD:EE:02", Name: "TV", Policy: "Policy0"},
+		}
+		Expect(ValidateKnownHosts(hosts)).To(Succeed())
+	})
+
+	It("should reject invalid MAC addresses", func() {
+		err := ValidateKnownHosts([]KnownHost{{Mac: "not-a-mac", Name: "NAS"}})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("invalid MAC address 'not-a-mac'"))
+	})
+
+	It("should reject duplicate MAC addresses regardless of case", func() {
+		hosts := []KnownHost{
+			{Mac: "aa:bb:cc:dd:ee:01", Name: "NAS"},
+			{Mac: "AA:BB:CC:DD:EE:01", Name: "TV"},
+		}
+		err := ValidateKnownHosts(hosts)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("found at positions 0 and 1"))
+	})
+
+	It("should reject empty names and names with quotes", func() {
+		Expect(ValidateKnownHosts([]KnownHost{{Mac: "aa:bb:cc:dd:ee:01", Name: " "}})).To(MatchError(ContainSubstring("host name cannot be empty")))
+		Expect(ValidateKnownHosts([]KnownHost{{Mac: "aa:bb:cc:dd:ee:01", Name: `My "NAS"`}})).To(MatchError(ContainSubstring("cannot contain double quotes")))
+	})
+
+	It("should reject invalid and duplicate IP addresses", func() {
+		Expect(ValidateKnownHosts([]KnownHost{{Mac: "aa:bb:cc:dd:ee:01", Name: "NAS", IP: "fe80::1"}})).To(MatchError(ContainSubstring("invalid IPv4 address")))
+
+		hosts := []KnownHost{
+			{Mac: "aa:bb:cc:dd:ee:01", Name: "NAS", IP: "192.168.1.10"},
+			{Mac: "aa:bb:cc:dd:ee:02", Name: "TV", IP: "192.168.1.10"},
+		}
+		Expect(ValidateKnownHosts(hosts)).To(MatchError(ContainSubstring("duplicate host IP address '192.168.1.10'")))
+	})
+})
+
+var _ = Describe("ValidateConnectionPolicies", func() {
+	It("should accept valid policies", func() {
+		policies := []ConnectionPolicy{
+			{ID: "Policy0", Description: "VPN", Interfaces: []string{"Wireguard0", "ISP"}, Hosts: []string{"aa:bb:cc:dd:ee:01"}},
+			{ID: "Policy1", Interfaces: []string{"ISP"}},
+		}
+		hosts := []KnownHost{{Mac: "AA:BB:CC:DD:EE:01", Name: "TV", Policy: "Policy0"}}
+		Expect(ValidateConnectionPolicies(policies, hosts)).To(Succeed())
+	})
+
+	It("should reject invalid and duplicate policy IDs", func() {
+		Expect(ValidateConnectionPolicies([]ConnectionPolicy{{ID: "vpn", Interfaces: []string{"ISP"}}}, nil)).
+			To(MatchError(ContainSubstring("invalid policy ID 'vpn'")))
+
+		policies := []ConnectionPolicy{
+			{ID: "Policy0", Interfaces: []string{"ISP"}},
+			{ID: "Policy0", Interfaces: []string{"Wireguard0"}},
+		}
+		Expect(ValidateConnectionPolicies(policies, nil)).To(MatchError(ContainSubstring("duplicate policy ID 'Policy0'")))
+	})
+
+	It("should reject policies without interfaces or with duplicate interfaces", func() {
+		Expect(ValidateConnectionPolicies([]ConnectionPolicy{{ID: "Policy0"}}, nil)).
+			To(MatchError(ContainSubstring("must permit at least one interface")))
+		Expect(ValidateConnectionPolicies([]ConnectionPolicy{{ID: "Policy0", Interfaces: []string{"ISP", "ISP"}}}, nil)).
+			To(MatchError(ContainSubstring("duplicate interface 'ISP'")))
+	})
+
+	It("should reject invalid MACs and hosts bound to multiple policies", func() {
+		Expect(ValidateConnectionPolicies([]ConnectionPolicy{{ID: "Policy0", Interfaces: []string{"ISP"}, Hosts: []string{"tv"}}}, nil)).
+			To(MatchError(ContainSubstring("invalid MAC address 'tv'")))
+
+		policies := []ConnectionPolicy{
+			{ID: "Policy0", Interfaces: []string{"ISP"}, Hosts: []string{"aa:bb:cc:dd:ee:01"}},
+			{ID: "Policy1", Interfaces: []string{"ISP"}, Hosts: []string{"AA:BB:CC:DD:EE:01"}},
+		}
+		Expect(ValidateConnectionPolicies(policies, nil)).To(MatchError(ContainSubstring("is bound to both Policy0 and Policy1")))
+	})
+
+	It("should reject bindings conflicting with the hosts inventory", func() {
+		policies := []ConnectionPolicy{{ID: "Policy0", Interfaces: []string{"ISP"}, Hosts: []string{"aa:bb:cc:dd:ee:01"}}}
+		hosts := []KnownHost{{Mac: "aa:bb:cc:dd:ee:01", Name: "TV", Policy: "Policy1"}}
+		Expect(ValidateConnectionPolicies(policies, hosts)).
+			To(MatchError(ContainSubstring("bound to Policy0 in policies and to Policy1 in hosts")))
+	})
+})
+
 var _ = Describe("ValidateDomainList", func() {
 	It("should accept valid domains", func() {
 		Expect(ValidateDomainList([]string{"example.com", "sub.example.com", "another-domain.org"}, "testgroup")).To(Succeed())
@@ -107,3 +233,93 @@ var _ = Describe("ValidateDomainList", func() {
 		Expect(ValidateDomainList([]string{}, "testgroup")).To(Succeed())
 	})
 })
+
+var _ = Describe("ValidateConflictPolicy", func() {
+	It("should accept all known policies and an empty value", func() {
+		for _, policy := range []string{"", ConflictPolicyWarn, ConflictPolicyError, ConflictPolicyFirstWins, ConflictPolicyPriorityWins} {
+			Expect(ValidateConflictPolicy(policy)).To(Succeed(), "policy %q", policy)
+		}
+	})
+
+	It("should reject unknown policies", func() {
+		err := ValidateConflictPolicy("last-wins")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("invalid DNS routing conflict policy 'last-wins'"))
+	})
+})
+
+var _ = Describe("ValidateDnsRoutingGroups format", func() {
+	It("should accept known domain list formats", func() {
+		for _, format := range []string{"", DomainFormatAuto, DomainFormatPlain, DomainFormatHosts, DomainFormatDnsmasq, DomainFormatAdGuard, DomainFormatJSON} {
+			groups := []DnsRoutingGroup{{Name: "group", DomainFile: []string{"/path/to/domains.txt"}, InterfaceID: "Wireguard0", Format: format}}
+			Expect(ValidateDnsRoutingGroups(groups)).To(Succeed(), "format %q", format)
+		}
+	})
+
+	It("should reject unknown domain list formats", func() {
+		groups := []DnsRoutingGroup{{Name: "group", DomainFile: []string{"/path/to/domains.txt"}, InterfaceID: "Wireguard0", Format: "yaml"}}
+		err := ValidateDnsRoutingGroups(groups)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("invalid domain list format 'yaml'"))
+	})
+
+	It("should reject unknown formats forced on a single source", func() {
+		groups := []DnsRoutingGroup{{Name: "group", DomainFile: []string{"/path/to/domains.txt"}, InterfaceID: "Wireguard0",
+			SourceFormats: map[string]string{"/path/to/domains.txt": "csv"}}}
+		err := ValidateDnsRoutingGroups(groups)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("invalid domain list format 'csv' for /path/to/domains.txt"))
+	})
+})
+
+var _ = Describe("ValidateDnsRoutingGroups overrides", func() {
+	newGroup := func(overrides ...DnsRoutingOverride) DnsRoutingGroup {
+		return DnsRoutingGroup{Name: "video", DomainFile: []string{"/path/to/domains.txt"}, InterfaceID: "Wireguard0", Overrides: overrides}
+	}
+
+	It("should accept valid overrides", func() {
+		groups := []DnsRoutingGroup{newGroup(
+			DnsRoutingOverride{Domain: "googlevideo.com", InterfaceID: "Wireguard1"},
+			DnsRoutingOverride{Domain: "8.8.8.8", InterfaceID: "ISP"},
+		)}
+		Expect(ValidateDnsRoutingGroups(groups)).To(Succeed())
+	})
+
+	DescribeTable("should reject invalid overrides",
+		func(override DnsRoutingOverride, message string) {
+			err := ValidateDnsRoutingGroups([]DnsRoutingGroup{newGroup(override)})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(message))
+		},
+		Entry("empty domain", DnsRoutingOverride{InterfaceID: "Wireguard1"}, "override domain cannot be empty"),
+		Entry("invalid domain", DnsRoutingOverride{Domain: "not a domain", InterfaceID: "Wireguard1"}, "invalid override domain"),
+		Entry("empty interface", DnsRoutingOverride{Domain: "googlevideo.com"}, "override interface ID cannot be empty"),
+	)
+
+	It("should reject duplicate override domains", func() {
+		groups := []DnsRoutingGroup{newGroup(
+			DnsRoutingOverride{Domain: "googlevideo.com", InterfaceID: "Wireguard1"},
+			DnsRoutingOverride{Domain: "googlevideo.com", InterfaceID: "ISP"},
+		)}
+		err := ValidateDnsRoutingGroups(groups)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("duplicate override"))
+	})
+
+	It("should reject companion groups clashing with configured groups", func() {
+		groups := []DnsRoutingGroup{
+			newGroup(DnsRoutingOverride{Domain: "googlevideo.com", InterfaceID: "Wireguard1"}),
+			{Name: "video-ovr-Wireguard1", DomainFile: []string{"/path/to/other.txt"}, InterfaceID: "Wireguard1"},
+		}
+		err := ValidateDnsRoutingGroups(groups)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("clashes with a configured group"))
+	})
+})
+
+var _ = Describe("OverrideGroupName", func() {
+	It("should replace characters not allowed in object-group names", func() {
+		Expect(OverrideGroupName("video", "Wireguard1")).To(Equal("video-ovr-Wireguard1"))
+		Expect(OverrideGroupName("video", "GigabitEthernet0/Vlan4")).To(Equal("video-ovr-GigabitEthernet0_Vlan4"))
+	})
+})
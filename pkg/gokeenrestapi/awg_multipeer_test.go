@@ -0,0 +1,198 @@
+package gokeenrestapi
+
+import (
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	"github.com/noksa/gokeenapi/pkg/gokeenrestapimodels"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AWG multi-peer", func() {
+	const (
+		peerA = "peerAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA="
+		peerB = "peerBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB="
+		peerC = "peerCCCCCCCCCCCCCCCCCCCCCCCCCCCCCCCCCCCCCCC="
+	)
+
+	var server *httptest.Server
+
+	BeforeEach(func() {
+		server = SetupMockRouterForTest(
+			WithScInterfaces(map[string]MockScInterface{
+				"Wireguard0": {
+					Description: "Site-to-site mesh",
+					IP:          MockIP{Address: "10.9.9.1/24"},
+					Wireguard: MockWireguard{
+						Asc: MockAsc{Jc: "0", Jmin: "0", Jmax: "0", S1: "0", S2: "0", H1: "0", H2: "0", H3: "0", H4: "0"},
+						Peer: []MockPeer{
+							{
+								Key:               peerA,
+								Endpoint:          "198.51.100.1:51820",
+								KeepaliveInterval: 25,
+								AllowedIPs:        []MockAllowedIP{{Address: "10.1.0.0", Mask: "255.255.0.0"}},
+							},
+							{
+								Key:               peerB,
+								Endpoint:          "198.51.100.2:51820",
+								KeepaliveInterval: 25,
+								AllowedIPs:        []MockAllowedIP{{Address: "10.2.0.0", Mask: "255.255.0.0"}},
+							},
+						},
+					},
+				},
+			}),
+		)
+	})
+
+	AfterEach(func() {
+		if server != nil {
+			server.Close()
+		}
+	})
+
+	createConf := func(content string) string {
+		confPath := filepath.Join(GinkgoT().TempDir(), "mesh.conf")
+		Expect(os.WriteFile(confPath, []byte(content), 0644)).To(Succeed())
+		return confPath
+	}
+
+	parseCommands := func(commands []gokeenrestapimodels.ParseRequest) []string {
+		var result []string
+		for _, command := range commands {
+			result = append(result, command.Parse)
+		}
+		return result
+	}
+
+	It("should parse every [Peer] section in file order", func() {
+		confPath := createConf(`[Interface]
+PrivateKey = abc
+Address = 10.9.9.1/24
+
+[Peer]
+PublicKey = ` + peerB + `
+Endpoint = 198.51.100.2:51820
+AllowedIPs = 10.2.0.0/16
+
+[Peer]
+PublicKey = ` + peerC + `
+AllowedIPs = 10.3.0.0/16, 10.4.0.0/16
+PersistentKeepalive = 15`)
+
+		_, peers, err := parseConfFile(confPath)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(peers).To(HaveLen(2))
+		Expect(peers[0].PublicKey).To(Equal(peerB))
+		Expect(peers[0].Endpoint).To(Equal("198.51.100.2:51820"))
+		Expect(peers[1].PublicKey).To(Equal(peerC))
+		Expect(peers[1].AllowedIPs).To(Equal([]string{"10.3.0.0/16", "10.4.0.0/16"}))
+		Expect(peers[1].PersistentKeepalive).To(Equal(15))
+	})
+
+	It("should reject duplicate peers", func() {
+		confPath := createConf(`[Interface]
+PrivateKey = abc
+
+[Peer]
+PublicKey = ` + peerA + `
+
+[Peer]
+PublicKey = ` + peerA)
+
+		_, _, err := parseConfFile(confPath)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("duplicate [Peer]"))
+	})
+
+	It("should remove absent peers, update changed peers and add new peers", func() {
+		confPath := createConf(`[Interface]
+PrivateKey = abc
+Address = 10.9.9.1/24
+
+[Peer]
+PublicKey = ` + peerB + `
+Endpoint = 198.51.100.22:51820
+AllowedIPs = 10.2.0.0/16
+PersistentKeepalive = 25
+
+[Peer]
+PublicKey = ` + peerC + `
+Endpoint = 198.51.100.3:51820
+AllowedIPs = 10.3.0.0/16
+PersistentKeepalive = 25`)
+
+		commands, err := AwgConf.PlanUpdate(confPath, "Wireguard0")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(parseCommands(commands)).To(Equal([]string{
+			"no interface Wireguard0 wireguard peer " + peerA,
+			"interface Wireguard0 wireguard peer " + peerB + " endpoint 198.51.100.22:51820",
+			"interface Wireguard0 wireguard peer " + peerC + " endpoint 198.51.100.3:51820",
+			"interface Wireguard0 wireguard peer " + peerC + " keepalive-interval 25",
+			"interface Wireguard0 wireguard peer " + peerC + " allow-ips 10.3.0.0/16",
+		}))
+
+		Expect(AwgConf.ConfigureOrUpdateInterface(confPath, "Wireguard0")).To(Succeed())
+
+		details, err := Interface.GetInterfaceViaRciShowScInterfaces("Wireguard0")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(details.Wireguard.Peer).To(HaveLen(2))
+		Expect(details.Wireguard.Peer[0].Key).To(Equal(peerB))
+		Expect(details.Wireguard.Peer[0].Endpoint.Address).To(Equal("198.51.100.22:51820"))
+		Expect(details.Wireguard.Peer[1].Key).To(Equal(peerC))
+
+		commands, err = AwgConf.PlanUpdate(confPath, "Wireguard0")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(commands).To(BeEmpty())
+	})
+
+	It("should not show a diff when peers are only reordered", func() {
+		confPath := createConf(`[Interface]
+PrivateKey = abc
+Address = 10.9.9.1/24
+
+[Peer]
+PublicKey = ` + peerB + `
+Endpoint = 198.51.100.2:51820
+AllowedIPs = 10.2.0.0/16
+PersistentKeepalive = 25
+
+[Peer]
+PublicKey = ` + peerA + `
+Endpoint = 198.51.100.1:51820
+AllowedIPs = 10.1.0.0/16
+PersistentKeepalive = 25`)
+
+		commands, err := AwgConf.PlanUpdate(confPath, "Wireguard0")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(commands).To(BeEmpty())
+
+		diff, err := AwgConf.DiffUpdate(confPath, "Wireguard0")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diff).To(BeEmpty())
+	})
+
+	It("should show removed and added peers in the diff", func() {
+		confPath := createConf(`[Interface]
+PrivateKey = abc
+Address = 10.9.9.1/24
+
+[Peer]
+PublicKey = ` + peerA + `
+Endpoint = 198.51.100.1:51820
+AllowedIPs = 10.1.0.0/16
+PersistentKeepalive = 25
+
+[Peer]
+PublicKey = ` + peerC + `
+AllowedIPs = 10.3.0.0/16`)
+
+		diff, err := AwgConf.DiffUpdate(confPath, "Wireguard0")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diff).To(ContainSubstring("-PublicKey = " + peerB))
+		Expect(diff).To(ContainSubstring("+PublicKey = " + peerC))
+		Expect(diff).NotTo(ContainSubstring("-PublicKey = " + peerA))
+	})
+})
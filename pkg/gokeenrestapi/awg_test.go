@@ -471,3 +471,102 @@ Endpoint = x:1`)
 		Expect(err).To(HaveOccurred())
 	})
 })
+
+var _ = Describe("AWG ExportConf", func() {
+	var server *httptest.Server
+
+	newServer := func(privateKey string) {
+		server = SetupMockRouterForTest(
+			WithScInterfaces(map[string]MockScInterface{
+				"Wireguard0": {
+					IP: MockIP{Address: "10.0.0.2", Mask: "255.255.255.0", Mtu: "1420"},
+					Wireguard: MockWireguard{
+						PrivateKey: privateKey,
+						Asc:        MockAsc{Jc: "4", Jmin: "40", Jmax: "70", S1: "15", S2: "25", H1: "11", H2: "22", H3: "33", H4: "44", I1: "<b 0xf6ab>"},
+						Peer: []MockPeer{
+							{
+								Key:               "gN65BkIKy1eCE9pP1wdc8ROUunkiVXrBvGAKBEKdOQI=",
+								Endpoint:          "vpn.example.com:51820",
+								KeepaliveInterval: 25,
+								PresharedKey:      "psk",
+								AllowedIPs:        []MockAllowedIP{{Address: "0.0.0.0", Mask: "0.0.0.0"}},
+							},
+						},
+					},
+				},
+			}),
+		)
+	}
+
+	AfterEach(func() {
+		if server != nil {
+			server.Close()
+		}
+	})
+
+	It("should render a complete conf file with the private key from the router", func() {
+		newServer("cOFA+3p5IjkzIjkzIjkzIjkzIjkzIjkzIjkzIjkzIjk=")
+
+		conf, placeholder, err := AwgConf.ExportConf("Wireguard0")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(placeholder).To(BeFalse())
+		Expect(conf).To(Equal(`[Interface]
+PrivateKey = cOFA+3p5IjkzIjkzIjkzIjkzIjkzIjkzIjkzIjkzIjk=
+Address = 10.0.0.2/24
+MTU = 1420
+Jc = 4
+Jmin = 40
+Jmax = 70
+S1 = 15
+S2 = 25
+H1 = 11
+H2 = 22
+H3 = 33
+H4 = 44
+I1 = <b 0xf6ab>
+
+[Peer]
+PublicKey = gN65BkIKy1eCE9pP1wdc8ROUunkiVXrBvGAKBEKdOQI=
+PresharedKey = psk
+Endpoint = vpn.example.com:51820
+AllowedIPs = 0.0.0.0/0
+PersistentKeepalive = 25
+`))
+	})
+
+	It("should write a placeholder when the router does not expose the private key", func() {
+		newServer("")
+
+		conf, placeholder, err := AwgConf.ExportConf("Wireguard0")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(placeholder).To(BeTrue())
+		Expect(conf).To(ContainSubstring("PrivateKey = " + AwgPrivateKeyPlaceholder + "\n"))
+	})
+
+	It("should produce a conf file that parses back into the same parameters", func() {
+		newServer("cOFA+3p5IjkzIjkzIjkzIjkzIjkzIjkzIjkzIjkzIjk=")
+
+		conf, _, err := AwgConf.ExportConf("Wireguard0")
+		Expect(err).NotTo(HaveOccurred())
+		confPath := filepath.Join(GinkgoT().TempDir(), "wg0.conf")
+		Expect(os.WriteFile(confPath, []byte(conf), 0600)).To(Succeed())
+
+		asc, peers, err := parseConfFile(confPath)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(asc.Jc).To(Equal("4"))
+		Expect(asc.H4).To(Equal("44"))
+		Expect(peers).To(HaveLen(1))
+		peer := peers[0]
+		Expect(peer.PublicKey).To(Equal("gN65BkIKy1eCE9pP1wdc8ROUunkiVXrBvGAKBEKdOQI="))
+		Expect(peer.AllowedIPs).To(Equal([]string{"0.0.0.0/0"}))
+		Expect(extractMtuFromConf(confPath)).To(Equal("1420"))
+	})
+
+	It("should reject interfaces that are not WireGuard", func() {
+		newServer("")
+
+		_, _, err := AwgConf.ExportConf("ISP")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("not a WireGuard interface"))
+	})
+})
@@ -108,21 +108,26 @@ type peerParams struct {
 	PresharedKey        string
 }
 
-// parseConfFile reads and parses a WireGuard .conf file, returning ASC and peer parameters.
+// parseConfFile reads and parses a WireGuard .conf file, returning ASC parameters and all peers.
 // ASC parameters are optional (standard WireGuard won't have them).
-func parseConfFile(confPath string) (ascParams, peerParams, error) {
+// Every [Peer] section is returned in file order, peers without a PublicKey are skipped.
+func parseConfFile(confPath string) (ascParams, []peerParams, error) {
+	return parseConfSource(confPath)
+}
+
+// parseConfSource parses a WireGuard .conf from a file path or raw []byte content, see parseConfFile
+func parseConfSource(source any) (ascParams, []peerParams, error) {
 	var asc ascParams
-	var peer peerParams
 
-	cfg, err := ini.Load(confPath)
+	cfg, err := ini.LoadSources(ini.LoadOptions{AllowNonUniqueSections: true}, source)
 	if err != nil {
-		return asc, peer, err
+		return asc, nil, err
 	}
 
 	// Parse [Interface] section — ASC params are optional
 	interfaceSection, err := cfg.GetSection("Interface")
 	if err != nil {
-		return asc, peer, fmt.Errorf("conf file missing [Interface] section: %w", err)
+		return asc, nil, fmt.Errorf("conf file missing [Interface] section: %w", err)
 	}
 
 	// AWG 1.0 params (all optional)
@@ -177,12 +182,32 @@ func parseConfFile(confPath string) (ascParams, peerParams, error) {
 		asc.I5 = key.String()
 	}
 
-	// Parse [Peer] section
-	peerSection, err := cfg.GetSection("Peer")
+	// Parse [Peer] sections, peers are keyed by public key
+	peerSections, err := cfg.SectionsByName("Peer")
 	if err != nil {
-		return asc, peer, fmt.Errorf("conf file missing [Peer] section: %w", err)
+		return asc, nil, fmt.Errorf("conf file missing [Peer] section: %w", err)
+	}
+
+	var peers []peerParams
+	seen := make(map[string]bool, len(peerSections))
+	for _, peerSection := range peerSections {
+		peer := parsePeerSection(peerSection)
+		if peer.PublicKey == "" {
+			continue
+		}
+		if seen[peer.PublicKey] {
+			return asc, nil, fmt.Errorf("conf file has duplicate [Peer] with PublicKey %s", peer.PublicKey)
+		}
+		seen[peer.PublicKey] = true
+		peers = append(peers, peer)
 	}
 
+	return asc, peers, nil
+}
+
+// parsePeerSection parses a single [Peer] section of a .conf file
+func parsePeerSection(peerSection *ini.Section) peerParams {
+	var peer peerParams
 	if key, err := peerSection.GetKey("PublicKey"); err == nil {
 		peer.PublicKey = key.String()
 	}
@@ -207,8 +232,7 @@ func parseConfFile(confPath string) (ascParams, peerParams, error) {
 			}
 		}
 	}
-
-	return asc, peer, nil
+	return peer
 }
 
 // ascNeedsUpdate compares parsed ASC params with current interface state
@@ -263,14 +287,7 @@ func defaultZero(s string) string {
 
 // peerNeedsUpdate compares parsed peer params with current interface peer state
 func peerNeedsUpdate(parsed peerParams, currentPeers []gokeenrestapimodels.Peer) bool {
-	// Find matching peer by public key
-	var currentPeer *gokeenrestapimodels.Peer
-	for i := range currentPeers {
-		if currentPeers[i].Key == parsed.PublicKey {
-			currentPeer = &currentPeers[i]
-			break
-		}
-	}
+	currentPeer := findPeer(parsed.PublicKey, currentPeers)
 
 	// If peer not found in current state, it definitely needs update
 	if currentPeer == nil {
@@ -300,6 +317,16 @@ func peerNeedsUpdate(parsed peerParams, currentPeers []gokeenrestapimodels.Peer)
 	return false
 }
 
+// findPeer returns the current peer with the given public key or nil
+func findPeer(publicKey string, currentPeers []gokeenrestapimodels.Peer) *gokeenrestapimodels.Peer {
+	for i := range currentPeers {
+		if currentPeers[i].Key == publicKey {
+			return &currentPeers[i]
+		}
+	}
+	return nil
+}
+
 // allowedIPsChanged checks if the allowed IP list from conf differs from current state
 func allowedIPsChanged(parsed []string, current []gokeenrestapimodels.AllowIps) bool {
 	if len(parsed) != len(current) {
@@ -356,13 +383,7 @@ func buildPeerCommands(interfaceId string, parsed peerParams, currentPeers []gok
 	}
 
 	// Find current peer state for comparison
-	var currentPeer *gokeenrestapimodels.Peer
-	for i := range currentPeers {
-		if currentPeers[i].Key == parsed.PublicKey {
-			currentPeer = &currentPeers[i]
-			break
-		}
-	}
+	currentPeer := findPeer(parsed.PublicKey, currentPeers)
 
 	// Update endpoint
 	if parsed.Endpoint != "" {
@@ -418,12 +439,40 @@ func buildPeerCommands(interfaceId string, parsed peerParams, currentPeers []gok
 	return commands
 }
 
+// buildPeersCommands generates RCI commands to bring all peers of the interface in sync with the conf file.
+// Peers are matched by public key: router peers absent from the conf are removed,
+// new and changed peers are added or updated
+func buildPeersCommands(interfaceId string, parsed []peerParams, currentPeers []gokeenrestapimodels.Peer) []gokeenrestapimodels.ParseRequest {
+	var commands []gokeenrestapimodels.ParseRequest
+
+	desired := make(map[string]bool, len(parsed))
+	for _, peer := range parsed {
+		desired[peer.PublicKey] = true
+	}
+	for _, currentPeer := range currentPeers {
+		if !desired[currentPeer.Key] {
+			commands = append(commands, gokeenrestapimodels.ParseRequest{
+				Parse: fmt.Sprintf("no interface %v wireguard peer %v", interfaceId, currentPeer.Key),
+			})
+		}
+	}
+
+	for _, peer := range parsed {
+		if peerNeedsUpdate(peer, currentPeers) {
+			commands = append(commands, buildPeerCommands(interfaceId, peer, currentPeers)...)
+		}
+	}
+
+	return commands
+}
+
 // ConfigureOrUpdateInterface updates an existing WireGuard interface with configuration from a .conf file.
 // It compares the current interface state with the conf file and applies only the necessary changes.
 // Supports:
 //   - ASC obfuscation parameters (AWG 1.0: Jc, Jmin, Jmax, S1, S2, H1-H4) — optional
 //   - AWG 2.0 parameters (S3, S4, I1-I5) — optional, requires KeeneticOS 5.1+
-//   - Peer endpoint, allowed IPs, keepalive interval, preshared key
+//   - Peer endpoint, allowed IPs, keepalive interval, preshared key for every [Peer] section
+//   - Removal of router peers that are absent from the conf file
 func (*keeneticAwgconf) ConfigureOrUpdateInterface(confPath, interfaceId string) error {
 	commands, err := AwgConf.PlanUpdate(confPath, interfaceId)
 	if err != nil {
@@ -462,7 +511,7 @@ func (*keeneticAwgconf) PlanUpdate(confPath, interfaceId string) ([]gokeenrestap
 		return nil, err
 	}
 
-	asc, peer, err := parseConfFile(confPath)
+	asc, peers, err := parseValidConfSource(confPath)
 	if err != nil {
 		return nil, err
 	}
@@ -482,19 +531,47 @@ func (*keeneticAwgconf) PlanUpdate(confPath, interfaceId string) ([]gokeenrestap
 		})
 	}
 
-	if peer.PublicKey != "" && peerNeedsUpdate(peer, interfaceDetails.Wireguard.Peer) {
-		parseSlice = append(parseSlice, buildPeerCommands(interfaceId, peer, interfaceDetails.Wireguard.Peer)...)
+	// A conf without peers leaves the router peers untouched
+	if len(peers) > 0 {
+		parseSlice = append(parseSlice, buildPeersCommands(interfaceId, peers, interfaceDetails.Wireguard.Peer)...)
 	}
 
 	return parseSlice, nil
 }
 
+// FetchConf downloads a .conf file from a URL and returns its content.
+// The content is never cached because it contains the private key
+func (*keeneticAwgconf) FetchConf(url string) (string, error) {
+	var content string
+	err := gokeenspinner.WrapWithSpinner(fmt.Sprintf("Fetching %v url", color.CyanString(url)), func() error {
+		response, err := GetURLClient().R().Get(url)
+		if err != nil {
+			return err
+		}
+		if response.StatusCode() != 200 {
+			return fmt.Errorf("unexpected status code %d", response.StatusCode())
+		}
+		content = string(response.Body())
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	if _, _, err := parseConfSource([]byte(content)); err != nil {
+		return "", fmt.Errorf("invalid conf file from %s: %w", url, err)
+	}
+	return content, nil
+}
+
 // AddInterface creates a new WireGuard interface from a .conf file
 func (*keeneticAwgconf) AddInterface(confFile string, name string) (gokeenrestapimodels.CreatedInterface, error) {
 	b, err := os.ReadFile(confFile)
 	if err != nil {
 		return gokeenrestapimodels.CreatedInterface{}, err
 	}
+	if _, _, err := parseValidConfSource(b); err != nil {
+		return gokeenrestapimodels.CreatedInterface{}, err
+	}
 	if name == "" {
 		name = filepath.Base(confFile)
 	}
@@ -543,7 +620,7 @@ func (*keeneticAwgconf) DiffUpdate(confPath, interfaceId string) (string, error)
 	}
 
 	// Parse the new conf file into structured params
-	asc, peer, err := parseConfFile(confPath)
+	asc, peers, err := parseValidConfSource(confPath)
 	if err != nil {
 		return "", err
 	}
@@ -561,7 +638,7 @@ func (*keeneticAwgconf) DiffUpdate(confPath, interfaceId string) (string, error)
 
 	// Render both sides in canonical .conf format
 	currentConf := renderCanonicalConf(interfaceDetails)
-	newConf := renderCanonicalConfFromParams(interfaceDetails, asc, peer, newMtu)
+	newConf := renderCanonicalConfFromParams(interfaceDetails, asc, peers, newMtu)
 
 	if currentConf == newConf {
 		return "", nil
@@ -578,6 +655,45 @@ func (*keeneticAwgconf) DiffUpdate(confPath, interfaceId string) (string, error)
 	return difflib.GetUnifiedDiffString(diff)
 }
 
+// ExportConf renders an existing WireGuard interface as a complete .conf file with [Interface] and [Peer] sections.
+// The private key is taken from the router when exposed by the API, otherwise AwgPrivateKeyPlaceholder is written.
+// Returns the conf content and whether the private key is a placeholder
+func (*keeneticAwgconf) ExportConf(interfaceId string) (string, bool, error) {
+	if err := Checks.CheckInterfaceId(interfaceId); err != nil {
+		return "", false, err
+	}
+	iface, err := Interface.GetInterfaceViaRciShowInterfaces(interfaceId)
+	if err != nil {
+		return "", false, err
+	}
+	if iface.Type != InterfaceTypeWireguard {
+		return "", false, fmt.Errorf("interface %s is not a WireGuard interface (type: %s)", interfaceId, iface.Type)
+	}
+
+	interfaceDetails, err := Interface.GetInterfaceViaRciShowScInterfaces(interfaceId)
+	if err != nil {
+		return "", false, err
+	}
+
+	conf := renderExportConf(interfaceDetails)
+	return conf, interfaceDetails.Wireguard.PrivateKey == "", nil
+}
+
+// renderExportConf renders router state as a complete .conf file
+// It is the canonical rendering with the private key added to the [Interface] section
+func renderExportConf(sc gokeenrestapimodels.RciShowScInterface) string {
+	privateKey := sc.Wireguard.PrivateKey
+	if privateKey == "" {
+		privateKey = AwgPrivateKeyPlaceholder
+	}
+
+	var b strings.Builder
+	b.WriteString("[Interface]\n")
+	b.WriteString(fmt.Sprintf("PrivateKey = %s\n", privateKey))
+	b.WriteString(strings.TrimPrefix(renderCanonicalConf(sc), "[Interface]\n"))
+	return b.String()
+}
+
 // extractMtuFromConf reads MTU from a conf file's [Interface] section
 func extractMtuFromConf(confPath string) string {
 	cfg, err := ini.Load(confPath)
@@ -616,7 +732,7 @@ func renderCanonicalConf(sc gokeenrestapimodels.RciShowScInterface) string {
 
 // renderCanonicalConfFromParams renders the "desired" state using parsed params,
 // but preserving address/MTU from current if not changed.
-func renderCanonicalConfFromParams(current gokeenrestapimodels.RciShowScInterface, asc ascParams, peer peerParams, mtu string) string {
+func renderCanonicalConfFromParams(current gokeenrestapimodels.RciShowScInterface, asc ascParams, peers []peerParams, mtu string) string {
 	var b strings.Builder
 
 	b.WriteString("[Interface]\n")
@@ -638,9 +754,11 @@ func renderCanonicalConfFromParams(current gokeenrestapimodels.RciShowScInterfac
 		writeASCBlock(&b, current.Wireguard.Asc)
 	}
 
-	// Peer: use new params if present, otherwise current
-	if peer.PublicKey != "" {
-		writePeerParamsBlock(&b, peer)
+	// Peers: use new params if present, otherwise current
+	if len(peers) > 0 {
+		for _, peer := range orderPeersLikeCurrent(peers, current.Wireguard.Peer) {
+			writePeerParamsBlock(&b, peer)
+		}
 	} else {
 		for _, p := range current.Wireguard.Peer {
 			writePeerBlock(&b, p)
@@ -650,6 +768,27 @@ func renderCanonicalConfFromParams(current gokeenrestapimodels.RciShowScInterfac
 	return b.String()
 }
 
+// orderPeersLikeCurrent orders peers the way the router lists them, so a reordered conf does not show up in the diff.
+// Peers that are not on the router yet follow in conf file order
+func orderPeersLikeCurrent(peers []peerParams, currentPeers []gokeenrestapimodels.Peer) []peerParams {
+	ordered := make([]peerParams, 0, len(peers))
+	used := make(map[string]bool, len(peers))
+	for _, currentPeer := range currentPeers {
+		for _, peer := range peers {
+			if peer.PublicKey == currentPeer.Key && !used[peer.PublicKey] {
+				ordered = append(ordered, peer)
+				used[peer.PublicKey] = true
+			}
+		}
+	}
+	for _, peer := range peers {
+		if !used[peer.PublicKey] {
+			ordered = append(ordered, peer)
+		}
+	}
+	return ordered
+}
+
 // addressToCIDR converts Address model (address + dotted mask) to CIDR notation
 func addressToCIDR(addr gokeenrestapimodels.Address) string {
 	if addr.Mask == "" {
@@ -0,0 +1,297 @@
+package gokeenrestapi
+
+import (
+	"crypto/ecdh"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"net"
+	"net/netip"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// AWG obfuscation limits. Padded handshake packets must fit into the minimal IPv6 MTU (1280 bytes)
+const (
+	// awgMaxPacketSize is the upper bound for junk packets and padded handshake messages
+	awgMaxPacketSize = 1280
+	// awgInitMessageSize is the size of the WireGuard handshake initiation message
+	awgInitMessageSize = 148
+	// awgResponseMessageSize is the size of the WireGuard handshake response message
+	awgResponseMessageSize = 92
+	// awgCookieMessageSize is the size of the WireGuard cookie reply message
+	awgCookieMessageSize = 64
+	// awgTransportHeaderSize is the size of the WireGuard transport data header
+	awgTransportHeaderSize = 32
+	// awgMinHeader is the smallest allowed H1-H4 value, 1-4 are the standard WireGuard message types
+	awgMinHeader = 5
+	// awgMaxHeader is the largest H1-H4 value, headers are 32-bit unsigned message types
+	awgMaxHeader = math.MaxUint32
+)
+
+// AwgGenerateOptions describes a client WireGuard interface generated from scratch
+type AwgGenerateOptions struct {
+	// Endpoint is the server address in host:port format
+	Endpoint string
+	// PeerPublicKey is the public key of the server
+	PeerPublicKey string
+	// Address is the tunnel address of the router, a bare IP is treated as /32 (/128 for IPv6)
+	Address string
+	// AllowedIPs are the networks routed through the tunnel
+	AllowedIPs []string
+	// PersistentKeepalive is the keepalive interval in seconds, 0 disables it
+	PersistentKeepalive int
+	// MTU is the interface MTU, 0 keeps the router default
+	MTU int
+	// PresharedKey generates a preshared key for the peer when true
+	PresharedKey bool
+	// AWG2 adds AWG 2.0 parameters S3 and S4 (KeeneticOS 5.1+)
+	AWG2 bool
+	// Name is the peer comment in the server-side stanza
+	Name string
+}
+
+// GeneratedAwgConf is a generated client .conf together with the matching server-side stanza
+type GeneratedAwgConf struct {
+	// Conf is the client .conf file imported on the router, it contains the private key
+	Conf string
+	// PublicKey is the public key of the generated client keypair
+	PublicKey string
+	// ServerStanza holds the ASC lines for the server [Interface] section and the [Peer] section for the router
+	ServerStanza string
+}
+
+// GenerateConf generates a Curve25519 keypair and random valid ASC parameters and renders
+// a client .conf file for the router together with the server-side peer stanza
+func (*keeneticAwgconf) GenerateConf(opts AwgGenerateOptions) (GeneratedAwgConf, error) {
+	if err := validateEndpoint(opts.Endpoint); err != nil {
+		return GeneratedAwgConf{}, err
+	}
+	if err := validateWireguardKey(opts.PeerPublicKey); err != nil {
+		return GeneratedAwgConf{}, fmt.Errorf("invalid peer public key: %w", err)
+	}
+	address, err := parseTunnelAddress(opts.Address)
+	if err != nil {
+		return GeneratedAwgConf{}, err
+	}
+	if len(opts.AllowedIPs) == 0 {
+		return GeneratedAwgConf{}, errors.New("at least one allowed IP is required")
+	}
+	for _, cidr := range opts.AllowedIPs {
+		if _, err := netip.ParsePrefix(cidr); err != nil {
+			return GeneratedAwgConf{}, fmt.Errorf("invalid allowed IP '%s': %w", cidr, err)
+		}
+	}
+
+	privateKey, publicKey, err := generateKeyPair()
+	if err != nil {
+		return GeneratedAwgConf{}, err
+	}
+	asc, err := generateASCParams(opts.AWG2)
+	if err != nil {
+		return GeneratedAwgConf{}, err
+	}
+	peer := peerParams{
+		PublicKey:           opts.PeerPublicKey,
+		Endpoint:            opts.Endpoint,
+		AllowedIPs:          opts.AllowedIPs,
+		PersistentKeepalive: opts.PersistentKeepalive,
+	}
+	if opts.PresharedKey {
+		peer.PresharedKey, err = generateKey()
+		if err != nil {
+			return GeneratedAwgConf{}, err
+		}
+	}
+
+	var conf strings.Builder
+	conf.WriteString("[Interface]\n")
+	conf.WriteString(fmt.Sprintf("PrivateKey = %s\n", privateKey))
+	conf.WriteString(fmt.Sprintf("Address = %s\n", address))
+	if opts.MTU > 0 {
+		conf.WriteString(fmt.Sprintf("MTU = %d\n", opts.MTU))
+	}
+	writeASCParamsBlock(&conf, asc)
+	writePeerParamsBlock(&conf, peer)
+
+	var server strings.Builder
+	server.WriteString("# Add to the [Interface] section of the server, AmneziaWG parameters must match on both sides\n")
+	writeASCParamsBlock(&server, asc)
+	server.WriteString("\n[Peer]\n")
+	if opts.Name != "" {
+		server.WriteString(fmt.Sprintf("# %s\n", opts.Name))
+	}
+	server.WriteString(fmt.Sprintf("PublicKey = %s\n", publicKey))
+	if peer.PresharedKey != "" {
+		server.WriteString(fmt.Sprintf("PresharedKey = %s\n", peer.PresharedKey))
+	}
+	server.WriteString(fmt.Sprintf("AllowedIPs = %s\n", netip.PrefixFrom(address.Addr(), address.Addr().BitLen())))
+
+	return GeneratedAwgConf{
+		Conf:         conf.String(),
+		PublicKey:    publicKey,
+		ServerStanza: server.String(),
+	}, nil
+}
+
+// generateKeyPair generates a WireGuard Curve25519 keypair and returns base64 encoded private and public keys
+func generateKeyPair() (string, string, error) {
+	var private [32]byte
+	if _, err := rand.Read(private[:]); err != nil {
+		return "", "", fmt.Errorf("failed to generate private key: %w", err)
+	}
+	// Clamp the private key the same way as "wg genkey"
+	private[0] &= 248
+	private[31] = (private[31] & 127) | 64
+
+	key, err := ecdh.X25519().NewPrivateKey(private[:])
+	if err != nil {
+		return "", "", fmt.Errorf("failed to generate private key: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(private[:]), base64.StdEncoding.EncodeToString(key.PublicKey().Bytes()), nil
+}
+
+// generateKey generates a random 32-byte base64 encoded key, used for preshared keys
+func generateKey() (string, error) {
+	var key [32]byte
+	if _, err := rand.Read(key[:]); err != nil {
+		return "", fmt.Errorf("failed to generate key: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(key[:]), nil
+}
+
+// validateWireguardKey checks that key is a base64 encoded 32-byte WireGuard key
+func validateWireguardKey(key string) error {
+	decoded, err := base64.StdEncoding.DecodeString(key)
+	if err != nil {
+		return fmt.Errorf("key is not valid base64: %w", err)
+	}
+	if len(decoded) != 32 {
+		return fmt.Errorf("key must be 32 bytes, got %d", len(decoded))
+	}
+	return nil
+}
+
+// validateEndpoint checks that endpoint is in host:port format with a valid port
+func validateEndpoint(endpoint string) error {
+	host, port, err := net.SplitHostPort(endpoint)
+	if err != nil {
+		return fmt.Errorf("invalid endpoint '%s': %w", endpoint, err)
+	}
+	if host == "" {
+		return fmt.Errorf("invalid endpoint '%s': host cannot be empty", endpoint)
+	}
+	portNumber, err := strconv.Atoi(port)
+	if err != nil || portNumber < 1 || portNumber > 65535 {
+		return fmt.Errorf("invalid endpoint '%s': port must be between 1 and 65535", endpoint)
+	}
+	return nil
+}
+
+// parseTunnelAddress parses the tunnel address of the router, a bare IP is treated as a single host prefix
+func parseTunnelAddress(address string) (netip.Prefix, error) {
+	if address == "" {
+		return netip.Prefix{}, errors.New("tunnel address is required")
+	}
+	if !strings.Contains(address, "/") {
+		addr, err := netip.ParseAddr(address)
+		if err != nil {
+			return netip.Prefix{}, fmt.Errorf("invalid tunnel address '%s': %w", address, err)
+		}
+		return netip.PrefixFrom(addr, addr.BitLen()), nil
+	}
+	prefix, err := netip.ParsePrefix(address)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("invalid tunnel address '%s': %w", address, err)
+	}
+	return prefix, nil
+}
+
+// generateASCParams returns random AWG 1.0 parameters, plus S3 and S4 when awg2 is set.
+// Values respect the protocol constraints:
+//   - 1 <= Jc <= 128 and Jmin < Jmax <= 1280
+//   - padded handshake messages fit into 1280 bytes and all padded message sizes differ,
+//     so S1+56 != S2 and the messages cannot be told apart by length
+//   - H1-H4 are unique and never collide with the standard WireGuard message types 1-4
+func generateASCParams(awg2 bool) (ascParams, error) {
+	var err error
+	next := func(min, max int) int {
+		if err != nil {
+			return 0
+		}
+		var n *big.Int
+		n, err = rand.Int(rand.Reader, big.NewInt(int64(max-min+1)))
+		if err != nil {
+			return 0
+		}
+		return min + int(n.Int64())
+	}
+
+	jc := next(3, 10)
+	jmin := next(10, 100)
+	jmax := next(jmin+10, min(jmin+1000, awgMaxPacketSize))
+
+	var s1, s2, s3, s4 int
+	for {
+		s1 = next(15, 150)
+		s2 = next(15, 150)
+		sizes := []int{awgInitMessageSize + s1, awgResponseMessageSize + s2}
+		if awg2 {
+			s3 = next(15, 150)
+			s4 = next(1, 32)
+			sizes = append(sizes, awgCookieMessageSize+s3, awgTransportHeaderSize+s4)
+		}
+		if err != nil || allDistinct(sizes) {
+			break
+		}
+	}
+
+	// Headers span the whole uint32 range, which does not fit into int on 32-bit platforms
+	var headers []int64
+	for len(headers) < 4 && err == nil {
+		var n *big.Int
+		n, err = rand.Int(rand.Reader, big.NewInt(awgMaxHeader-awgMinHeader+1))
+		if err != nil {
+			break
+		}
+		if h := awgMinHeader + n.Int64(); !slices.Contains(headers, h) {
+			headers = append(headers, h)
+		}
+	}
+	if err != nil {
+		return ascParams{}, fmt.Errorf("failed to generate ASC parameters: %w", err)
+	}
+
+	asc := ascParams{
+		Jc:   strconv.Itoa(jc),
+		Jmin: strconv.Itoa(jmin),
+		Jmax: strconv.Itoa(jmax),
+		S1:   strconv.Itoa(s1),
+		S2:   strconv.Itoa(s2),
+		H1:   strconv.FormatInt(headers[0], 10),
+		H2:   strconv.FormatInt(headers[1], 10),
+		H3:   strconv.FormatInt(headers[2], 10),
+		H4:   strconv.FormatInt(headers[3], 10),
+	}
+	if awg2 {
+		asc.S3 = strconv.Itoa(s3)
+		asc.S4 = strconv.Itoa(s4)
+	}
+	return asc, nil
+}
+
+// allDistinct reports whether all values are different
+func allDistinct(values []int) bool {
+	seen := make(map[int]bool, len(values))
+	for _, value := range values {
+		if seen[value] {
+			return false
+		}
+		seen[value] = true
+	}
+	return true
+}
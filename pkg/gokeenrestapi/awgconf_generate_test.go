@@ -0,0 +1,164 @@
+package gokeenrestapi
+
+import (
+	"crypto/ecdh"
+	"encoding/base64"
+	"strconv"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gopkg.in/ini.v1"
+	"pgregory.net/rapid"
+)
+
+// testServerPublicKey is a syntactically valid WireGuard public key
+const testServerPublicKey = "SeRvErPuBlIcKeY0000000000000000000000000000="
+
+// atoi converts a generated ASC value, failing the test on malformed numbers
+func atoi(value string) int {
+	n, err := strconv.Atoi(value)
+	ExpectWithOffset(1, err).NotTo(HaveOccurred())
+	return n
+}
+
+var _ = Describe("AWG GenerateConf", func() {
+	validOpts := func() AwgGenerateOptions {
+		return AwgGenerateOptions{
+			Endpoint:            "vpn.example.com:51820",
+			PeerPublicKey:       testServerPublicKey,
+			Address:             "10.8.0.2",
+			AllowedIPs:          []string{"0.0.0.0/0"},
+			PersistentKeepalive: 25,
+		}
+	}
+
+	It("renders a client conf and a matching server stanza", func() {
+		opts := validOpts()
+		opts.MTU = 1420
+		opts.PresharedKey = true
+		opts.Name = "home"
+
+		generated, err := AwgConf.GenerateConf(opts)
+		Expect(err).NotTo(HaveOccurred())
+
+		cfg, err := ini.Load([]byte(generated.Conf))
+		Expect(err).NotTo(HaveOccurred())
+		iface := cfg.Section("Interface")
+		Expect(iface.Key("Address").String()).To(Equal("10.8.0.2/32"))
+		Expect(iface.Key("MTU").String()).To(Equal("1420"))
+		peer := cfg.Section("Peer")
+		Expect(peer.Key("PublicKey").String()).To(Equal(testServerPublicKey))
+		Expect(peer.Key("Endpoint").String()).To(Equal("vpn.example.com:51820"))
+		Expect(peer.Key("PersistentKeepalive").String()).To(Equal("25"))
+		psk := peer.Key("PresharedKey").String()
+		Expect(validateWireguardKey(psk)).To(Succeed())
+
+		privateKey, err := base64.StdEncoding.DecodeString(iface.Key("PrivateKey").String())
+		Expect(err).NotTo(HaveOccurred())
+		key, err := ecdh.X25519().NewPrivateKey(privateKey)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(base64.StdEncoding.EncodeToString(key.PublicKey().Bytes())).To(Equal(generated.PublicKey))
+
+		Expect(generated.ServerStanza).To(ContainSubstring("Jc = " + iface.Key("Jc").String() + "\n"))
+		Expect(generated.ServerStanza).To(ContainSubstring("H4 = " + iface.Key("H4").String() + "\n"))
+		Expect(generated.ServerStanza).To(ContainSubstring("[Peer]\n# home\nPublicKey = " + generated.PublicKey + "\n"))
+		Expect(generated.ServerStanza).To(ContainSubstring("PresharedKey = " + psk + "\n"))
+		Expect(generated.ServerStanza).To(ContainSubstring("AllowedIPs = 10.8.0.2/32\n"))
+		Expect(generated.ServerStanza).NotTo(ContainSubstring(iface.Key("PrivateKey").String()))
+	})
+
+	It("keeps the prefix of the address and omits AWG 2.0 parameters by default", func() {
+		opts := validOpts()
+		opts.Address = "10.8.0.2/24"
+
+		generated, err := AwgConf.GenerateConf(opts)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(generated.Conf).To(ContainSubstring("Address = 10.8.0.2/24\n"))
+		Expect(generated.Conf).NotTo(ContainSubstring("S3 ="))
+		Expect(generated.Conf).NotTo(ContainSubstring("MTU ="))
+		Expect(generated.ServerStanza).To(ContainSubstring("AllowedIPs = 10.8.0.2/32\n"))
+	})
+
+	It("adds S3 and S4 with awg2", func() {
+		opts := validOpts()
+		opts.AWG2 = true
+
+		generated, err := AwgConf.GenerateConf(opts)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(generated.Conf).To(ContainSubstring("S3 = "))
+		Expect(generated.Conf).To(ContainSubstring("S4 = "))
+	})
+
+	DescribeTable("rejects invalid options",
+		func(modify func(*AwgGenerateOptions), message string) {
+			opts := validOpts()
+			modify(&opts)
+			_, err := AwgConf.GenerateConf(opts)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(message))
+		},
+		Entry("endpoint without port", func(o *AwgGenerateOptions) { o.Endpoint = "vpn.example.com" }, "invalid endpoint"),
+		Entry("endpoint with port 0", func(o *AwgGenerateOptions) { o.Endpoint = "vpn.example.com:0" }, "port must be between"),
+		Entry("short public key", func(o *AwgGenerateOptions) { o.PeerPublicKey = "AAAA" }, "key must be 32 bytes"),
+		Entry("invalid address", func(o *AwgGenerateOptions) { o.Address = "10.8.0.300" }, "invalid tunnel address"),
+		Entry("invalid allowed IP", func(o *AwgGenerateOptions) { o.AllowedIPs = []string{"0.0.0.0"} }, "invalid allowed IP"),
+		Entry("no allowed IPs", func(o *AwgGenerateOptions) { o.AllowedIPs = nil }, "at least one allowed IP"),
+	)
+})
+
+var _ = Describe("Property: Generated ASC Parameters", func() {
+	It("should always respect the AWG protocol constraints", func() {
+		rapid.Check(GinkgoT(), func(t *rapid.T) {
+			awg2 := rapid.Bool().Draw(t, "awg2")
+
+			asc, err := generateASCParams(awg2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(validateASCParams(asc)).To(Succeed())
+
+			jc, jmin, jmax := atoi(asc.Jc), atoi(asc.Jmin), atoi(asc.Jmax)
+			Expect(jc).To(BeNumerically(">=", 1))
+			Expect(jc).To(BeNumerically("<=", 128))
+			Expect(jmin).To(BeNumerically("<", jmax))
+			Expect(jmax).To(BeNumerically("<=", awgMaxPacketSize))
+
+			s1, s2 := atoi(asc.S1), atoi(asc.S2)
+			Expect(s1 + 56).NotTo(Equal(s2))
+			Expect(awgInitMessageSize + s1).To(BeNumerically("<=", awgMaxPacketSize))
+			Expect(awgResponseMessageSize + s2).To(BeNumerically("<=", awgMaxPacketSize))
+			sizes := []int{awgInitMessageSize + s1, awgResponseMessageSize + s2}
+			if awg2 {
+				s3, s4 := atoi(asc.S3), atoi(asc.S4)
+				sizes = append(sizes, awgCookieMessageSize+s3, awgTransportHeaderSize+s4)
+			} else {
+				Expect(asc.S3).To(BeEmpty())
+				Expect(asc.S4).To(BeEmpty())
+			}
+			Expect(allDistinct(sizes)).To(BeTrue(), "padded message sizes must differ: %v", sizes)
+
+			headers := []int{atoi(asc.H1), atoi(asc.H2), atoi(asc.H3), atoi(asc.H4)}
+			Expect(allDistinct(headers)).To(BeTrue(), "headers must be unique: %v", headers)
+			for _, h := range headers {
+				Expect(h).To(BeNumerically(">=", awgMinHeader))
+				Expect(h).To(BeNumerically("<=", int64(awgMaxHeader)))
+			}
+		})
+	})
+
+	It("should always produce a public key matching the private key", func() {
+		rapid.Check(GinkgoT(), func(t *rapid.T) {
+			privateKey, publicKey, err := generateKeyPair()
+			Expect(err).NotTo(HaveOccurred())
+
+			raw, err := base64.StdEncoding.DecodeString(privateKey)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(raw).To(HaveLen(32))
+			Expect(raw[0] & 7).To(BeZero())
+			Expect(raw[31] & 0xc0).To(Equal(byte(0x40)))
+
+			key, err := ecdh.X25519().NewPrivateKey(raw)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(base64.StdEncoding.EncodeToString(key.PublicKey().Bytes())).To(Equal(publicKey))
+			Expect(publicKey).To(HaveLen(44))
+		})
+	})
+})
@@ -0,0 +1,102 @@
+package gokeenrestapi
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// AwgPeerHealth describes the runtime state of a WireGuard peer
+type AwgPeerHealth struct {
+	// InterfaceID is the WireGuard interface the peer belongs to
+	InterfaceID string `json:"interfaceId"`
+	// PublicKey is the public key of the peer
+	PublicKey string `json:"publicKey"`
+	// Remote is the current endpoint of the peer as host:port, empty when unknown
+	Remote string `json:"remote,omitempty"`
+	// LastHandshake is the number of seconds since the last handshake, negative when there was none
+	LastHandshake int64 `json:"lastHandshake"`
+	// RxBytes is the number of bytes received from the peer
+	RxBytes int64 `json:"rxBytes"`
+	// TxBytes is the number of bytes sent to the peer
+	TxBytes int64 `json:"txBytes"`
+	// Problem explains why the peer is stale, empty for a healthy peer
+	Problem string `json:"problem,omitempty"`
+}
+
+// Stale reports whether the peer is up but not working
+func (p AwgPeerHealth) Stale() bool {
+	return p.Problem != ""
+}
+
+// GetPeerHealth reads handshake age and traffic counters of the peers of enabled WireGuard interfaces.
+// A peer is stale when it never completed a handshake, its last handshake is older than staleAfter
+// or nothing was received from it. Without interfaceIds all WireGuard interfaces are checked.
+// Results are sorted by interface ID and public key
+func (*keeneticAwgconf) GetPeerHealth(staleAfter time.Duration, interfaceIds ...string) ([]AwgPeerHealth, error) {
+	interfaces, err := Interface.GetInterfacesViaRciShowInterfaces(false, InterfaceTypeWireguard)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := interfaceIds
+	if len(ids) == 0 {
+		for _, iface := range interfaces {
+			ids = append(ids, iface.Id)
+		}
+	}
+
+	var peers []AwgPeerHealth
+	for _, interfaceId := range ids {
+		var found bool
+		for _, iface := range interfaces {
+			if iface.Id != interfaceId {
+				continue
+			}
+			found = true
+			// Disabled interfaces are expected to have no handshakes
+			if iface.State != StateUp || iface.Wireguard == nil {
+				break
+			}
+			for _, peer := range iface.Wireguard.Peer {
+				health := AwgPeerHealth{
+					InterfaceID:   interfaceId,
+					PublicKey:     peer.PublicKey,
+					LastHandshake: peer.LastHandshake,
+					RxBytes:       peer.Rxbytes,
+					TxBytes:       peer.Txbytes,
+				}
+				if peer.Remote != "" {
+					health.Remote = fmt.Sprintf("%s:%d", peer.Remote, peer.RemotePort)
+				}
+				health.Problem = peerHealthProblem(peer.LastHandshake, peer.Rxbytes, staleAfter)
+				peers = append(peers, health)
+			}
+			break
+		}
+		if !found {
+			return nil, fmt.Errorf("keenetic router doesn't have WireGuard interface with id '%v'", interfaceId)
+		}
+	}
+
+	sort.Slice(peers, func(i, j int) bool {
+		if peers[i].InterfaceID != peers[j].InterfaceID {
+			return peers[i].InterfaceID < peers[j].InterfaceID
+		}
+		return peers[i].PublicKey < peers[j].PublicKey
+	})
+	return peers, nil
+}
+
+// peerHealthProblem explains why a peer with the given counters is stale, returns an empty string for a healthy peer
+func peerHealthProblem(lastHandshake, rxBytes int64, staleAfter time.Duration) string {
+	switch {
+	case lastHandshake < 0:
+		return "no handshake yet"
+	case time.Duration(lastHandshake)*time.Second > staleAfter:
+		return fmt.Sprintf("last handshake %v ago", time.Duration(lastHandshake)*time.Second)
+	case rxBytes == 0:
+		return "nothing received"
+	}
+	return ""
+}
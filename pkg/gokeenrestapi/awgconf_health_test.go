@@ -0,0 +1,98 @@
+package gokeenrestapi
+
+import (
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AWG GetPeerHealth", func() {
+	const (
+		peerA = "peerAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA="
+		peerB = "peerBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB="
+	)
+
+	var server *httptest.Server
+
+	BeforeEach(func() {
+		server = SetupMockRouterForTest(
+			WithInterfaces([]MockInterface{
+				{
+					ID: "Wireguard0", Type: InterfaceTypeWireguard,
+					Connected: StateConnected, Link: StateUp, State: StateUp,
+					LastHandshake: 30, RxBytes: 2048, TxBytes: 4096,
+				},
+				{
+					ID: "Wireguard1", Type: InterfaceTypeWireguard,
+					Connected: StateConnected, Link: StateUp, State: StateUp,
+					LastHandshake: 900, RxBytes: 2048, TxBytes: 4096,
+				},
+				{
+					ID: "Wireguard2", Type: InterfaceTypeWireguard,
+					Connected: StateDisconnected, Link: StateDown, State: StateDown,
+					LastHandshake: -1,
+				},
+				{
+					ID: "ISP", Type: InterfaceTypePPPoE,
+					Connected: StateConnected, Link: StateUp, State: StateUp,
+				},
+			}),
+			WithScInterfaces(map[string]MockScInterface{
+				"Wireguard0": {Wireguard: MockWireguard{Peer: []MockPeer{{Key: peerB, Endpoint: "198.51.100.1:51820"}, {Key: peerA}}}},
+				"Wireguard1": {Wireguard: MockWireguard{Peer: []MockPeer{{Key: peerA}}}},
+				"Wireguard2": {Wireguard: MockWireguard{Peer: []MockPeer{{Key: peerA}}}},
+			}),
+		)
+	})
+
+	AfterEach(func() {
+		if server != nil {
+			server.Close()
+		}
+	})
+
+	It("reports peers of enabled WireGuard interfaces sorted by interface and key", func() {
+		peers, err := AwgConf.GetPeerHealth(3 * time.Minute)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(peers).To(HaveLen(3))
+
+		Expect(peers[0].InterfaceID).To(Equal("Wireguard0"))
+		Expect(peers[0].PublicKey).To(Equal(peerA))
+		Expect(peers[0].Stale()).To(BeFalse())
+
+		Expect(peers[1].PublicKey).To(Equal(peerB))
+		Expect(peers[1].Remote).To(Equal("198.51.100.1:51820"))
+		Expect(peers[1].LastHandshake).To(Equal(int64(30)))
+		Expect(peers[1].RxBytes).To(Equal(int64(2048)))
+		Expect(peers[1].TxBytes).To(Equal(int64(4096)))
+
+		Expect(peers[2].InterfaceID).To(Equal("Wireguard1"))
+		Expect(peers[2].Problem).To(Equal("last handshake 15m0s ago"))
+	})
+
+	It("checks only the requested interfaces", func() {
+		peers, err := AwgConf.GetPeerHealth(3*time.Minute, "Wireguard1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(peers).To(HaveLen(1))
+		Expect(peers[0].Stale()).To(BeTrue())
+	})
+
+	It("fails for interfaces that are not WireGuard", func() {
+		_, err := AwgConf.GetPeerHealth(3*time.Minute, "ISP")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("doesn't have WireGuard interface with id 'ISP'"))
+	})
+
+	DescribeTable("peerHealthProblem",
+		func(lastHandshake, rxBytes int64, expected string) {
+			Expect(peerHealthProblem(lastHandshake, rxBytes, 3*time.Minute)).To(Equal(expected))
+		},
+		Entry("healthy", int64(10), int64(1), ""),
+		Entry("at the threshold", int64(180), int64(1), ""),
+		Entry("never handshaked", int64(-1), int64(0), "no handshake yet"),
+		Entry("old handshake", int64(181), int64(1), "last handshake 3m1s ago"),
+		Entry("nothing received", int64(10), int64(0), "nothing received"),
+	)
+})
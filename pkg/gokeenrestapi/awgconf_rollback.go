@@ -0,0 +1,114 @@
+package gokeenrestapi
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/noksa/gokeenapi/internal/gokeenlog"
+	"github.com/noksa/gokeenapi/internal/gokeenspinner"
+	"github.com/noksa/gokeenapi/pkg/gokeenrestapimodels"
+	"go.uber.org/multierr"
+)
+
+// UpdateInterfaceWithRollback updates a WireGuard interface like ConfigureOrUpdateInterface and then waits
+// up to verifyTimeout for the interface to reconnect with a fresh handshake. If it does not recover, the
+// configuration taken before the update is restored, so a wrong endpoint or key does not cut the tunnel off.
+// Interfaces that are down or have no peers before the update are not verified
+func (*keeneticAwgconf) UpdateInterfaceWithRollback(confPath, interfaceId string, verifyTimeout time.Duration) error {
+	commands, err := AwgConf.PlanUpdate(confPath, interfaceId)
+	if err != nil {
+		return err
+	}
+
+	if len(commands) == 0 {
+		gokeenlog.InfoSubStepf("Interface %v is already up to date", color.CyanString(interfaceId))
+		return nil
+	}
+
+	iface, err := Interface.GetInterfaceViaRciShowInterfaces(interfaceId)
+	if err != nil {
+		return err
+	}
+	snapshot, err := Interface.GetInterfaceViaRciShowScInterfaces(interfaceId)
+	if err != nil {
+		return err
+	}
+
+	since := time.Now()
+	err = applyInterfaceCommands(interfaceId, commands, "Updating %v interface configuration")
+	if err == nil {
+		// Without a working tunnel before the update there is nothing to lose
+		if !isInterfaceUp(iface) || len(snapshot.Wireguard.Peer) == 0 {
+			gokeenlog.InfoSubStepf("Interface %v was not connected before the update, skipping connection check", color.CyanString(interfaceId))
+			return nil
+		}
+		err = Interface.WaitUntilInterfaceReconnectedContext(context.Background(), interfaceId, since, verifyTimeout)
+		if err == nil {
+			return nil
+		}
+	}
+
+	gokeenlog.InfoSubStepf("⚠️  %s: %v, restoring the previous configuration of %v",
+		color.YellowString("WARNING"), err, color.CyanString(interfaceId))
+	if restoreErr := restoreInterfaceSnapshot(interfaceId, snapshot); restoreErr != nil {
+		err = multierr.Append(err, fmt.Errorf("restoring the previous configuration failed: %w", restoreErr))
+	}
+	return fmt.Errorf("update of %s was rolled back: %w", interfaceId, err)
+}
+
+// restoreInterfaceSnapshot brings ASC parameters and peers of the interface back to the snapshot
+func restoreInterfaceSnapshot(interfaceId string, snapshot gokeenrestapimodels.RciShowScInterface) error {
+	current, err := Interface.GetInterfaceViaRciShowScInterfaces(interfaceId)
+	if err != nil {
+		return err
+	}
+	commands := buildRestoreCommands(interfaceId, snapshot, current)
+	if len(commands) == 0 {
+		return nil
+	}
+	return applyInterfaceCommands(interfaceId, commands, "Restoring previous configuration of %v")
+}
+
+// buildRestoreCommands generates RCI commands that turn the current interface configuration back into the snapshot
+func buildRestoreCommands(interfaceId string, snapshot, current gokeenrestapimodels.RciShowScInterface) []gokeenrestapimodels.ParseRequest {
+	var commands []gokeenrestapimodels.ParseRequest
+
+	if snapshot.Wireguard.Asc != current.Wireguard.Asc {
+		asc := ascParamsFromModel(snapshot.Wireguard.Asc)
+		for _, value := range []*string{&asc.Jc, &asc.Jmin, &asc.Jmax, &asc.S1, &asc.S2, &asc.H1, &asc.H2, &asc.H3, &asc.H4} {
+			*value = defaultZero(*value)
+		}
+		// AWG 2.0 values set by the update have to be cleared explicitly
+		currentAsc := ascParamsFromModel(current.Wireguard.Asc)
+		if currentAsc.hasAWG2() {
+			asc.S3 = defaultZero(asc.S3)
+		}
+		commands = append(commands, gokeenrestapimodels.ParseRequest{Parse: buildASCCommand(interfaceId, asc)})
+	}
+
+	peers := make([]peerParams, 0, len(snapshot.Wireguard.Peer))
+	for _, peer := range snapshot.Wireguard.Peer {
+		params := peerParams{
+			PublicKey:           peer.Key,
+			Endpoint:            peer.Endpoint.Address,
+			PersistentKeepalive: peer.KeepaliveInterval.Interval,
+			PresharedKey:        peer.PresharedKey,
+		}
+		for _, allowIP := range peer.AllowIps {
+			params.AllowedIPs = append(params.AllowedIPs, allowIPToCIDR(allowIP))
+		}
+		peers = append(peers, params)
+	}
+	return append(commands, buildPeersCommands(interfaceId, peers, current.Wireguard.Peer)...)
+}
+
+// applyInterfaceCommands executes RCI commands for the interface and saves the configuration
+func applyInterfaceCommands(interfaceId string, commands []gokeenrestapimodels.ParseRequest, spinnerFormat string) error {
+	return gokeenspinner.WrapWithSpinner(fmt.Sprintf(spinnerFormat, color.CyanString(interfaceId)), func() error {
+		commands = Common.EnsureSaveConfigAtEnd(commands)
+		_, err := Common.ExecutePostParse(commands...)
+		return err
+	})
+}
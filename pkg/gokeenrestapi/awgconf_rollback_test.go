@@ -0,0 +1,133 @@
+package gokeenrestapi
+
+import (
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/noksa/gokeenapi/pkg/gokeenrestapimodels"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AWG UpdateInterfaceWithRollback", func() {
+	const (
+		peerA = "peerAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA="
+		peerB = "peerBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB="
+	)
+
+	const brokenConf = `[Interface]
+PrivateKey = abc
+Address = 10.9.9.1/24
+Jc = 7
+Jmin = 50
+Jmax = 1000
+S1 = 30
+S2 = 40
+H1 = 11
+H2 = 12
+H3 = 13
+H4 = 14
+
+[Peer]
+PublicKey = peerBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB=
+Endpoint = 203.0.113.9:51820
+AllowedIPs = 0.0.0.0/0
+PersistentKeepalive = 15`
+
+	var server *httptest.Server
+
+	setup := func(state string, lastHandshake int64) {
+		connected := StateConnected
+		if state == StateDown {
+			connected = StateDisconnected
+		}
+		server = SetupMockRouterForTest(
+			WithInterfaces([]MockInterface{{
+				ID: "Wireguard0", Type: InterfaceTypeWireguard,
+				Connected: connected, Link: state, State: state,
+				LastHandshake: lastHandshake,
+			}}),
+			WithScInterfaces(map[string]MockScInterface{
+				"Wireguard0": {
+					IP: MockIP{Address: "10.9.9.1/24"},
+					Wireguard: MockWireguard{
+						Asc: MockAsc{Jc: "3", Jmin: "50", Jmax: "1000", S1: "86", S2: "3", H1: "1", H2: "2", H3: "3", H4: "4"},
+						Peer: []MockPeer{{
+							Key:               peerA,
+							Endpoint:          "198.51.100.1:51820",
+							KeepaliveInterval: 25,
+							AllowedIPs:        []MockAllowedIP{{Address: "10.1.0.0", Mask: "255.255.0.0"}},
+						}},
+					},
+				},
+			}),
+		)
+	}
+
+	AfterEach(func() {
+		if server != nil {
+			server.Close()
+		}
+	})
+
+	createConf := func() string {
+		confPath := filepath.Join(GinkgoT().TempDir(), "broken.conf")
+		Expect(os.WriteFile(confPath, []byte(brokenConf), 0644)).To(Succeed())
+		return confPath
+	}
+
+	currentInterface := func() gokeenrestapimodels.RciShowScInterface {
+		details, err := Interface.GetInterfaceViaRciShowScInterfaces("Wireguard0")
+		ExpectWithOffset(1, err).NotTo(HaveOccurred())
+		return details
+	}
+
+	It("keeps the update when the interface reconnects with a fresh handshake", func() {
+		setup(StateUp, 0)
+
+		Expect(AwgConf.UpdateInterfaceWithRollback(createConf(), "Wireguard0", 5*time.Second)).To(Succeed())
+
+		details := currentInterface()
+		Expect(details.Wireguard.Asc.Jc).To(Equal("7"))
+		Expect(details.Wireguard.Peer).To(HaveLen(1))
+		Expect(details.Wireguard.Peer[0].Key).To(Equal(peerB))
+	})
+
+	It("restores the snapshot when no handshake happens within the timeout", func() {
+		setup(StateUp, 600)
+
+		err := AwgConf.UpdateInterfaceWithRollback(createConf(), "Wireguard0", time.Second)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("update of Wireguard0 was rolled back: no handshake on Wireguard0 within 1s"))
+
+		details := currentInterface()
+		Expect(details.Wireguard.Asc.Jc).To(Equal("3"))
+		Expect(details.Wireguard.Asc.H1).To(Equal("1"))
+		Expect(details.Wireguard.Peer).To(HaveLen(1))
+		Expect(details.Wireguard.Peer[0].Key).To(Equal(peerA))
+		Expect(details.Wireguard.Peer[0].Endpoint.Address).To(Equal("198.51.100.1:51820"))
+		Expect(details.Wireguard.Peer[0].KeepaliveInterval.Interval).To(Equal(25))
+		Expect(allowIPToCIDR(details.Wireguard.Peer[0].AllowIps[0])).To(Equal("10.1.0.0/16"))
+	})
+
+	It("does not verify an interface that was down before the update", func() {
+		setup(StateDown, 600)
+
+		Expect(AwgConf.UpdateInterfaceWithRollback(createConf(), "Wireguard0", time.Second)).To(Succeed())
+		Expect(currentInterface().Wireguard.Peer[0].Key).To(Equal(peerB))
+	})
+
+	It("clears AWG 2.0 values that were not in the snapshot", func() {
+		snapshot := gokeenrestapimodels.RciShowScInterface{}
+		snapshot.Wireguard.Asc = gokeenrestapimodels.Asc{Jc: "3", Jmin: "50", Jmax: "1000", S1: "86", S2: "3", H1: "1", H2: "2", H3: "3", H4: "4"}
+		current := snapshot
+		current.Wireguard.Asc.S3 = "20"
+		current.Wireguard.Asc.I1 = "<c>"
+
+		commands := buildRestoreCommands("Wireguard0", snapshot, current)
+		Expect(commands).To(HaveLen(1))
+		Expect(commands[0].Parse).To(Equal("interface Wireguard0 wireguard asc 3 50 1000 86 3 1 2 3 4 0 0 0 0 0 0 0"))
+	})
+})
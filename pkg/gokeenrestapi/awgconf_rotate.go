@@ -0,0 +1,141 @@
+package gokeenrestapi
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/noksa/gokeenapi/internal/gokeenlog"
+	"github.com/noksa/gokeenapi/pkg/gokeenrestapimodels"
+	"go.uber.org/multierr"
+)
+
+// AwgParams is a set of AWG obfuscation parameters rendered for the paired server
+type AwgParams struct {
+	// Stanza holds the parameter lines for the [Interface] section of the server
+	Stanza string
+	// Values maps parameter names (Jc, Jmin, ..., I5) to their values, unset parameters are omitted
+	Values map[string]string
+}
+
+// AwgRotateOptions controls RotateASC
+type AwgRotateOptions struct {
+	// AWG2 also rotates S3 and S4 (KeeneticOS 5.1+). Always enabled when the interface already uses them
+	AWG2 bool
+	// Timeout is how long to wait for a new handshake before rolling back
+	Timeout time.Duration
+	// DryRun only generates the new parameters without publishing or applying them
+	DryRun bool
+	// Publish delivers parameters to the paired server before they are applied on the router.
+	// It is called again with the previous parameters and rollback set when the handshake does not come back
+	Publish func(params AwgParams, rollback bool) error
+}
+
+// RotateASC replaces the AWG obfuscation parameters of a WireGuard interface with a fresh random set.
+// The new set is published to the paired server, applied on the router and kept only if a new handshake
+// happens within the timeout, otherwise the previous set is restored on both sides.
+// Signature packets I1-I5 are not rotated. Returns the new parameters
+func (*keeneticAwgconf) RotateASC(interfaceId string, opts AwgRotateOptions) (AwgParams, error) {
+	if err := Checks.CheckInterfaceId(interfaceId); err != nil {
+		return AwgParams{}, err
+	}
+	if err := Checks.CheckInterfaceExists(interfaceId); err != nil {
+		return AwgParams{}, err
+	}
+
+	details, err := Interface.GetInterfaceViaRciShowScInterfaces(interfaceId)
+	if err != nil {
+		return AwgParams{}, err
+	}
+	if len(details.Wireguard.Peer) == 0 {
+		return AwgParams{}, fmt.Errorf("interface %s has no WireGuard peers, the handshake cannot be verified", interfaceId)
+	}
+
+	previous := ascParamsFromModel(details.Wireguard.Asc)
+	awg2 := opts.AWG2 || defaultZero(previous.S3) != "0" || defaultZero(previous.S4) != "0"
+	next, err := generateASCParams(awg2)
+	if err != nil {
+		return AwgParams{}, err
+	}
+	next.I1, next.I2, next.I3, next.I4, next.I5 = previous.I1, previous.I2, previous.I3, previous.I4, previous.I5
+	if awg2 {
+		warnIfAWG2Unsupported(next)
+	}
+
+	nextParams := renderAwgParams(next)
+	if opts.DryRun {
+		return nextParams, nil
+	}
+
+	publish := func(asc ascParams, rollback bool) error {
+		if opts.Publish == nil {
+			return nil
+		}
+		return opts.Publish(renderAwgParams(asc), rollback)
+	}
+
+	if err := publish(next, false); err != nil {
+		return AwgParams{}, fmt.Errorf("failed to publish new AWG parameters, the router was not changed: %w", err)
+	}
+
+	since := time.Now()
+	err = applyASC(interfaceId, next, "Applying new AWG parameters to %v")
+	if err == nil {
+		err = Interface.WaitUntilInterfaceReconnectedContext(context.Background(), interfaceId, since, opts.Timeout)
+		if err == nil {
+			return nextParams, nil
+		}
+	}
+
+	gokeenlog.InfoSubStepf("⚠️  %s: %v, rolling back to the previous AWG parameters", color.YellowString("WARNING"), err)
+	if rollbackErr := applyASC(interfaceId, previous, "Restoring previous AWG parameters of %v"); rollbackErr != nil {
+		err = multierr.Append(err, fmt.Errorf("rollback on the router failed: %w", rollbackErr))
+	}
+	if rollbackErr := publish(previous, true); rollbackErr != nil {
+		err = multierr.Append(err, fmt.Errorf("rollback on the server failed: %w", rollbackErr))
+	}
+	return AwgParams{}, fmt.Errorf("AWG parameters of %s were not rotated: %w", interfaceId, err)
+}
+
+// applyASC pushes ASC parameters to the interface and saves the configuration
+func applyASC(interfaceId string, asc ascParams, spinnerFormat string) error {
+	return applyInterfaceCommands(interfaceId, []gokeenrestapimodels.ParseRequest{{Parse: buildASCCommand(interfaceId, asc)}}, spinnerFormat)
+}
+
+// ascParamsFromModel converts ASC parameters of an interface into ascParams
+func ascParamsFromModel(asc gokeenrestapimodels.Asc) ascParams {
+	return ascParams{
+		Jc:   asc.Jc,
+		Jmin: asc.Jmin,
+		Jmax: asc.Jmax,
+		S1:   asc.S1,
+		S2:   asc.S2,
+		H1:   asc.H1,
+		H2:   asc.H2,
+		H3:   asc.H3,
+		H4:   asc.H4,
+		S3:   asc.S3,
+		S4:   asc.S4,
+		I1:   asc.I1,
+		I2:   asc.I2,
+		I3:   asc.I3,
+		I4:   asc.I4,
+		I5:   asc.I5,
+	}
+}
+
+// renderAwgParams renders ASC parameters for the paired server
+func renderAwgParams(asc ascParams) AwgParams {
+	var b strings.Builder
+	writeASCParamsBlock(&b, asc)
+
+	values := make(map[string]string)
+	for line := range strings.SplitSeq(strings.TrimSpace(b.String()), "\n") {
+		if name, value, ok := strings.Cut(line, " = "); ok {
+			values[name] = value
+		}
+	}
+	return AwgParams{Stanza: b.String(), Values: values}
+}
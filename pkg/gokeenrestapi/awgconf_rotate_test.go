@@ -0,0 +1,135 @@
+package gokeenrestapi
+
+import (
+	"errors"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AWG RotateASC", func() {
+	const peerKey = "gN65BkIKy1eCE9pP1wdc8ROUunkiVXrBvGAKBEKdOQI="
+
+	var server *httptest.Server
+
+	previousAsc := MockAsc{Jc: "3", Jmin: "50", Jmax: "1000", S1: "86", S2: "3", H1: "1", H2: "2", H3: "3", H4: "4", I1: "<c>"}
+
+	setup := func(lastHandshake int64) {
+		server = SetupMockRouterForTest(
+			WithInterfaces([]MockInterface{{
+				ID: "Wireguard0", Type: InterfaceTypeWireguard,
+				Connected: StateConnected, Link: StateUp, State: StateUp,
+				LastHandshake: lastHandshake,
+			}}),
+			WithScInterfaces(map[string]MockScInterface{
+				"Wireguard0": {
+					IP: MockIP{Address: "10.0.0.1/24"},
+					Wireguard: MockWireguard{
+						Asc:  previousAsc,
+						Peer: []MockPeer{{Key: peerKey, Endpoint: "vpn.example.com:51820"}},
+					},
+				},
+			}),
+		)
+	}
+
+	AfterEach(func() {
+		if server != nil {
+			server.Close()
+		}
+	})
+
+	currentAsc := func() ascParams {
+		details, err := Interface.GetInterfaceViaRciShowScInterfaces("Wireguard0")
+		ExpectWithOffset(1, err).NotTo(HaveOccurred())
+		return ascParamsFromModel(details.Wireguard.Asc)
+	}
+
+	type publication struct {
+		params   AwgParams
+		rollback bool
+	}
+
+	It("applies a new valid set after publishing it and keeps signature packets", func() {
+		setup(0)
+		var published []publication
+
+		params, err := AwgConf.RotateASC("Wireguard0", AwgRotateOptions{
+			Timeout: 5 * time.Second,
+			Publish: func(params AwgParams, rollback bool) error {
+				published = append(published, publication{params, rollback})
+				return nil
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		asc := currentAsc()
+		Expect(validateASCParams(asc)).To(Succeed())
+		Expect(asc.H1).NotTo(Equal(previousAsc.H1))
+		Expect(asc.I1).To(Equal("<c>"))
+		Expect(params.Values).To(HaveKeyWithValue("Jc", asc.Jc))
+		Expect(params.Values).To(HaveKeyWithValue("H4", asc.H4))
+		Expect(params.Stanza).To(ContainSubstring("S1 = " + asc.S1 + "\n"))
+
+		Expect(published).To(HaveLen(1))
+		Expect(published[0].rollback).To(BeFalse())
+		Expect(published[0].params).To(Equal(params))
+	})
+
+	It("rolls back on the router and the server when the handshake does not come back", func() {
+		setup(600)
+		var published []publication
+
+		_, err := AwgConf.RotateASC("Wireguard0", AwgRotateOptions{
+			Timeout: time.Second,
+			Publish: func(params AwgParams, rollback bool) error {
+				published = append(published, publication{params, rollback})
+				return nil
+			},
+		})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("were not rotated: no handshake on Wireguard0 within 1s"))
+
+		asc := currentAsc()
+		Expect([]string{asc.Jc, asc.Jmin, asc.Jmax, asc.S1, asc.S2, asc.H1, asc.H2, asc.H3, asc.H4, asc.I1}).To(Equal([]string{
+			previousAsc.Jc, previousAsc.Jmin, previousAsc.Jmax, previousAsc.S1, previousAsc.S2,
+			previousAsc.H1, previousAsc.H2, previousAsc.H3, previousAsc.H4, previousAsc.I1,
+		}))
+		Expect(published).To(HaveLen(2))
+		Expect(published[1].rollback).To(BeTrue())
+		Expect(published[1].params.Values).To(HaveKeyWithValue("Jmax", "1000"))
+		Expect(published[1].params.Values).To(HaveKeyWithValue("I1", "<c>"))
+	})
+
+	It("does not touch the router when publishing fails", func() {
+		setup(0)
+
+		_, err := AwgConf.RotateASC("Wireguard0", AwgRotateOptions{
+			Timeout: time.Second,
+			Publish: func(AwgParams, bool) error { return errors.New("server unreachable") },
+		})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("the router was not changed: server unreachable"))
+		Expect(currentAsc().Jmax).To(Equal("1000"))
+	})
+
+	It("only generates parameters in dry-run mode", func() {
+		setup(0)
+
+		params, err := AwgConf.RotateASC("Wireguard0", AwgRotateOptions{AWG2: true, Timeout: time.Second, DryRun: true})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(params.Values).To(HaveKey("S3"))
+		Expect(params.Values).To(HaveKey("S4"))
+		Expect(currentAsc().Jmax).To(Equal("1000"))
+	})
+
+	It("refuses interfaces without peers", func() {
+		server = SetupMockRouterForTest()
+
+		_, err := AwgConf.RotateASC("Wireguard0", AwgRotateOptions{Timeout: time.Second})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("has no WireGuard peers"))
+	})
+})
@@ -0,0 +1,341 @@
+package gokeenrestapi
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+)
+
+const (
+	// AwgShareLinkPrefix is the scheme of AmneziaVPN share links
+	AwgShareLinkPrefix = "vpn://"
+	// amneziaDefaultDNS1 and amneziaDefaultDNS2 replace DNS placeholders when the link carries no DNS servers
+	amneziaDefaultDNS1 = "1.1.1.1"
+	amneziaDefaultDNS2 = "1.0.0.1"
+	// shareLinkMaxSize limits the decompressed share link payload
+	shareLinkMaxSize = 1 << 20
+)
+
+// flexString accepts both JSON strings and numbers, AmneziaVPN versions differ in how they encode ports and ASC values
+type flexString string
+
+func (f *flexString) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*f = flexString(s)
+		return nil
+	}
+	var n json.Number
+	if err := json.Unmarshal(data, &n); err != nil {
+		return err
+	}
+	*f = flexString(n.String())
+	return nil
+}
+
+// amneziaShareConfig is the JSON document inside an AmneziaVPN vpn:// link
+type amneziaShareConfig struct {
+	Containers       []amneziaContainer `json:"containers"`
+	DefaultContainer string             `json:"defaultContainer"`
+	Description      string             `json:"description"`
+	DNS1             string             `json:"dns1"`
+	DNS2             string             `json:"dns2"`
+	HostName         string             `json:"hostName"`
+}
+
+// amneziaContainer is a single protocol container of an AmneziaVPN share config
+type amneziaContainer struct {
+	Container string           `json:"container"`
+	Awg       *amneziaProtocol `json:"awg"`
+	Wireguard *amneziaProtocol `json:"wireguard"`
+}
+
+// amneziaProtocol holds AWG parameters and the serialized client config of a container
+type amneziaProtocol struct {
+	amneziaASC
+	Port       flexString `json:"port"`
+	LastConfig string     `json:"last_config"`
+}
+
+// amneziaASC holds AmneziaWG obfuscation parameters as they appear in share links
+type amneziaASC struct {
+	Jc   flexString `json:"Jc"`
+	Jmin flexString `json:"Jmin"`
+	Jmax flexString `json:"Jmax"`
+	S1   flexString `json:"S1"`
+	S2   flexString `json:"S2"`
+	H1   flexString `json:"H1"`
+	H2   flexString `json:"H2"`
+	H3   flexString `json:"H3"`
+	H4   flexString `json:"H4"`
+	S3   flexString `json:"S3"`
+	S4   flexString `json:"S4"`
+	I1   flexString `json:"I1"`
+	I2   flexString `json:"I2"`
+	I3   flexString `json:"I3"`
+	I4   flexString `json:"I4"`
+	I5   flexString `json:"I5"`
+}
+
+// amneziaLastConfig is the client config stored as a JSON string in "last_config"
+type amneziaLastConfig struct {
+	amneziaASC
+	Config              string     `json:"config"`
+	ClientPrivKey       string     `json:"client_priv_key"`
+	ClientIP            string     `json:"client_ip"`
+	ServerPubKey        string     `json:"server_pub_key"`
+	PskKey              string     `json:"psk_key"`
+	HostName            string     `json:"hostName"`
+	Port                flexString `json:"port"`
+	Mtu                 flexString `json:"mtu"`
+	AllowedIPs          []string   `json:"allowed_ips"`
+	PersistentKeepAlive flexString `json:"persistent_keep_alive"`
+}
+
+// shareLinkConf is a decoded share link: the .conf content for the router and its parsed parameters
+type shareLinkConf struct {
+	Conf        string
+	Description string
+	ASC         ascParams
+	Peers       []peerParams
+}
+
+// ConfFromShareLink decodes an AmneziaVPN vpn:// share link (or the bare QR payload without the scheme)
+// into WireGuard .conf content that can be used wherever a .conf file is accepted.
+// Returns the conf content and the description of the connection from the link,
+// which is the server host name when the link has no description
+func (*keeneticAwgconf) ConfFromShareLink(link string) (string, string, error) {
+	decoded, err := parseShareLink(link)
+	if err != nil {
+		return "", "", err
+	}
+	return decoded.Conf, decoded.Description, nil
+}
+
+// parseShareLink decodes a share link and parses the resulting .conf into ASC and peer parameters
+func parseShareLink(link string) (shareLinkConf, error) {
+	payload, err := decodeShareLinkPayload(link)
+	if err != nil {
+		return shareLinkConf{}, err
+	}
+
+	var share amneziaShareConfig
+	if err := json.Unmarshal(payload, &share); err != nil {
+		return shareLinkConf{}, fmt.Errorf("invalid share link: %w", err)
+	}
+
+	protocol := share.wireguardProtocol()
+	if protocol == nil {
+		return shareLinkConf{}, errors.New("share link does not contain an AmneziaWG or WireGuard container")
+	}
+
+	var last amneziaLastConfig
+	if protocol.LastConfig != "" {
+		if err := json.Unmarshal([]byte(protocol.LastConfig), &last); err != nil {
+			return shareLinkConf{}, fmt.Errorf("invalid share link: failed to parse last_config: %w", err)
+		}
+	}
+
+	var conf string
+	if last.Config != "" {
+		conf = share.substituteDNS(last.Config)
+	} else {
+		conf, err = share.renderConf(*protocol, last)
+		if err != nil {
+			return shareLinkConf{}, err
+		}
+	}
+
+	asc, peers, err := parseConfSource([]byte(conf))
+	if err != nil {
+		return shareLinkConf{}, fmt.Errorf("invalid share link: %w", err)
+	}
+	if len(peers) == 0 {
+		return shareLinkConf{}, errors.New("invalid share link: no peer with a public key")
+	}
+
+	// Links exported without a description still name the server they connect to
+	description := share.Description
+	if description == "" {
+		description = share.HostName
+	}
+	return shareLinkConf{Conf: conf, Description: description, ASC: asc, Peers: peers}, nil
+}
+
+// decodeShareLinkPayload strips the vpn:// scheme, decodes base64 and decompresses the payload.
+// AmneziaVPN compresses with Qt qCompress (4-byte big-endian length followed by a zlib stream);
+// plain zlib and uncompressed JSON payloads are accepted as well
+func decodeShareLinkPayload(link string) ([]byte, error) {
+	payload := strings.TrimSpace(link)
+	payload = strings.TrimPrefix(payload, AwgShareLinkPrefix)
+	payload = strings.TrimRight(payload, "=")
+	if payload == "" {
+		return nil, errors.New("share link is empty")
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		raw, err = base64.RawStdEncoding.DecodeString(payload)
+		if err != nil {
+			return nil, fmt.Errorf("invalid share link: not valid base64: %w", err)
+		}
+	}
+
+	if len(raw) > 4 {
+		expected := binary.BigEndian.Uint32(raw[:4])
+		if data, err := inflate(raw[4:]); err == nil && (expected == 0 || int(expected) == len(data)) {
+			return data, nil
+		}
+	}
+	if data, err := inflate(raw); err == nil {
+		return data, nil
+	}
+	if json.Valid(raw) {
+		return raw, nil
+	}
+	return nil, errors.New("invalid share link: payload is neither compressed nor JSON")
+}
+
+// inflate decompresses a zlib stream up to shareLinkMaxSize bytes
+func inflate(data []byte) ([]byte, error) {
+	reader, err := zlib.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = reader.Close() }()
+	out, err := io.ReadAll(io.LimitReader(reader, shareLinkMaxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(out) > shareLinkMaxSize {
+		return nil, errors.New("share link payload is too large")
+	}
+	return out, nil
+}
+
+// wireguardProtocol returns the AWG or WireGuard protocol of the default container, or of the first container that has one
+func (s amneziaShareConfig) wireguardProtocol() *amneziaProtocol {
+	pick := func(c amneziaContainer) *amneziaProtocol {
+		if c.Awg != nil {
+			return c.Awg
+		}
+		return c.Wireguard
+	}
+	for _, c := range s.Containers {
+		if c.Container == s.DefaultContainer {
+			if protocol := pick(c); protocol != nil {
+				return protocol
+			}
+		}
+	}
+	for _, c := range s.Containers {
+		if protocol := pick(c); protocol != nil {
+			return protocol
+		}
+	}
+	return nil
+}
+
+// substituteDNS replaces AmneziaVPN DNS placeholders in a config template
+func (s amneziaShareConfig) substituteDNS(conf string) string {
+	dns1, dns2 := s.DNS1, s.DNS2
+	if dns1 == "" {
+		dns1 = amneziaDefaultDNS1
+	}
+	if dns2 == "" {
+		dns2 = amneziaDefaultDNS2
+	}
+	return strings.NewReplacer("$PRIMARY_DNS", dns1, "$SECONDARY_DNS", dns2).Replace(conf)
+}
+
+// renderConf builds a .conf from the individual last_config fields, used when the link has no config template
+func (s amneziaShareConfig) renderConf(protocol amneziaProtocol, last amneziaLastConfig) (string, error) {
+	if last.ClientPrivKey == "" || last.ServerPubKey == "" || last.ClientIP == "" {
+		return "", errors.New("invalid share link: client_priv_key, client_ip and server_pub_key are required")
+	}
+
+	host := last.HostName
+	if host == "" {
+		host = s.HostName
+	}
+	port := string(last.Port)
+	if port == "" {
+		port = string(protocol.Port)
+	}
+	if host == "" || port == "" {
+		return "", errors.New("invalid share link: server host and port are required")
+	}
+
+	address := last.ClientIP
+	if !strings.Contains(address, "/") {
+		address += "/32"
+	}
+
+	asc := last.amneziaASC.toParams()
+	if !asc.hasAnyASC() {
+		asc = protocol.amneziaASC.toParams()
+	}
+
+	allowedIPs := last.AllowedIPs
+	if len(allowedIPs) == 0 {
+		allowedIPs = []string{"0.0.0.0/0", "::/0"}
+	}
+	peer := peerParams{
+		PublicKey:    last.ServerPubKey,
+		PresharedKey: last.PskKey,
+		Endpoint:     net.JoinHostPort(host, port),
+		AllowedIPs:   allowedIPs,
+	}
+	if keepalive := string(last.PersistentKeepAlive); keepalive != "" {
+		interval, err := strconv.Atoi(keepalive)
+		if err != nil {
+			return "", fmt.Errorf("invalid share link: invalid persistent_keep_alive '%s'", keepalive)
+		}
+		peer.PersistentKeepalive = interval
+	}
+
+	var b strings.Builder
+	b.WriteString("[Interface]\n")
+	b.WriteString(fmt.Sprintf("PrivateKey = %s\n", last.ClientPrivKey))
+	b.WriteString(fmt.Sprintf("Address = %s\n", address))
+	b.WriteString(fmt.Sprintf("DNS = %s\n", s.substituteDNS("$PRIMARY_DNS, $SECONDARY_DNS")))
+	if mtu := string(last.Mtu); mtu != "" && mtu != "0" {
+		b.WriteString(fmt.Sprintf("MTU = %s\n", mtu))
+	}
+	writeASCParamsBlock(&b, asc)
+	writePeerParamsBlock(&b, peer)
+	return b.String(), nil
+}
+
+// toParams converts share link ASC values into ascParams
+func (a amneziaASC) toParams() ascParams {
+	return ascParams{
+		Jc:   string(a.Jc),
+		Jmin: string(a.Jmin),
+		Jmax: string(a.Jmax),
+		S1:   string(a.S1),
+		S2:   string(a.S2),
+		H1:   string(a.H1),
+		H2:   string(a.H2),
+		H3:   string(a.H3),
+		H4:   string(a.H4),
+		S3:   string(a.S3),
+		S4:   string(a.S4),
+		I1:   string(a.I1),
+		I2:   string(a.I2),
+		I3:   string(a.I3),
+		I4:   string(a.I4),
+		I5:   string(a.I5),
+	}
+}
@@ -0,0 +1,71 @@
+package gokeenrestapi
+
+import (
+	"fmt"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"pgregory.net/rapid"
+)
+
+// genWireguardKey generates a syntactically valid base64 WireGuard key
+func genWireguardKey() *rapid.Generator[string] {
+	return rapid.Custom(func(t *rapid.T) string {
+		body := rapid.StringOfN(rapid.RuneFrom([]rune("ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789+/")), 42, 42, -1).Draw(t, "key")
+		return body + "A="
+	})
+}
+
+// genSharePeer generates peer parameters as they appear in share link configs
+func genSharePeer() *rapid.Generator[peerParams] {
+	return rapid.Custom(func(t *rapid.T) peerParams {
+		return peerParams{
+			PublicKey:           genWireguardKey().Draw(t, "publicKey"),
+			Endpoint:            fmt.Sprintf("%s:%d", genIPv4Address().Draw(t, "host"), rapid.IntRange(1, 65535).Draw(t, "port")),
+			AllowedIPs:          rapid.SliceOfN(genIPv4Network(), 1, 4).Draw(t, "allowedIPs"),
+			PersistentKeepalive: rapid.IntRange(0, 120).Draw(t, "keepalive"),
+		}
+	})
+}
+
+var _ = Describe("Property: Share Link Parsing", func() {
+	It("should decode the same ASC and peer parameters as the embedded conf", func() {
+		rapid.Check(GinkgoT(), func(t *rapid.T) {
+			params := genASCParameters().Draw(t, "asc")
+			peers := rapid.SliceOfNDistinct(genSharePeer(), 1, 3, func(p peerParams) string { return p.PublicKey }).Draw(t, "peers")
+
+			var b strings.Builder
+			b.WriteString("[Interface]\nPrivateKey = cOFA+3p5IjkzIjkzIjkzIjkzIjkzIjkzIjkzIjkzIjk=\nAddress = 10.8.1.2/32\nDNS = $PRIMARY_DNS\n")
+			writeASCParamsBlock(&b, ascParams{
+				Jc: params.Jc, Jmin: params.Jmin, Jmax: params.Jmax, S1: params.S1, S2: params.S2,
+				H1: params.H1, H2: params.H2, H3: params.H3, H4: params.H4,
+			})
+			for _, peer := range peers {
+				writePeerParamsBlock(&b, peer)
+			}
+			conf := b.String()
+
+			expectedASC, expectedPeers, err := parseConfSource([]byte(conf))
+			Expect(err).NotTo(HaveOccurred())
+
+			decoded, err := parseShareLink(encodeShareLink(awgShareConfig(map[string]any{}, map[string]any{"config": conf})))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(decoded.ASC).To(Equal(expectedASC))
+			Expect(decoded.Peers).To(Equal(expectedPeers))
+			Expect(decoded.Peers).To(HaveLen(len(peers)))
+			Expect(decoded.Conf).NotTo(ContainSubstring("$PRIMARY_DNS"))
+		})
+	})
+
+	It("should return an error instead of panicking on arbitrary input", func() {
+		rapid.Check(GinkgoT(), func(t *rapid.T) {
+			link := rapid.OneOf(
+				rapid.String(),
+				rapid.StringMatching(`vpn://[A-Za-z0-9_-]{0,64}`),
+			).Draw(t, "link")
+
+			Expect(func() { _, _ = parseShareLink(link) }).NotTo(Panic())
+		})
+	})
+})
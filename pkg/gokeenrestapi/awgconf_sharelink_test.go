@@ -0,0 +1,167 @@
+package gokeenrestapi
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// compressShareLinkPayload compresses data like Qt qCompress: 4-byte big-endian length followed by a zlib stream
+func compressShareLinkPayload(data []byte) []byte {
+	var buf bytes.Buffer
+	header := make([]byte, 4)
+	binary.BigEndian.PutUint32(header, uint32(len(data)))
+	buf.Write(header)
+	writer := zlib.NewWriter(&buf)
+	_, _ = writer.Write(data)
+	_ = writer.Close()
+	return buf.Bytes()
+}
+
+// encodeShareLink builds an AmneziaVPN vpn:// link from a share config document
+func encodeShareLink(share map[string]any) string {
+	payload, err := json.Marshal(share)
+	ExpectWithOffset(1, err).NotTo(HaveOccurred())
+	return AwgShareLinkPrefix + base64.RawURLEncoding.EncodeToString(compressShareLinkPayload(payload))
+}
+
+// awgShareConfig builds a share config document with a single amnezia-awg container
+func awgShareConfig(protocol map[string]any, lastConfig map[string]any) map[string]any {
+	if lastConfig != nil {
+		serialized, err := json.Marshal(lastConfig)
+		ExpectWithOffset(1, err).NotTo(HaveOccurred())
+		protocol["last_config"] = string(serialized)
+	}
+	return map[string]any{
+		"containers":       []any{map[string]any{"container": "amnezia-awg", "awg": protocol}},
+		"defaultContainer": "amnezia-awg",
+		"description":      "My server",
+		"dns1":             "9.9.9.9",
+		"dns2":             "149.112.112.112",
+		"hostName":         "203.0.113.10",
+	}
+}
+
+var _ = Describe("AWG share links", func() {
+	const shareConfTemplate = `[Interface]
+Address = 10.8.1.2/32
+DNS = $PRIMARY_DNS, $SECONDARY_DNS
+PrivateKey = cOFA+3p5IjkzIjkzIjkzIjkzIjkzIjkzIjkzIjkzIjk=
+Jc = 4
+Jmin = 10
+Jmax = 50
+S1 = 86
+S2 = 57
+H1 = 1012
+H2 = 2023
+H3 = 3034
+H4 = 4045
+
+[Peer]
+PublicKey = gN65BkIKy1eCE9pP1wdc8ROUunkiVXrBvGAKBEKdOQI=
+PresharedKey = cHNrcHNrcHNrcHNrcHNrcHNrcHNrcHNrcHNrcHNrcHM=
+AllowedIPs = 0.0.0.0/0, ::/0
+Endpoint = 203.0.113.10:40125
+PersistentKeepalive = 25
+`
+
+	It("should decode a link with a config template", func() {
+		link := encodeShareLink(awgShareConfig(map[string]any{"port": "40125"}, map[string]any{"config": shareConfTemplate}))
+
+		decoded, err := parseShareLink(link)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(decoded.Description).To(Equal("My server"))
+		Expect(decoded.Conf).To(ContainSubstring("DNS = 9.9.9.9, 149.112.112.112\n"))
+		Expect(decoded.ASC.Jc).To(Equal("4"))
+		Expect(decoded.ASC.H4).To(Equal("4045"))
+		Expect(decoded.Peers).To(HaveLen(1))
+		Expect(decoded.Peers[0].PublicKey).To(Equal("gN65BkIKy1eCE9pP1wdc8ROUunkiVXrBvGAKBEKdOQI="))
+		Expect(decoded.Peers[0].Endpoint).To(Equal("203.0.113.10:40125"))
+		Expect(decoded.Peers[0].AllowedIPs).To(Equal([]string{"0.0.0.0/0", "::/0"}))
+		Expect(decoded.Peers[0].PersistentKeepalive).To(Equal(25))
+	})
+
+	It("should build the conf from individual fields when there is no template", func() {
+		protocol := map[string]any{
+			"Jc": "5", "Jmin": "20", "Jmax": "80", "S1": "30", "S2": "40",
+			"H1": "11", "H2": "22", "H3": "33", "H4": "44",
+			"port": 51820,
+		}
+		link := encodeShareLink(awgShareConfig(protocol, map[string]any{
+			"client_priv_key":       "cOFA+3p5IjkzIjkzIjkzIjkzIjkzIjkzIjkzIjkzIjk=",
+			"client_ip":             "10.8.1.3",
+			"server_pub_key":        "gN65BkIKy1eCE9pP1wdc8ROUunkiVXrBvGAKBEKdOQI=",
+			"persistent_keep_alive": "15",
+			"mtu":                   "1376",
+		}))
+
+		decoded, err := parseShareLink(link)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(decoded.Conf).To(ContainSubstring("Address = 10.8.1.3/32\n"))
+		Expect(decoded.Conf).To(ContainSubstring("MTU = 1376\n"))
+		Expect(decoded.ASC.Jc).To(Equal("5"))
+		Expect(decoded.ASC.H4).To(Equal("44"))
+		Expect(decoded.Peers[0].Endpoint).To(Equal("203.0.113.10:51820"))
+		Expect(decoded.Peers[0].AllowedIPs).To(Equal([]string{"0.0.0.0/0", "::/0"}))
+		Expect(decoded.Peers[0].PersistentKeepalive).To(Equal(15))
+	})
+
+	It("should accept a bare QR payload, plain zlib and uncompressed JSON", func() {
+		share := awgShareConfig(map[string]any{}, map[string]any{"config": shareConfTemplate})
+		payload, err := json.Marshal(share)
+		Expect(err).NotTo(HaveOccurred())
+
+		var zlibOnly bytes.Buffer
+		writer := zlib.NewWriter(&zlibOnly)
+		_, _ = writer.Write(payload)
+		_ = writer.Close()
+
+		for _, link := range []string{
+			base64.RawURLEncoding.EncodeToString(compressShareLinkPayload(payload)),
+			AwgShareLinkPrefix + base64.URLEncoding.EncodeToString(zlibOnly.Bytes()),
+			AwgShareLinkPrefix + base64.StdEncoding.EncodeToString(payload),
+		} {
+			decoded, err := parseShareLink(link)
+			Expect(err).NotTo(HaveOccurred(), link)
+			Expect(decoded.Peers).To(HaveLen(1))
+		}
+	})
+
+	DescribeTable("should reject invalid links",
+		func(link, message string) {
+			_, err := parseShareLink(link)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(message))
+		},
+		Entry("empty link", "vpn://", "share link is empty"),
+		Entry("not base64", "vpn://!!!", "not valid base64"),
+		Entry("not compressed nor JSON", "vpn://"+base64.RawURLEncoding.EncodeToString([]byte("hello world")), "neither compressed nor JSON"),
+		Entry("no wireguard container", encodeShareLink(map[string]any{
+			"containers": []any{map[string]any{"container": "amnezia-openvpn", "openvpn": map[string]any{}}},
+		}), "does not contain an AmneziaWG or WireGuard container"),
+		Entry("missing client fields", encodeShareLink(awgShareConfig(map[string]any{"port": "1"}, map[string]any{"client_ip": "10.0.0.2"})), "client_priv_key, client_ip and server_pub_key are required"),
+	)
+
+	It("should expose the decoded conf through AwgConf", func() {
+		link := encodeShareLink(awgShareConfig(map[string]any{}, map[string]any{"config": shareConfTemplate}))
+
+		conf, description, err := AwgConf.ConfFromShareLink(link)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(description).To(Equal("My server"))
+		Expect(conf).To(HavePrefix("[Interface]\nAddress = 10.8.1.2/32\n"))
+	})
+
+	It("should fall back to the host name for links without a description", func() {
+		share := awgShareConfig(map[string]any{}, map[string]any{"config": shareConfTemplate})
+		delete(share, "description")
+
+		_, description, err := AwgConf.ConfFromShareLink(encodeShareLink(share))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(description).To(Equal("203.0.113.10"))
+	})
+})
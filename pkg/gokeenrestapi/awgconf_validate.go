@@ -0,0 +1,218 @@
+package gokeenrestapi
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.uber.org/multierr"
+)
+
+// awgMaxJunkCount is the largest number of junk packets (Jc) sent before the handshake
+const awgMaxJunkCount = 128
+
+// ValidateConf checks the AWG parameters of a .conf file without contacting the router.
+// Every violation is reported, use multierr.Errors to list them one by one
+func (*keeneticAwgconf) ValidateConf(confPath string) error {
+	asc, _, err := parseConfFile(confPath)
+	if err != nil {
+		return err
+	}
+	return validateASCParams(asc)
+}
+
+// parseValidConfSource parses a .conf like parseConfSource and rejects invalid AWG parameters,
+// so broken obfuscation settings never reach the router
+func parseValidConfSource(source any) (ascParams, []peerParams, error) {
+	asc, peers, err := parseConfSource(source)
+	if err != nil {
+		return asc, nil, err
+	}
+	if err := validateASCParams(asc); err != nil {
+		return asc, nil, fmt.Errorf("invalid AWG parameters: %w", err)
+	}
+	return asc, peers, nil
+}
+
+// awgHeader is a parsed H1-H4 value, a single number or an AWG 2.0 range "min-max"
+type awgHeader struct {
+	name     string
+	value    string
+	from, to int64
+}
+
+// validateASCParams checks AWG obfuscation parameters against the protocol constraints:
+//   - 0 <= Jc <= 128 and Jmin <= Jmax <= 1280
+//   - padded handshake messages fit into 1280 bytes and all padded message sizes differ,
+//     e.g. S1+56 != S2, otherwise the messages can be told apart by length
+//   - non-zero H1-H4 values (or AWG 2.0 ranges) are unique and do not overlap
+//   - I1-I5 are sequences of well-formed <b 0x..>, <c>, <t>, <r N>, <rc N> and <rd N> tags
+//     or plain numbers written by older firmware and configs
+//
+// Empty values are treated as 0, the router default. All violations are returned
+func validateASCParams(asc ascParams) error {
+	var mErr error
+	fail := func(format string, args ...any) {
+		mErr = multierr.Append(mErr, fmt.Errorf(format, args...))
+	}
+	number := func(name, value string, max int) int {
+		if value == "" {
+			return 0
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 || n > max {
+			fail("%s = %s must be a number between 0 and %d", name, value, max)
+			return 0
+		}
+		return n
+	}
+
+	number("Jc", asc.Jc, awgMaxJunkCount)
+	jmin := number("Jmin", asc.Jmin, awgMaxPacketSize)
+	jmax := number("Jmax", asc.Jmax, awgMaxPacketSize)
+	if jmin > jmax {
+		fail("Jmin = %d must not be greater than Jmax = %d", jmin, jmax)
+	}
+
+	type padding struct {
+		name string
+		size int
+		set  bool
+	}
+	paddings := []padding{
+		{"S1", awgInitMessageSize + number("S1", asc.S1, awgMaxPacketSize-awgInitMessageSize), true},
+		{"S2", awgResponseMessageSize + number("S2", asc.S2, awgMaxPacketSize-awgResponseMessageSize), true},
+		{"S3", awgCookieMessageSize + number("S3", asc.S3, awgMaxPacketSize-awgCookieMessageSize), asc.S3 != ""},
+		{"S4", awgTransportHeaderSize + number("S4", asc.S4, awgMaxPacketSize-awgTransportHeaderSize), asc.S4 != ""},
+	}
+	for i := range paddings {
+		for j := i + 1; j < len(paddings); j++ {
+			a, b := paddings[i], paddings[j]
+			if !a.set || !b.set || a.size != b.size {
+				continue
+			}
+			hint := ""
+			if a.name == "S1" && b.name == "S2" {
+				hint = fmt.Sprintf(" (S2 must not equal S1 + %d)", awgInitMessageSize-awgResponseMessageSize)
+			}
+			fail("%s and %s give the same padded message size %d%s", a.name, b.name, a.size, hint)
+		}
+	}
+
+	var headers []awgHeader
+	for _, h := range []struct{ name, value string }{{"H1", asc.H1}, {"H2", asc.H2}, {"H3", asc.H3}, {"H4", asc.H4}} {
+		header, err := parseAWGHeader(h.name, h.value)
+		if err != nil {
+			mErr = multierr.Append(mErr, err)
+			continue
+		}
+		// 0 keeps the standard WireGuard message type
+		if header.to == 0 {
+			continue
+		}
+		for _, other := range headers {
+			if header.from <= other.to && other.from <= header.to {
+				fail("%s = %s overlaps %s = %s, H1-H4 must be unique", header.name, header.value, other.name, other.value)
+			}
+		}
+		headers = append(headers, header)
+	}
+
+	for _, p := range []struct{ name, value string }{{"I1", asc.I1}, {"I2", asc.I2}, {"I3", asc.I3}, {"I4", asc.I4}, {"I5", asc.I5}} {
+		if err := validateIPacket(p.name, p.value); err != nil {
+			mErr = multierr.Append(mErr, err)
+		}
+	}
+
+	return mErr
+}
+
+// parseAWGHeader parses an H1-H4 value, either a number or an AWG 2.0 range "min-max"
+func parseAWGHeader(name, value string) (awgHeader, error) {
+	header := awgHeader{name: name, value: value}
+	if value == "" {
+		return header, nil
+	}
+	invalid := fmt.Errorf("%s = %s must be a number or a range min-max between 0 and %d", name, value, uint32(awgMaxHeader))
+
+	low, high, isRange := strings.Cut(value, "-")
+	from, err := strconv.ParseInt(strings.TrimSpace(low), 10, 64)
+	if err != nil || from < 0 || from > awgMaxHeader {
+		return header, invalid
+	}
+	to := from
+	if isRange {
+		to, err = strconv.ParseInt(strings.TrimSpace(high), 10, 64)
+		if err != nil || to < 0 || to > awgMaxHeader {
+			return header, invalid
+		}
+		if from > to {
+			return header, fmt.Errorf("%s = %s: range start must not be greater than its end", name, value)
+		}
+	}
+	header.from, header.to = from, to
+	return header, nil
+}
+
+// validateIPacket checks the tag syntax of an I1-I5 signature packet, e.g. "<b 0x1a2b><c><t><r 16>"
+// Legacy numeric values such as "70" are accepted as is, 0 is how the router clears the packet
+func validateIPacket(name, value string) error {
+	rest := strings.TrimSpace(value)
+	if _, err := strconv.ParseUint(rest, 10, 64); err == nil {
+		return nil
+	}
+	position := 0
+	for rest != "" {
+		if rest[0] != '<' {
+			return fmt.Errorf("%s: unexpected text at tag %d, tags must be enclosed in <>", name, position+1)
+		}
+		end := strings.IndexByte(rest, '>')
+		if end < 0 {
+			return fmt.Errorf("%s: tag %d is not closed with >", name, position+1)
+		}
+		position++
+		if err := validateIPacketTag(rest[1:end]); err != nil {
+			return fmt.Errorf("%s: tag %d <%s>: %w", name, position, rest[1:end], err)
+		}
+		rest = strings.TrimSpace(rest[end+1:])
+	}
+	return nil
+}
+
+// validateIPacketTag checks a single I-packet tag without the angle brackets
+func validateIPacketTag(tag string) error {
+	fields := strings.Fields(tag)
+	if len(fields) == 0 {
+		return errors.New("empty tag")
+	}
+	switch fields[0] {
+	case "c", "t":
+		if len(fields) != 1 {
+			return fmt.Errorf("<%s> takes no value", fields[0])
+		}
+	case "b":
+		if len(fields) != 2 {
+			return errors.New("<b> requires one hex value, e.g. <b 0x1a2b>")
+		}
+		data, ok := strings.CutPrefix(strings.ToLower(fields[1]), "0x")
+		if !ok || data == "" {
+			return errors.New("<b> value must start with 0x followed by hex bytes")
+		}
+		if _, err := hex.DecodeString(data); err != nil {
+			return fmt.Errorf("<b> value is not valid hex: %w", err)
+		}
+	case "r", "rc", "rd":
+		if len(fields) != 2 {
+			return fmt.Errorf("<%s> requires a length, e.g. <%s 16>", fields[0], fields[0])
+		}
+		length, err := strconv.Atoi(fields[1])
+		if err != nil || length < 1 || length > awgMaxPacketSize {
+			return fmt.Errorf("<%s> length must be a number between 1 and %d", fields[0], awgMaxPacketSize)
+		}
+	default:
+		return errors.New("unknown tag, expected one of b, c, t, r, rc, rd")
+	}
+	return nil
+}
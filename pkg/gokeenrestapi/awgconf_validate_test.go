@@ -0,0 +1,108 @@
+package gokeenrestapi
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/multierr"
+)
+
+var _ = Describe("AWG parameter validation", func() {
+	validASC := func() ascParams {
+		return ascParams{
+			Jc: "4", Jmin: "40", Jmax: "70",
+			S1: "15", S2: "25",
+			H1: "11", H2: "22", H3: "33", H4: "44",
+		}
+	}
+
+	It("accepts valid AWG 1.0 parameters", func() {
+		Expect(validateASCParams(validASC())).To(Succeed())
+	})
+
+	It("accepts a standard WireGuard conf and router defaults", func() {
+		Expect(validateASCParams(ascParams{})).To(Succeed())
+		Expect(validateASCParams(ascParams{
+			Jc: "0", Jmin: "0", Jmax: "0", S1: "0", S2: "0",
+			H1: "0", H2: "0", H3: "0", H4: "0", I1: "0",
+		})).To(Succeed())
+	})
+
+	It("accepts AWG 2.0 header ranges and signature packets", func() {
+		asc := validASC()
+		asc.H1, asc.H2, asc.H3, asc.H4 = "100-200", "201-300", "301-400", "401-500"
+		asc.S3, asc.S4 = "30", "10"
+		asc.I1 = "<b 0xc70000000108><r 16><c><t>"
+		asc.I2 = "<rc 8> <rd 4>"
+		Expect(validateASCParams(asc)).To(Succeed())
+	})
+
+	It("accepts legacy numeric signature packets and the full uint32 header range", func() {
+		asc := validASC()
+		asc.H1, asc.H4 = "4294967295", "4294967000-4294967294"
+		asc.I1, asc.I2, asc.I3 = "70", "56", "42"
+		Expect(validateASCParams(asc)).To(Succeed())
+	})
+
+	DescribeTable("rejects invalid parameters with a precise message",
+		func(modify func(*ascParams), message string) {
+			asc := validASC()
+			modify(&asc)
+			err := validateASCParams(asc)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(message))
+		},
+		Entry("non-numeric Jc", func(a *ascParams) { a.Jc = "many" }, "Jc = many must be a number between 0 and 128"),
+		Entry("Jc too large", func(a *ascParams) { a.Jc = "129" }, "Jc = 129 must be a number between 0 and 128"),
+		Entry("Jmin greater than Jmax", func(a *ascParams) { a.Jmin, a.Jmax = "100", "50" }, "Jmin = 100 must not be greater than Jmax = 50"),
+		Entry("Jmax above packet size", func(a *ascParams) { a.Jmax = "1281" }, "Jmax = 1281 must be a number between 0 and 1280"),
+		Entry("S1 padding too large", func(a *ascParams) { a.S1 = "1133" }, "S1 = 1133 must be a number between 0 and 1132"),
+		Entry("S1+56 equals S2", func(a *ascParams) { a.S1, a.S2 = "10", "66" }, "S1 and S2 give the same padded message size 158 (S2 must not equal S1 + 56)"),
+		Entry("S3 collides with S1", func(a *ascParams) { a.S1, a.S3 = "10", "94" }, "S1 and S3 give the same padded message size 158"),
+		Entry("duplicate headers", func(a *ascParams) { a.H3 = "11" }, "H3 = 11 overlaps H1 = 11"),
+		Entry("overlapping header ranges", func(a *ascParams) { a.H1, a.H2 = "100-200", "150-250" }, "H2 = 150-250 overlaps H1 = 100-200"),
+		Entry("reversed header range", func(a *ascParams) { a.H1 = "200-100" }, "H1 = 200-100: range start must not be greater than its end"),
+		Entry("I-packet without brackets", func(a *ascParams) { a.I1 = "b 0x42" }, "I1: unexpected text at tag 1, tags must be enclosed in <>"),
+		Entry("header out of range", func(a *ascParams) { a.H1 = "4294967296" }, "H1 = 4294967296 must be a number or a range min-max between 0 and 4294967295"),
+		Entry("unclosed I-packet tag", func(a *ascParams) { a.I2 = "<b 0x01><r 10" }, "I2: tag 2 is not closed with >"),
+		Entry("unknown I-packet tag", func(a *ascParams) { a.I3 = "<x 1>" }, "I3: tag 1 <x 1>: unknown tag"),
+		Entry("I-packet bytes without 0x", func(a *ascParams) { a.I1 = "<b 0102>" }, "<b> value must start with 0x"),
+		Entry("I-packet bytes with odd hex", func(a *ascParams) { a.I1 = "<b 0x123>" }, "<b> value is not valid hex"),
+		Entry("I-packet random without length", func(a *ascParams) { a.I4 = "<r>" }, "<r> requires a length"),
+		Entry("I-packet random with zero length", func(a *ascParams) { a.I5 = "<rd 0>" }, "<rd> length must be a number between 1 and 1280"),
+		Entry("I-packet counter with value", func(a *ascParams) { a.I1 = "<c 1>" }, "<c> takes no value"),
+	)
+
+	It("reports every violation at once", func() {
+		asc := validASC()
+		asc.Jmin, asc.Jmax = "100", "50"
+		asc.H4 = "11"
+		asc.I1 = "<z>"
+
+		errs := multierr.Errors(validateASCParams(asc))
+		Expect(errs).To(HaveLen(3))
+	})
+
+	It("rejects invalid conf files before touching the router", func() {
+		confPath := filepath.Join(GinkgoT().TempDir(), "broken.conf")
+		Expect(os.WriteFile(confPath, []byte(`[Interface]
+PrivateKey = abc
+Jc = 4
+Jmin = 100
+Jmax = 50
+
+[Peer]
+PublicKey = gN65BkIKy1eCE9pP1wdc8ROUunkiVXrBvGAKBEKdOQI=`), 0600)).To(Succeed())
+
+		err := AwgConf.ValidateConf(confPath)
+		Expect(multierr.Errors(err)).To(HaveLen(1))
+		Expect(err.Error()).To(Equal("Jmin = 100 must not be greater than Jmax = 50"))
+
+		// No router is running: the validation error is returned before any request
+		_, err = AwgConf.AddInterface(confPath, "")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("invalid AWG parameters"))
+	})
+})
@@ -3,6 +3,7 @@ package gokeenrestapi
 import (
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/fatih/color"
@@ -63,53 +64,41 @@ func (*checks) CheckComponentInstalled(componentName string) (installed string,
 }
 
 // CheckAWGInterfaceExistsFromConfFile checks if a WireGuard connection from the config file already exists
-// Returns an error if a matching WireGuard interface is found with the same configuration
+// An interface matches when it has the address of the config file and any of its [Peer] sections:
+// the same public key and, if the peer has an Endpoint, the same endpoint
+// Returns an error if a matching WireGuard interface is found
 func (*checks) CheckAWGInterfaceExistsFromConfFile(confPath string) error {
-	interfaces, err := Interface.GetInterfacesViaRciShowInterfaces(true, InterfaceTypeWireguard)
-	if err != nil {
-		return err
-	}
-	var interfacesIds []string
-	for _, interfacesDetails := range interfaces {
-		interfacesIds = append(interfacesIds, interfacesDetails.Id)
-	}
-	scInterfaces, err := Interface.GetInterfacesViaRciShowScInterfaces(interfacesIds...)
-	if err != nil {
-		return err
-	}
-	conf, err := ini.Load(confPath)
+	_, peers, err := parseConfSource(confPath)
 	if err != nil {
 		return err
 	}
-	peerSection, err := conf.GetSection("Peer")
+	address, err := parseConfAddress(confPath)
 	if err != nil {
 		return err
 	}
-	interfaceSection, err := conf.GetSection("Interface")
-	if err != nil {
-		return err
-	}
-	address, err := interfaceSection.GetKey("Address")
+	interfaces, err := Interface.GetInterfacesViaRciShowInterfaces(true, InterfaceTypeWireguard)
 	if err != nil {
 		return err
 	}
-	endpoint, err := peerSection.GetKey("Endpoint")
-	if err != nil {
-		return err
+	var interfacesIds []string
+	for _, interfacesDetails := range interfaces {
+		interfacesIds = append(interfacesIds, interfacesDetails.Id)
 	}
-	publicKey, err := peerSection.GetKey("PublicKey")
+	scInterfaces, err := Interface.GetInterfacesViaRciShowScInterfaces(interfacesIds...)
 	if err != nil {
 		return err
 	}
 	foundWgId := ""
-	addressKey := address.String()
-	addressKeySplit := strings.Split(addressKey, "/")
-	publicKeyKey := publicKey.String()
-	endpointKey := endpoint.String()
 	for id, interfaceDetails := range scInterfaces {
+		if !strings.EqualFold(interfaceDetails.IP.Address.Address, address) {
+			continue
+		}
 		for _, peer := range interfaceDetails.Wireguard.Peer {
-			if strings.EqualFold(publicKeyKey, peer.Key) && strings.EqualFold(endpointKey, peer.Endpoint.Address) &&
-				strings.EqualFold(interfaceDetails.IP.Address.Address, addressKeySplit[0]) {
+			matches := slices.ContainsFunc(peers, func(confPeer peerParams) bool {
+				return strings.EqualFold(confPeer.PublicKey, peer.Key) &&
+					(confPeer.Endpoint == "" || strings.EqualFold(confPeer.Endpoint, peer.Endpoint.Address))
+			})
+			if matches {
 				foundWgId = id
 				break
 			}
@@ -120,3 +109,21 @@ func (*checks) CheckAWGInterfaceExistsFromConfFile(confPath string) error {
 	}
 	return nil
 }
+
+// parseConfAddress returns the first address of the [Interface] section of a .conf without its prefix length
+func parseConfAddress(source any) (string, error) {
+	cfg, err := ini.LoadSources(ini.LoadOptions{AllowNonUniqueSections: true}, source)
+	if err != nil {
+		return "", err
+	}
+	interfaceSection, err := cfg.GetSection("Interface")
+	if err != nil {
+		return "", fmt.Errorf("conf file missing [Interface] section: %w", err)
+	}
+	key, err := interfaceSection.GetKey("Address")
+	if err != nil {
+		return "", fmt.Errorf("conf file missing Address in [Interface] section: %w", err)
+	}
+	address := strings.TrimSpace(strings.Split(key.String(), ",")[0])
+	return strings.Split(address, "/")[0], nil
+}
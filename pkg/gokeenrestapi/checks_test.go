@@ -165,6 +165,25 @@ AllowedIPs = 0.0.0.0/0`)
 		Expect(Checks.CheckAWGInterfaceExistsFromConfFile(confPath)).To(Succeed())
 	})
 
+	It("should fail when any peer of a multi-peer conf matches an existing interface", func() {
+		confPath := createConf(`[Interface]
+PrivateKey = abc
+Address = 10.0.0.1/24, fd00::1/64
+
+[Peer]
+PublicKey = otherPublicKey123456789012345678901234567890=
+Endpoint = other.example.com:51820
+AllowedIPs = 10.1.0.0/16
+
+[Peer]
+PublicKey = gN65BkIKy1eCE9pP1wdc8ROUunkiVXrBvGAKBEKdOQI=
+AllowedIPs = 0.0.0.0/0`)
+
+		err := Checks.CheckAWGInterfaceExistsFromConfFile(confPath)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("Wireguard0"))
+	})
+
 	It("should fail for non-existent conf file", func() {
 		err := Checks.CheckAWGInterfaceExistsFromConfFile("/nonexistent/path.conf")
 		Expect(err).To(HaveOccurred())
@@ -14,6 +14,17 @@ const (
 	StateDisconnected = "no"
 )
 
+// DNS-routing group statuses compared with config
+const (
+	DnsRoutingStatusInSync    = "in-sync"
+	DnsRoutingStatusDrifted   = "drifted"
+	DnsRoutingStatusUnmanaged = "unmanaged"
+	DnsRoutingStatusMissing   = "missing"
+)
+
+// AwgPrivateKeyPlaceholder is written to exported .conf files when the router does not expose the private key
+const AwgPrivateKeyPlaceholder = "<PRIVATE_KEY>"
+
 // Parse status
 const (
 	StatusOK    = "ok"
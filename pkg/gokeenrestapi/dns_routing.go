@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -30,6 +31,9 @@ const (
 // versionNumericRe extracts the leading numeric version (e.g. "5.1" from "5.1 Beta 4")
 var versionNumericRe = regexp.MustCompile(`^[\d]+(?:\.[\d]+)*`)
 
+// ErrDnsRoutingUnsupported is returned by CheckDnsRoutingSupport when the firmware is too old for DNS-routing
+var ErrDnsRoutingUnsupported = errors.New("DNS-routing requires Keenetic firmware version " + minDnsRoutingVersion + " or higher")
+
 var (
 	// DnsRouting provides DNS-routing functionality for domain-based routing policies
 	DnsRouting keeneticDnsRouting
@@ -37,6 +41,22 @@ var (
 
 type keeneticDnsRouting struct{}
 
+// DnsRoutingGroupStatus describes a DNS-routing group on the router compared with config
+type DnsRoutingGroupStatus struct {
+	// Name is the object-group name
+	Name string `json:"name"`
+	// InterfaceID is the interface the group is routed through on the router (empty without a dns-proxy route)
+	InterfaceID string `json:"interfaceId"`
+	// InterfaceUp reports whether the target interface is up and connected
+	InterfaceUp bool `json:"interfaceUp"`
+	// DomainCount is the number of domains in the object-group on the router
+	DomainCount int `json:"domainCount"`
+	// Status is one of in-sync, drifted, unmanaged or missing
+	Status string `json:"status"`
+	// Drift lists the differences between the router and config for drifted and missing groups
+	Drift []string `json:"drift,omitempty"`
+}
+
 // CheckDnsRoutingSupport validates that the router firmware version supports DNS-routing (>= 5.0.1)
 func (*keeneticDnsRouting) CheckDnsRoutingSupport() error {
 	runtime := gokeencache.GetRuntimeConfig()
@@ -65,7 +85,7 @@ func (*keeneticDnsRouting) CheckDnsRoutingSupport() error {
 	}
 
 	if currentVer.LessThan(minVer) {
-		return fmt.Errorf("DNS-routing requires Keenetic firmware version %s or higher. Current version: %s", minDnsRoutingVersion, routerVersion)
+		return fmt.Errorf("%w. Current version: %s", ErrDnsRoutingUnsupported, routerVersion)
 	}
 
 	return nil
@@ -217,12 +237,8 @@ func parseDomainLines(lines []string, source string) ([]string, int) {
 		// - Bare names without TLD (youtube, instagram)
 		// - Lines starting with @ or other invalid characters
 		// - regexp patterns (after prefix removal, these will fail IDNA validation)
-		valid, reason := validateDomainWithIDNA(processedLine)
-		if !valid {
+		if !acceptDomain(processedLine, originalLine, source) {
 			skipped++
-			if config.Cfg.Logs.Debug {
-				gokeenlog.InfoSubStepf("Skipped invalid domain from %s: %s (%s)", source, originalLine, reason)
-			}
 			continue
 		}
 
@@ -234,15 +250,29 @@ func parseDomainLines(lines []string, source string) ([]string, int) {
 
 // LoadDomainsFromFile reads domains from a .txt file (one domain per line)
 // Supports comments (lines starting with #) and empty lines
+// The list format is detected automatically, see LoadDomainsFromFileWithFormat
 func (*keeneticDnsRouting) LoadDomainsFromFile(filePath string) ([]string, error) {
+	return DnsRouting.LoadDomainsFromFileWithFormat(filePath, config.DomainFormatAuto)
+}
+
+// LoadDomainsFromFileWithFormat reads domains from a file in the given format
+// An empty format or auto detects the format from the file content
+func (*keeneticDnsRouting) LoadDomainsFromFileWithFormat(filePath, format string) ([]string, error) {
 	b, err := os.ReadFile(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read domain file '%s': %w", filePath, err)
 	}
 
-	lines := strings.Split(string(b), "\n")
-	domains, skipped := parseDomainLines(lines, fmt.Sprintf("file %s", filepath.Base(filePath)))
+	domains, skipped, detected, err := parseDomainContent(string(b), fmt.Sprintf("file %s", filepath.Base(filePath)), format)
+	if err != nil {
+		return nil, err
+	}
 
+	if detected != config.DomainFormatPlain {
+		gokeenlog.InfoSubStepf("Parsed file %v as %v format",
+			color.CyanString(filepath.Base(filePath)),
+			color.BlueString(detected))
+	}
 	if skipped > 0 {
 		gokeenlog.InfoSubStepf("Skipped %v invalid domain(s) from file: %v",
 			color.YellowString("%d", skipped),
@@ -254,11 +284,20 @@ func (*keeneticDnsRouting) LoadDomainsFromFile(filePath string) ([]string, error
 
 // LoadDomainsFromURL downloads a .txt file from a URL and returns the domains
 // Tracks content changes via checksum and reports when remote lists are updated
+// The list format is detected automatically, see LoadDomainsFromURLWithFormat
 func (*keeneticDnsRouting) LoadDomainsFromURL(url string) ([]string, error) {
+	return DnsRouting.LoadDomainsFromURLWithFormat(url, config.DomainFormatAuto)
+}
+
+// LoadDomainsFromURLWithFormat downloads a domain list in the given format from a URL and returns the domains
+// An empty format or auto detects the format from the downloaded content
+func (*keeneticDnsRouting) LoadDomainsFromURLWithFormat(url, format string) ([]string, error) {
 	// Check cache first
 	if cached, ok := gokeencache.GetURLContent(url); ok {
-		lines := strings.Split(cached, "\n")
-		domains, _ := parseDomainLines(lines, "URL")
+		domains, _, _, err := parseDomainContent(cached, "URL", format)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse domain URL '%s': %w", url, err)
+		}
 		gokeenlog.InfoSubStepf("Loaded %v domains from cache, URL: %v",
 			color.GreenString("%d", len(domains)),
 			color.CyanString(url))
@@ -273,6 +312,7 @@ func (*keeneticDnsRouting) LoadDomainsFromURL(url string) ([]string, error) {
 
 	var domains []string
 	var skipped int
+	var detected string
 	var checksumChanged bool
 
 	err := gokeenspinner.WrapWithSpinnerAndOptions(
@@ -299,14 +339,20 @@ func (*keeneticDnsRouting) LoadDomainsFromURL(url string) ([]string, error) {
 				gokeenlog.InfoSubStepf("Warning: failed to cache URL content for %v: %v", url, err)
 			}
 
-			lines := strings.Split(content, "\n")
-			domains, skipped = parseDomainLines(lines, "URL")
+			var parseErr error
+			domains, skipped, detected, parseErr = parseDomainContent(content, "URL", format)
+			if parseErr != nil {
+				return parseErr
+			}
 
 			opts.AddActionAfterSpinner(func() {
 				if checksumChanged {
 					gokeenlog.InfoSubStepf("Domain list updated (checksum changed): %v",
 						color.YellowString(url))
 				}
+				if detected != config.DomainFormatPlain {
+					gokeenlog.InfoSubStepf("Parsed as %v format", color.BlueString(detected))
+				}
 				if skipped > 0 {
 					gokeenlog.InfoSubStepf("Skipped %v invalid domain(s)",
 						color.YellowString("%d", skipped))
@@ -327,6 +373,7 @@ func (*keeneticDnsRouting) LoadDomainsFromURL(url string) ([]string, error) {
 }
 
 // validateNoDuplicateDomainsAcrossGroups checks that no domain appears in multiple groups
+// and that no domain is covered by a parent domain from another group
 // This prevents routing conflicts where the same domain would be routed through different interfaces
 func validateNoDuplicateDomainsAcrossGroups(groupDomains map[string][]string) {
 	domainToGroups := make(map[string][]string)
@@ -339,6 +386,7 @@ func validateNoDuplicateDomainsAcrossGroups(groupDomains map[string][]string) {
 	var duplicates []string
 	for domain, groupNames := range domainToGroups {
 		if len(groupNames) > 1 {
+			slices.Sort(groupNames)
 			duplicates = append(duplicates, domain)
 		}
 	}
@@ -356,44 +404,248 @@ func validateNoDuplicateDomainsAcrossGroups(groupDomains map[string][]string) {
 		gokeenlog.Info("Continue anyway - but keep in mind that it should be fixed")
 		gokeenlog.HorizontalLine()
 	}
+
+	// Keenetic object-groups match subdomains, so a parent domain in one group
+	// also captures subdomains listed in another group
+	var overlaps []string
+	for groupName, domains := range groupDomains {
+		for _, domain := range domains {
+			for _, parent := range parentDomains(domain) {
+				for _, parentGroup := range domainToGroups[parent] {
+					if parentGroup == groupName {
+						continue
+					}
+					overlaps = append(overlaps, fmt.Sprintf("%s (group %s) is covered by %s (group %s)",
+						color.YellowString(domain), color.CyanString(groupName),
+						color.YellowString(parent), color.CyanString(parentGroup)))
+				}
+			}
+		}
+	}
+
+	if len(overlaps) > 0 {
+		slices.Sort(overlaps)
+		gokeenlog.Infof("%s: domains are covered by a parent domain from another group", color.RedString("Misconfiguration found"))
+		for _, overlap := range overlaps {
+			gokeenlog.InfoSubStep(overlap)
+		}
+		gokeenlog.Info("Subdomains are matched by their parent domain, so this traffic may be routed through either group")
+		gokeenlog.Info("Continue anyway - but keep in mind that it should be fixed")
+		gokeenlog.HorizontalLine()
+	}
 }
 
-// AddDnsRoutingGroups creates object-groups and dns-proxy routes for the specified groups
-// This function is idempotent - it only creates groups/domains/routes that don't already exist
-func (*keeneticDnsRouting) AddDnsRoutingGroups(groups []config.DnsRoutingGroup) error {
-	if len(groups) == 0 {
-		gokeenlog.Info("No DNS-routing groups to add")
+// checkNoDuplicateDomainsAcrossGroups returns an error listing every domain that appears in multiple groups
+// Used by the error conflict policy
+func checkNoDuplicateDomainsAcrossGroups(groupDomains map[string][]string) error {
+	domainToGroups := make(map[string][]string)
+	for groupName, domains := range groupDomains {
+		for _, domain := range domains {
+			domainToGroups[domain] = append(domainToGroups[domain], groupName)
+		}
+	}
+
+	var duplicates []string
+	for domain, groupNames := range domainToGroups {
+		if len(groupNames) > 1 {
+			slices.Sort(groupNames)
+			duplicates = append(duplicates, fmt.Sprintf("%s (groups: %s)", domain, strings.Join(groupNames, ", ")))
+		}
+	}
+	if len(duplicates) == 0 {
 		return nil
 	}
 
-	// Validate configuration
-	if err := config.ValidateDnsRoutingGroups(groups); err != nil {
-		return err
+	slices.Sort(duplicates)
+	return fmt.Errorf("domains appear in multiple DNS-routing groups (conflict policy '%s'): %s",
+		config.ConflictPolicyError, strings.Join(duplicates, "; "))
+}
+
+// resolveDomainConflicts keeps every domain present in multiple groups only in the winning group
+// Under first-wins the group defined first in config wins; under priority-wins the group with the
+// highest priority wins and ties fall back to config order, so the result is deterministic across runs
+// Returns the number of domain entries removed from losing groups
+func resolveDomainConflicts(groups []config.DnsRoutingGroup, groupDomains map[string][]string, policy string) int {
+	// winners maps a domain to the index (in groups) of the group that keeps it
+	winners := make(map[string]int)
+	for i, group := range groups {
+		for _, domain := range groupDomains[group.Name] {
+			winner, seen := winners[domain]
+			if !seen {
+				winners[domain] = i
+				continue
+			}
+			if policy == config.ConflictPolicyPriorityWins && group.Priority > groups[winner].Priority {
+				winners[domain] = i
+			}
+		}
 	}
 
-	// Check router version support
-	if err := DnsRouting.CheckDnsRoutingSupport(); err != nil {
-		return err
+	removed := 0
+	for i, group := range groups {
+		domains, exists := groupDomains[group.Name]
+		if !exists {
+			continue
+		}
+		kept := make([]string, 0, len(domains))
+		for _, domain := range domains {
+			if winners[domain] == i {
+				kept = append(kept, domain)
+				continue
+			}
+			removed++
+			gokeenlog.InfoSubStepf("Removed %v from group %v: kept in group %v (%v)",
+				color.YellowString(domain),
+				color.CyanString(group.Name),
+				color.CyanString(groups[winners[domain]].Name),
+				policy)
+		}
+		groupDomains[group.Name] = kept
 	}
 
-	// Fetch interfaces once for all validations
-	interfaces, err := Interface.GetInterfacesViaRciShowInterfaces(false)
-	if err != nil {
-		return fmt.Errorf("failed to fetch interfaces: %w", err)
+	return removed
+}
+
+// parentDomains returns all parent domains of domain, from the closest to the furthest
+// Example: "a.b.example.com" -> ["b.example.com", "example.com"]
+// Bare TLDs and IP addresses are never returned
+func parentDomains(domain string) []string {
+	if net.ParseIP(domain) != nil {
+		return nil
 	}
+	var parents []string
+	for i := 0; i < len(domain); i++ {
+		if domain[i] != '.' {
+			continue
+		}
+		parent := domain[i+1:]
+		if !strings.Contains(parent, ".") {
+			break
+		}
+		parents = append(parents, parent)
+	}
+	return parents
+}
 
-	// Validate all interfaces exist before generating commands
+// collapseSubdomains removes domains that are covered by a parent domain from the same list
+// Keenetic FQDN object-groups match subdomains, so "rr1.googlevideo.com" is redundant next to "googlevideo.com"
+// Order of the remaining domains is preserved. Returns the collapsed list and the number of removed domains
+func collapseSubdomains(domains []string) ([]string, int) {
+	present := make(map[string]struct{}, len(domains))
+	for _, domain := range domains {
+		present[domain] = struct{}{}
+	}
+
+	collapsed := make([]string, 0, len(domains))
+	for _, domain := range domains {
+		covered := slices.ContainsFunc(parentDomains(domain), func(parent string) bool {
+			_, exists := present[parent]
+			return exists
+		})
+		if !covered {
+			collapsed = append(collapsed, domain)
+		}
+	}
+
+	return collapsed, len(domains) - len(collapsed)
+}
+
+// expandDnsRoutingOverrides moves overridden domains of every group into companion groups
+// Returns groups followed by one companion group per (group, override interface) pair and adds
+// companion domains to groupDomains. Overridden domains are removed from the parent group.
+// Companion groups inherit the priority of their parent for the priority-wins conflict policy
+func expandDnsRoutingOverrides(groups []config.DnsRoutingGroup, groupDomains map[string][]string) []config.DnsRoutingGroup {
+	expanded := slices.Clone(groups)
 	for _, group := range groups {
-		if err := Checks.CheckInterfaceId(group.InterfaceID); err != nil {
-			return fmt.Errorf("group '%s': %w", group.Name, err)
+		if len(group.Overrides) == 0 {
+			continue
+		}
+
+		overridden := make(map[string]bool, len(group.Overrides))
+		var companionNames []string
+		for _, override := range group.Overrides {
+			overridden[override.Domain] = true
+			companion := config.OverrideGroupName(group.Name, override.InterfaceID)
+			if _, exists := groupDomains[companion]; !exists {
+				companionNames = append(companionNames, companion)
+				expanded = append(expanded, config.DnsRoutingGroup{
+					Name:        companion,
+					InterfaceID: override.InterfaceID,
+					Priority:    group.Priority,
+				})
+			}
+			groupDomains[companion] = append(groupDomains[companion], override.Domain)
+		}
+		for _, companion := range companionNames {
+			slices.Sort(groupDomains[companion])
+		}
+
+		domains, exists := groupDomains[group.Name]
+		if !exists {
+			continue
+		}
+		domains = slices.DeleteFunc(domains, func(domain string) bool {
+			return overridden[domain]
+		})
+		groupDomains[group.Name] = domains
+
+		// FQDN object-groups match subdomains, so the parent group keeps matching an override
+		// whose parent domain is still in its list and the override may not take effect
+		for _, override := range group.Overrides {
+			for _, parent := range parentDomains(override.Domain) {
+				if slices.Contains(domains, parent) {
+					gokeenlog.Infof("⚠️  %s: override %v of group %v is covered by domain %v of the same group and may not take effect",
+						color.YellowString("WARNING"),
+						color.CyanString(override.Domain),
+						color.CyanString(group.Name),
+						color.YellowString(parent))
+					break
+				}
+			}
 		}
-		// Check if interface exists in the fetched list
-		if _, exists := interfaces[group.InterfaceID]; !exists {
-			return fmt.Errorf("group '%s': interface '%s' not found", group.Name, group.InterfaceID)
+	}
+	return expanded
+}
+
+// isOverrideGroupOf reports whether groupName is the companion group of parentName for the interface
+// its dns-proxy route points to. Names are matched exactly, a plain prefix match would also catch
+// unrelated groups such as "video-ovr-custom" of a user
+func isOverrideGroupOf(groupName, parentName, routeTarget string) bool {
+	return routeTarget != "" && groupName == config.OverrideGroupName(parentName, routeTarget)
+}
+
+// staleOverrideGroups returns companion groups on the router whose parent group is configured
+// but whose override interface is no longer used by that parent
+func staleOverrideGroups(parentGroups, expandedGroups []config.DnsRoutingGroup, existingGroups map[string][]string, existingRoutes map[string]string) []config.DnsRoutingGroup {
+	current := make(map[string]bool, len(expandedGroups))
+	for _, group := range expandedGroups {
+		current[group.Name] = true
+	}
+
+	var stale []config.DnsRoutingGroup
+	for groupName := range existingGroups {
+		if current[groupName] {
+			continue
+		}
+		for _, parent := range parentGroups {
+			if isOverrideGroupOf(groupName, parent.Name, existingRoutes[groupName]) {
+				stale = append(stale, config.DnsRoutingGroup{Name: groupName, InterfaceID: existingRoutes[groupName]})
+				break
+			}
 		}
 	}
 
-	// Load domains from files and URLs for each group
+	slices.SortFunc(stale, func(a, b config.DnsRoutingGroup) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return stale
+}
+
+// loadGroupDomains loads and deduplicates domains of every group from its files and URLs,
+// moves overridden domains into companion groups, validates every group against the router limits
+// and applies the conflict policy for domains present in multiple groups
+// Returns groups followed by their companion groups; groups without any loaded domain are absent from the returned map
+func loadGroupDomains(groups []config.DnsRoutingGroup, conflictPolicy string) ([]config.DnsRoutingGroup, map[string][]string, error) {
 	var mErr error
 	groupDomains := make(map[string][]string)
 
@@ -403,7 +655,7 @@ func (*keeneticDnsRouting) AddDnsRoutingGroups(groups []config.DnsRoutingGroup)
 		// Load domains from local files
 		// Paths are already resolved (absolute) during config loading
 		for _, file := range group.DomainFile {
-			domains, err := DnsRouting.LoadDomainsFromFile(file)
+			domains, err := DnsRouting.LoadDomainsFromFileWithFormat(file, group.SourceFormat(file))
 			if err != nil {
 				mErr = multierr.Append(mErr, fmt.Errorf("group '%s': %w", group.Name, err))
 				continue
@@ -413,7 +665,7 @@ func (*keeneticDnsRouting) AddDnsRoutingGroups(groups []config.DnsRoutingGroup)
 
 		// Load domains from URLs
 		for _, url := range group.DomainURL {
-			domains, err := DnsRouting.LoadDomainsFromURL(url)
+			domains, err := DnsRouting.LoadDomainsFromURLWithFormat(url, group.SourceFormat(url))
 			if err != nil {
 				mErr = multierr.Append(mErr, fmt.Errorf("group '%s': %w", group.Name, err))
 				continue
@@ -437,29 +689,119 @@ func (*keeneticDnsRouting) AddDnsRoutingGroups(groups []config.DnsRoutingGroup)
 				color.CyanString(group.Name))
 		}
 
+		// Drop subdomains already covered by a parent domain to save the per-group budget
+		if group.CollapseSubdomains {
+			var collapsed int
+			allDomains, collapsed = collapseSubdomains(allDomains)
+			if collapsed > 0 {
+				gokeenlog.InfoSubStepf("Collapsed %v subdomain(s) covered by a parent domain in group %v",
+					color.YellowString("%d", collapsed),
+					color.CyanString(group.Name))
+			}
+		}
+
+		groupDomains[group.Name] = allDomains
+	}
+
+	// If there were errors loading domains, return them
+	if mErr != nil {
+		return nil, nil, mErr
+	}
+
+	// Move overridden domains into companion groups before the limits and the conflict policy are applied,
+	// so companion groups are checked like any other group
+	groups = expandDnsRoutingOverrides(groups, groupDomains)
+
+	for _, group := range groups {
+		domains, exists := groupDomains[group.Name]
+		if !exists {
+			continue
+		}
+
 		// Check router limit: maximum domains per group
-		if len(allDomains) > maxDomainsPerGroup {
-			mErr = multierr.Append(mErr, fmt.Errorf("group '%s': exceeds router limit of %d domains (has %d domains)", group.Name, maxDomainsPerGroup, len(allDomains)))
+		if len(domains) > maxDomainsPerGroup {
+			mErr = multierr.Append(mErr, fmt.Errorf("group '%s': exceeds router limit of %d domains (has %d domains)", group.Name, maxDomainsPerGroup, len(domains)))
 			continue
 		}
 
 		// Validate loaded domains
-		if err := config.ValidateDomainList(allDomains, group.Name); err != nil {
+		if err := config.ValidateDomainList(domains, group.Name); err != nil {
 			mErr = multierr.Append(mErr, err)
-			continue
 		}
-
-		groupDomains[group.Name] = allDomains
 	}
-
-	// If there were errors loading domains, return them
 	if mErr != nil {
-		return mErr
+		return nil, nil, mErr
+	}
+
+	// Apply the conflict policy for domains present in multiple groups
+	switch conflictPolicy {
+	case config.ConflictPolicyError:
+		if err := checkNoDuplicateDomainsAcrossGroups(groupDomains); err != nil {
+			return nil, nil, err
+		}
+	case config.ConflictPolicyFirstWins, config.ConflictPolicyPriorityWins:
+		resolveDomainConflicts(groups, groupDomains, conflictPolicy)
 	}
 
 	// Validate no domain appears in multiple groups (configuration error)
 	validateNoDuplicateDomainsAcrossGroups(groupDomains)
 
+	return groups, groupDomains, nil
+}
+
+// AddDnsRoutingGroups creates object-groups and dns-proxy routes for the specified groups
+// This function is idempotent - it only creates groups/domains/routes that don't already exist
+func (*keeneticDnsRouting) AddDnsRoutingGroups(groups []config.DnsRoutingGroup) error {
+	if len(groups) == 0 {
+		gokeenlog.Info("No DNS-routing groups to add")
+		return nil
+	}
+
+	// Validate configuration
+	if err := config.ValidateDnsRoutingGroups(groups); err != nil {
+		return err
+	}
+	conflictPolicy := config.Cfg.DNS.Routes.ConflictPolicy
+	if err := config.ValidateConflictPolicy(conflictPolicy); err != nil {
+		return err
+	}
+
+	// Check router version support
+	if err := DnsRouting.CheckDnsRoutingSupport(); err != nil {
+		return err
+	}
+
+	// Fetch interfaces once for all validations
+	interfaces, err := Interface.GetInterfacesViaRciShowInterfaces(false)
+	if err != nil {
+		return fmt.Errorf("failed to fetch interfaces: %w", err)
+	}
+
+	// Validate all interfaces exist before generating commands
+	for _, group := range groups {
+		interfaceIds := []string{group.InterfaceID}
+		for _, override := range group.Overrides {
+			interfaceIds = append(interfaceIds, override.InterfaceID)
+		}
+		for _, interfaceId := range interfaceIds {
+			if err := Checks.CheckInterfaceId(interfaceId); err != nil {
+				return fmt.Errorf("group '%s': %w", group.Name, err)
+			}
+			// Check if interface exists in the fetched list
+			if _, exists := interfaces[interfaceId]; !exists {
+				return fmt.Errorf("group '%s': interface '%s' not found", group.Name, interfaceId)
+			}
+		}
+	}
+
+	// Load domains from files and URLs, move overridden domains into companion groups
+	// managed together with their parent and apply the conflict policy
+	parentGroups := groups
+	groups, groupDomains, err := loadGroupDomains(groups, conflictPolicy)
+	if err != nil {
+		return err
+	}
+
 	// Get existing groups from router to make operation idempotent
 
 	existingGroups, err := DnsRouting.GetExistingDnsRoutingGroups()
@@ -539,6 +881,20 @@ func (*keeneticDnsRouting) AddDnsRoutingGroups(groups []config.DnsRoutingGroup)
 		}
 	}
 
+	// Remove companion groups of overrides that are no longer in config
+	staleCompanions := staleOverrideGroups(parentGroups, groups, existingGroups, existingRoutes)
+	for _, companion := range staleCompanions {
+		gokeenlog.InfoSubStepf("Removing override group %v", color.RedString(companion.Name))
+		if companion.InterfaceID != "" {
+			parseSlice = append(parseSlice, gokeenrestapimodels.ParseRequest{
+				Parse: fmt.Sprintf("no dns-proxy route object-group %s %s", companion.Name, companion.InterfaceID),
+			})
+		}
+		parseSlice = append(parseSlice, gokeenrestapimodels.ParseRequest{
+			Parse: fmt.Sprintf("no object-group fqdn %s", companion.Name),
+		})
+	}
+
 	// If no commands to execute, we're done
 	if len(parseSlice) == 0 {
 		gokeenlog.Info("All DNS-routing groups and domains are up to date")
@@ -577,6 +933,233 @@ func (*keeneticDnsRouting) AddDnsRoutingGroups(groups []config.DnsRoutingGroup)
 	return err
 }
 
+// GetDnsRoutingStatus compares DNS-routing groups on the router with the groups from config
+// Groups present only on the router are reported as unmanaged, groups present only in config as missing
+// Results are sorted by group name
+func (*keeneticDnsRouting) GetDnsRoutingStatus(groups []config.DnsRoutingGroup) ([]DnsRoutingGroupStatus, error) {
+	if err := DnsRouting.CheckDnsRoutingSupport(); err != nil {
+		return nil, err
+	}
+
+	existingGroups, err := DnsRouting.GetExistingDnsRoutingGroups()
+	if err != nil {
+		return nil, err
+	}
+
+	existingRoutes, err := DnsRouting.GetExistingDnsProxyRoutes()
+	if err != nil {
+		return nil, err
+	}
+
+	interfaces, err := Interface.GetInterfacesViaRciShowInterfaces(false)
+	if err != nil {
+		return nil, err
+	}
+
+	groups, groupDomains, err := loadGroupDomains(groups, config.Cfg.DNS.Routes.ConflictPolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	isUp := func(interfaceId string) bool {
+		iface, exists := interfaces[interfaceId]
+		return exists && iface.Connected == StateConnected && iface.Link == StateUp && iface.State == StateUp
+	}
+
+	configured := make(map[string]config.DnsRoutingGroup, len(groups))
+	for _, group := range groups {
+		configured[group.Name] = group
+	}
+
+	var statuses []DnsRoutingGroupStatus
+	for groupName, domains := range existingGroups {
+		routeInterface := existingRoutes[groupName]
+		status := DnsRoutingGroupStatus{
+			Name:        groupName,
+			InterfaceID: routeInterface,
+			InterfaceUp: routeInterface != "" && isUp(routeInterface),
+			DomainCount: len(domains),
+			Status:      DnsRoutingStatusUnmanaged,
+		}
+
+		if group, exists := configured[groupName]; exists {
+			status.Drift = dnsRoutingDrift(group, routeInterface, domains, groupDomains[groupName])
+			status.Status = DnsRoutingStatusInSync
+			if len(status.Drift) > 0 {
+				status.Status = DnsRoutingStatusDrifted
+			}
+		}
+		statuses = append(statuses, status)
+	}
+
+	for _, group := range groups {
+		if _, exists := existingGroups[group.Name]; exists {
+			continue
+		}
+		statuses = append(statuses, DnsRoutingGroupStatus{
+			Name:        group.Name,
+			InterfaceID: group.InterfaceID,
+			InterfaceUp: isUp(group.InterfaceID),
+			Status:      DnsRoutingStatusMissing,
+			Drift:       []string{"object-group not found on router"},
+		})
+	}
+
+	slices.SortFunc(statuses, func(a, b DnsRoutingGroupStatus) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return statuses, nil
+}
+
+// dnsRoutingDrift describes the differences between a group on the router and its config definition
+// Returns nil when the group is in sync
+func dnsRoutingDrift(group config.DnsRoutingGroup, routeInterface string, existingDomains, expectedDomains []string) []string {
+	var drift []string
+
+	switch routeInterface {
+	case "":
+		drift = append(drift, "dns-proxy route not found on router")
+	case group.InterfaceID:
+	default:
+		drift = append(drift, fmt.Sprintf("routed via %s, config expects %s", routeInterface, group.InterfaceID))
+	}
+
+	var toAdd, toRemove int
+	for _, domain := range expectedDomains {
+		if !slices.Contains(existingDomains, domain) {
+			toAdd++
+		}
+	}
+	for _, domain := range existingDomains {
+		if !slices.Contains(expectedDomains, domain) {
+			toRemove++
+		}
+	}
+	if toAdd > 0 {
+		drift = append(drift, fmt.Sprintf("%d domain(s) from config missing on router", toAdd))
+	}
+	if toRemove > 0 {
+		drift = append(drift, fmt.Sprintf("%d domain(s) on router not in config", toRemove))
+	}
+
+	return drift
+}
+
+// FindOrphanedDnsRoutingGroups returns router groups whose name starts with prefix but which are not defined in config
+// InterfaceID of a returned group is taken from its dns-proxy route and is empty when the group has no route
+func (*keeneticDnsRouting) FindOrphanedDnsRoutingGroups(groups []config.DnsRoutingGroup, prefix string) ([]config.DnsRoutingGroup, error) {
+	if prefix == "" {
+		return nil, errors.New("prune prefix is required to protect groups that are not managed by gokeenapi. Set 'dns.routes.prunePrefix' in config or use --prune-prefix")
+	}
+
+	if err := DnsRouting.CheckDnsRoutingSupport(); err != nil {
+		return nil, err
+	}
+
+	existingGroups, err := DnsRouting.GetExistingDnsRoutingGroups()
+	if err != nil {
+		return nil, err
+	}
+
+	existingRoutes, err := DnsRouting.GetExistingDnsProxyRoutes()
+	if err != nil {
+		return nil, err
+	}
+
+	configured := make(map[string]bool, len(groups))
+	for _, group := range groups {
+		configured[group.Name] = true
+		for _, override := range group.Overrides {
+			configured[config.OverrideGroupName(group.Name, override.InterfaceID)] = true
+		}
+	}
+
+	var orphans []config.DnsRoutingGroup
+	for groupName := range existingGroups {
+		if !strings.HasPrefix(groupName, prefix) || configured[groupName] {
+			continue
+		}
+		orphans = append(orphans, config.DnsRoutingGroup{
+			Name:        groupName,
+			InterfaceID: existingRoutes[groupName],
+		})
+	}
+
+	slices.SortFunc(orphans, func(a, b config.DnsRoutingGroup) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return orphans, nil
+}
+
+// MoveDnsProxyRoutes re-points dns-proxy routes of the given groups from fromInterface to toInterface.
+// Object-groups and their domains are kept
+func (*keeneticDnsRouting) MoveDnsProxyRoutes(groupNames []string, fromInterface, toInterface string) error {
+	if len(groupNames) == 0 {
+		gokeenlog.Info("No dns-proxy routes to move")
+		return nil
+	}
+
+	if err := DnsRouting.CheckDnsRoutingSupport(); err != nil {
+		return err
+	}
+
+	var parseSlice []gokeenrestapimodels.ParseRequest
+	for _, groupName := range groupNames {
+		parseSlice = append(parseSlice,
+			gokeenrestapimodels.ParseRequest{Parse: fmt.Sprintf("no dns-proxy route object-group %s %s", groupName, fromInterface)},
+			gokeenrestapimodels.ParseRequest{Parse: fmt.Sprintf("dns-proxy route object-group %s %s auto", groupName, toInterface)},
+		)
+	}
+	parseSlice = Common.EnsureSaveConfigAtEnd(parseSlice)
+
+	var parseResponse []gokeenrestapimodels.ParseResponse
+	err := gokeenspinner.WrapWithSpinner(
+		fmt.Sprintf("Moving %v dns-proxy routes from %v to %v", color.CyanString("%d", len(groupNames)), fromInterface, toInterface),
+		func() error {
+			var executeErr error
+			parseResponse, executeErr = Common.ExecutePostParse(parseSlice...)
+			return executeErr
+		},
+	)
+	gokeenlog.PrintParseResponse(parseResponse)
+	return err
+}
+
+// DeleteDnsProxyRoutes removes dns-proxy routes of the given groups to interfaceId.
+// Object-groups and their domains are kept
+func (*keeneticDnsRouting) DeleteDnsProxyRoutes(groupNames []string, interfaceId string) error {
+	if len(groupNames) == 0 {
+		gokeenlog.Info("No dns-proxy routes to delete")
+		return nil
+	}
+
+	if err := DnsRouting.CheckDnsRoutingSupport(); err != nil {
+		return err
+	}
+
+	var parseSlice []gokeenrestapimodels.ParseRequest
+	for _, groupName := range groupNames {
+		parseSlice = append(parseSlice, gokeenrestapimodels.ParseRequest{
+			Parse: fmt.Sprintf("no dns-proxy route object-group %s %s", groupName, interfaceId),
+		})
+	}
+	parseSlice = Common.EnsureSaveConfigAtEnd(parseSlice)
+
+	var parseResponse []gokeenrestapimodels.ParseResponse
+	err := gokeenspinner.WrapWithSpinner(
+		fmt.Sprintf("Deleting %v dns-proxy routes to %v", color.CyanString("%d", len(groupNames)), interfaceId),
+		func() error {
+			var executeErr error
+			parseResponse, executeErr = Common.ExecutePostParse(parseSlice...)
+			return executeErr
+		},
+	)
+	gokeenlog.PrintParseResponse(parseResponse)
+	return err
+}
+
 // DeleteDnsRoutingGroups removes dns-proxy routes and object-groups for the specified groups
 func (*keeneticDnsRouting) DeleteDnsRoutingGroups(groups []config.DnsRoutingGroup) error {
 	if len(groups) == 0 {
@@ -594,6 +1177,10 @@ func (*keeneticDnsRouting) DeleteDnsRoutingGroups(groups []config.DnsRoutingGrou
 	// Generate deletion commands
 	// Order: dns-proxy routes first, then object-groups
 	for _, group := range groups {
+		// Groups without a dns-proxy route only need the object-group removed
+		if group.InterfaceID == "" {
+			continue
+		}
 		parseSlice = append(parseSlice, gokeenrestapimodels.ParseRequest{
 			Parse: fmt.Sprintf("no dns-proxy route object-group %s %s", group.Name, group.InterfaceID),
 		})
@@ -0,0 +1,144 @@
+package gokeenrestapi
+
+import (
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	"github.com/noksa/gokeenapi/pkg/config"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("parentDomains", func() {
+	It("should return parents from the closest to the furthest", func() {
+		Expect(parentDomains("a.b.example.com")).To(Equal([]string{"b.example.com", "example.com"}))
+	})
+
+	It("should not return bare TLDs", func() {
+		Expect(parentDomains("example.com")).To(BeEmpty())
+	})
+
+	It("should not treat IP addresses as domains", func() {
+		Expect(parentDomains("10.1.2.3")).To(BeEmpty())
+	})
+})
+
+var _ = Describe("collapseSubdomains", func() {
+	It("should drop subdomains covered by a parent domain", func() {
+		domains := []string{"googlevideo.com", "rr1---sn-abc.googlevideo.com", "rr2---sn-def.googlevideo.com", "youtube.com"}
+
+		collapsed, removed := collapseSubdomains(domains)
+
+		Expect(collapsed).To(Equal([]string{"googlevideo.com", "youtube.com"}))
+		Expect(removed).To(Equal(2))
+	})
+
+	It("should drop deeply nested subdomains when only a distant parent is present", func() {
+		collapsed, removed := collapseSubdomains([]string{"a.b.c.example.com", "example.com"})
+
+		Expect(collapsed).To(Equal([]string{"example.com"}))
+		Expect(removed).To(Equal(1))
+	})
+
+	It("should keep sibling domains that merely share a suffix", func() {
+		collapsed, removed := collapseSubdomains([]string{"example.com", "myexample.com"})
+
+		Expect(collapsed).To(Equal([]string{"example.com", "myexample.com"}))
+		Expect(removed).To(BeZero())
+	})
+
+	It("should keep IP addresses", func() {
+		collapsed, removed := collapseSubdomains([]string{"8.8.8.8", "8.8.8", "example.com"})
+
+		Expect(collapsed).To(ContainElement("8.8.8.8"))
+		Expect(removed).To(BeZero())
+	})
+})
+
+var _ = Describe("AddDnsRoutingGroups with collapseSubdomains", func() {
+	var (
+		server *httptest.Server
+		tmpDir string
+	)
+
+	BeforeEach(func() {
+		server = NewMockRouterServer(WithVersion("5.0.1"))
+		SetupTestConfig(server.URL)
+		Expect(Common.Auth()).To(Succeed())
+		tmpDir = GinkgoT().TempDir()
+	})
+
+	AfterEach(func() {
+		CleanupTestConfig()
+		server.Close()
+	})
+
+	It("should only push parent domains when collapsing is enabled", func() {
+		domainFile := filepath.Join(tmpDir, "video.txt")
+		Expect(os.WriteFile(domainFile, []byte("googlevideo.com\nrr1.googlevideo.com\nrr2.googlevideo.com\nytimg.com\n"), 0644)).To(Succeed())
+
+		groups := []config.DnsRoutingGroup{
+			{Name: "video", DomainFile: []string{domainFile}, InterfaceID: "Wireguard0", CollapseSubdomains: true},
+		}
+
+		Expect(DnsRouting.AddDnsRoutingGroups(groups)).To(Succeed())
+
+		existing, err := DnsRouting.GetExistingDnsRoutingGroups()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(existing["video"]).To(ConsistOf("googlevideo.com", "ytimg.com"))
+	})
+
+	It("should keep subdomains when collapsing is disabled", func() {
+		domainFile := filepath.Join(tmpDir, "video.txt")
+		Expect(os.WriteFile(domainFile, []byte("googlevideo.com\nrr1.googlevideo.com\n"), 0644)).To(Succeed())
+
+		groups := []config.DnsRoutingGroup{
+			{Name: "video", DomainFile: []string{domainFile}, InterfaceID: "Wireguard0"},
+		}
+
+		Expect(DnsRouting.AddDnsRoutingGroups(groups)).To(Succeed())
+
+		existing, err := DnsRouting.GetExistingDnsRoutingGroups()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(existing["video"]).To(ConsistOf("googlevideo.com", "rr1.googlevideo.com"))
+	})
+
+	It("should fit a list into the router limit once subdomains are collapsed", func() {
+		content := "example.com\n"
+		for i := range maxDomainsPerGroup {
+			content += "d" + string(rune('a'+i%26)) + string(rune('a'+i/26)) + ".example.com\n"
+		}
+		domainFile := filepath.Join(tmpDir, "big.txt")
+		Expect(os.WriteFile(domainFile, []byte(content), 0644)).To(Succeed())
+
+		groups := []config.DnsRoutingGroup{
+			{Name: "big", DomainFile: []string{domainFile}, InterfaceID: "Wireguard0", CollapseSubdomains: true},
+		}
+
+		Expect(DnsRouting.AddDnsRoutingGroups(groups)).To(Succeed())
+
+		existing, err := DnsRouting.GetExistingDnsRoutingGroups()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(existing["big"]).To(ConsistOf("example.com"))
+	})
+
+	It("should warn but continue when a parent domain lives in another group", func() {
+		fileA := filepath.Join(tmpDir, "a.txt")
+		fileB := filepath.Join(tmpDir, "b.txt")
+		Expect(os.WriteFile(fileA, []byte("googlevideo.com\n"), 0644)).To(Succeed())
+		Expect(os.WriteFile(fileB, []byte("rr1.googlevideo.com\n"), 0644)).To(Succeed())
+
+		groups := []config.DnsRoutingGroup{
+			{Name: "group-a", DomainFile: []string{fileA}, InterfaceID: "Wireguard0", CollapseSubdomains: true},
+			{Name: "group-b", DomainFile: []string{fileB}, InterfaceID: "ISP", CollapseSubdomains: true},
+		}
+
+		Expect(DnsRouting.AddDnsRoutingGroups(groups)).To(Succeed())
+
+		existing, err := DnsRouting.GetExistingDnsRoutingGroups()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(existing["group-a"]).To(ConsistOf("googlevideo.com"))
+		Expect(existing["group-b"]).To(ConsistOf("rr1.googlevideo.com"))
+	})
+})
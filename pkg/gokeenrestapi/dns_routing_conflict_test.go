@@ -0,0 +1,156 @@
+package gokeenrestapi
+
+import (
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	"github.com/noksa/gokeenapi/pkg/config"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("resolveDomainConflicts", func() {
+	groups := []config.DnsRoutingGroup{
+		{Name: "first", Priority: 1},
+		{Name: "second", Priority: 5},
+		{Name: "third", Priority: 5},
+	}
+
+	newGroupDomains := func() map[string][]string {
+		return map[string][]string{
+			"first":  {"a.com", "shared.com", "all.com"},
+			"second": {"b.com", "shared.com", "all.com"},
+			"third":  {"c.com", "all.com"},
+		}
+	}
+
+	It("should keep conflicting domains in the first group under first-wins", func() {
+		groupDomains := newGroupDomains()
+
+		removed := resolveDomainConflicts(groups, groupDomains, config.ConflictPolicyFirstWins)
+
+		Expect(removed).To(Equal(3))
+		Expect(groupDomains["first"]).To(Equal([]string{"a.com", "shared.com", "all.com"}))
+		Expect(groupDomains["second"]).To(Equal([]string{"b.com"}))
+		Expect(groupDomains["third"]).To(Equal([]string{"c.com"}))
+	})
+
+	It("should keep conflicting domains in the highest priority group under priority-wins", func() {
+		groupDomains := newGroupDomains()
+
+		removed := resolveDomainConflicts(groups, groupDomains, config.ConflictPolicyPriorityWins)
+
+		Expect(removed).To(Equal(3))
+		Expect(groupDomains["first"]).To(Equal([]string{"a.com"}))
+		Expect(groupDomains["second"]).To(Equal([]string{"b.com", "shared.com", "all.com"}))
+		Expect(groupDomains["third"]).To(Equal([]string{"c.com"}))
+	})
+
+	It("should not touch groups without conflicts", func() {
+		groupDomains := map[string][]string{
+			"first":  {"a.com"},
+			"second": {"b.com"},
+		}
+
+		Expect(resolveDomainConflicts(groups, groupDomains, config.ConflictPolicyPriorityWins)).To(BeZero())
+		Expect(groupDomains["first"]).To(Equal([]string{"a.com"}))
+		Expect(groupDomains["second"]).To(Equal([]string{"b.com"}))
+	})
+})
+
+var _ = Describe("checkNoDuplicateDomainsAcrossGroups", func() {
+	It("should list duplicates with sorted group names", func() {
+		err := checkNoDuplicateDomainsAcrossGroups(map[string][]string{
+			"group-b": {"shared.com", "b.com"},
+			"group-a": {"shared.com", "a.com"},
+		})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("shared.com (groups: group-a, group-b)"))
+	})
+
+	It("should succeed when all domains are unique", func() {
+		Expect(checkNoDuplicateDomainsAcrossGroups(map[string][]string{
+			"group-a": {"a.com"},
+			"group-b": {"b.com"},
+		})).To(Succeed())
+	})
+})
+
+var _ = Describe("AddDnsRoutingGroups with conflict policy", func() {
+	var (
+		server *httptest.Server
+		groups []config.DnsRoutingGroup
+	)
+
+	BeforeEach(func() {
+		server = NewMockRouterServer(WithVersion("5.0.1"))
+		SetupTestConfig(server.URL)
+		Expect(Common.Auth()).To(Succeed())
+
+		tmpDir := GinkgoT().TempDir()
+		fileA := filepath.Join(tmpDir, "a.txt")
+		fileB := filepath.Join(tmpDir, "b.txt")
+		Expect(os.WriteFile(fileA, []byte("a.com\nshared.com\n"), 0644)).To(Succeed())
+		Expect(os.WriteFile(fileB, []byte("b.com\nshared.com\n"), 0644)).To(Succeed())
+
+		groups = []config.DnsRoutingGroup{
+			{Name: "group-a", DomainFile: []string{fileA}, InterfaceID: "Wireguard0"},
+			{Name: "group-b", DomainFile: []string{fileB}, InterfaceID: "ISP", Priority: 10},
+		}
+	})
+
+	AfterEach(func() {
+		CleanupTestConfig()
+		server.Close()
+	})
+
+	It("should keep duplicates in both groups under the default warn policy", func() {
+		Expect(DnsRouting.AddDnsRoutingGroups(groups)).To(Succeed())
+
+		existing, err := DnsRouting.GetExistingDnsRoutingGroups()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(existing["group-a"]).To(ContainElement("shared.com"))
+		Expect(existing["group-b"]).To(ContainElement("shared.com"))
+	})
+
+	It("should fail without touching the router under the error policy", func() {
+		config.Cfg.DNS.Routes.ConflictPolicy = config.ConflictPolicyError
+
+		err := DnsRouting.AddDnsRoutingGroups(groups)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("shared.com"))
+
+		existing, err := DnsRouting.GetExistingDnsRoutingGroups()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(existing).To(BeEmpty())
+	})
+
+	It("should keep duplicates only in the first group under first-wins", func() {
+		config.Cfg.DNS.Routes.ConflictPolicy = config.ConflictPolicyFirstWins
+		Expect(DnsRouting.AddDnsRoutingGroups(groups)).To(Succeed())
+
+		existing, err := DnsRouting.GetExistingDnsRoutingGroups()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(existing["group-a"]).To(ConsistOf("a.com", "shared.com"))
+		Expect(existing["group-b"]).To(ConsistOf("b.com"))
+	})
+
+	It("should keep duplicates only in the highest priority group under priority-wins", func() {
+		config.Cfg.DNS.Routes.ConflictPolicy = config.ConflictPolicyPriorityWins
+		Expect(DnsRouting.AddDnsRoutingGroups(groups)).To(Succeed())
+
+		existing, err := DnsRouting.GetExistingDnsRoutingGroups()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(existing["group-a"]).To(ConsistOf("a.com"))
+		Expect(existing["group-b"]).To(ConsistOf("b.com", "shared.com"))
+	})
+
+	It("should reject an unknown policy", func() {
+		config.Cfg.DNS.Routes.ConflictPolicy = "last-wins"
+
+		err := DnsRouting.AddDnsRoutingGroups(groups)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("invalid DNS routing conflict policy"))
+	})
+})
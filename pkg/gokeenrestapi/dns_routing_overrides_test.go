@@ -0,0 +1,201 @@
+package gokeenrestapi
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/noksa/gokeenapi/pkg/config"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("expandDnsRoutingOverrides", func() {
+	It("should move overridden domains into one companion group per interface", func() {
+		groups := []config.DnsRoutingGroup{
+			{Name: "video", InterfaceID: "Wireguard0", Overrides: []config.DnsRoutingOverride{
+				{Domain: "b.com", InterfaceID: "ISP"},
+				{Domain: "a.com", InterfaceID: "ISP"},
+				{Domain: "c.com", InterfaceID: "GigabitEthernet0/Vlan4"},
+			}},
+			{Name: "plain", InterfaceID: "Wireguard0"},
+		}
+		groupDomains := map[string][]string{
+			"video": {"a.com", "b.com", "c.com", "d.com"},
+			"plain": {"e.com"},
+		}
+
+		expanded := expandDnsRoutingOverrides(groups, groupDomains)
+
+		Expect(expanded).To(Equal([]config.DnsRoutingGroup{
+			groups[0],
+			groups[1],
+			{Name: "video-ovr-ISP", InterfaceID: "ISP"},
+			{Name: "video-ovr-GigabitEthernet0_Vlan4", InterfaceID: "GigabitEthernet0/Vlan4"},
+		}))
+		Expect(groupDomains).To(Equal(map[string][]string{
+			"video":                            {"d.com"},
+			"plain":                            {"e.com"},
+			"video-ovr-ISP":                    {"a.com", "b.com"},
+			"video-ovr-GigabitEthernet0_Vlan4": {"c.com"},
+		}))
+	})
+})
+
+var _ = Describe("expandDnsRoutingOverrides with a covering parent domain", func() {
+	It("should still move the override and keep the covering domain in the parent group", func() {
+		groups := []config.DnsRoutingGroup{
+			{Name: "video", InterfaceID: "Wireguard0", Priority: 5, Overrides: []config.DnsRoutingOverride{
+				{Domain: "rr1.googlevideo.com", InterfaceID: "ISP"},
+			}},
+		}
+		groupDomains := map[string][]string{
+			"video": {"googlevideo.com", "youtube.com"},
+		}
+
+		expanded := expandDnsRoutingOverrides(groups, groupDomains)
+
+		Expect(expanded).To(ContainElement(config.DnsRoutingGroup{Name: "video-ovr-ISP", InterfaceID: "ISP", Priority: 5}))
+		Expect(groupDomains["video"]).To(Equal([]string{"googlevideo.com", "youtube.com"}))
+		Expect(groupDomains["video-ovr-ISP"]).To(Equal([]string{"rr1.googlevideo.com"}))
+	})
+})
+
+var _ = Describe("AddDnsRoutingGroups with overrides", func() {
+	var (
+		server     *httptest.Server
+		domainFile string
+	)
+
+	BeforeEach(func() {
+		server = NewMockRouterServer(WithVersion("5.0.1"))
+		SetupTestConfig(server.URL)
+		Expect(Common.Auth()).To(Succeed())
+
+		domainFile = filepath.Join(GinkgoT().TempDir(), "video.txt")
+		Expect(os.WriteFile(domainFile, []byte("youtube.com\ngooglevideo.com\nytimg.com\n"), 0644)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		CleanupTestConfig()
+		server.Close()
+	})
+
+	It("should route overridden domains through a companion group", func() {
+		groups := []config.DnsRoutingGroup{{
+			Name: "video", DomainFile: []string{domainFile}, InterfaceID: "Wireguard0",
+			Overrides: []config.DnsRoutingOverride{{Domain: "googlevideo.com", InterfaceID: "ISP"}},
+		}}
+
+		Expect(DnsRouting.AddDnsRoutingGroups(groups)).To(Succeed())
+
+		existing, err := DnsRouting.GetExistingDnsRoutingGroups()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(existing["video"]).To(ConsistOf("youtube.com", "ytimg.com"))
+		Expect(existing["video-ovr-ISP"]).To(ConsistOf("googlevideo.com"))
+
+		routes, err := DnsRouting.GetExistingDnsProxyRoutes()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(routes).To(HaveKeyWithValue("video", "Wireguard0"))
+		Expect(routes).To(HaveKeyWithValue("video-ovr-ISP", "ISP"))
+
+		statuses, err := DnsRouting.GetDnsRoutingStatus(groups)
+		Expect(err).NotTo(HaveOccurred())
+		for _, status := range statuses {
+			Expect(status.Status).To(Equal(DnsRoutingStatusInSync), "group %s", status.Name)
+		}
+
+		orphans, err := DnsRouting.FindOrphanedDnsRoutingGroups(groups, "video")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(orphans).To(BeEmpty())
+	})
+
+	It("should remove the companion group and restore the domain when the override is dropped", func() {
+		groups := []config.DnsRoutingGroup{{
+			Name: "video", DomainFile: []string{domainFile}, InterfaceID: "Wireguard0",
+			Overrides: []config.DnsRoutingOverride{{Domain: "googlevideo.com", InterfaceID: "ISP"}},
+		}}
+		Expect(DnsRouting.AddDnsRoutingGroups(groups)).To(Succeed())
+
+		groups[0].Overrides = nil
+		Expect(DnsRouting.AddDnsRoutingGroups(groups)).To(Succeed())
+
+		existing, err := DnsRouting.GetExistingDnsRoutingGroups()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(existing).NotTo(HaveKey("video-ovr-ISP"))
+		Expect(existing["video"]).To(ConsistOf("youtube.com", "googlevideo.com", "ytimg.com"))
+
+		routes, err := DnsRouting.GetExistingDnsProxyRoutes()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(routes).NotTo(HaveKey("video-ovr-ISP"))
+	})
+
+	It("should leave groups that only share the companion name prefix alone", func() {
+		customFile := filepath.Join(GinkgoT().TempDir(), "custom.txt")
+		Expect(os.WriteFile(customFile, []byte("custom.example.com\n"), 0644)).To(Succeed())
+		groups := []config.DnsRoutingGroup{
+			{
+				Name: "video", DomainFile: []string{domainFile}, InterfaceID: "Wireguard0",
+				Overrides: []config.DnsRoutingOverride{{Domain: "googlevideo.com", InterfaceID: "ISP"}},
+			},
+			{Name: "video-ovr-custom", DomainFile: []string{customFile}, InterfaceID: "Wireguard0"},
+		}
+		Expect(DnsRouting.AddDnsRoutingGroups(groups)).To(Succeed())
+
+		groups[0].Overrides = nil
+		Expect(DnsRouting.AddDnsRoutingGroups(groups[:1])).To(Succeed())
+
+		existing, err := DnsRouting.GetExistingDnsRoutingGroups()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(existing).NotTo(HaveKey("video-ovr-ISP"))
+		Expect(existing["video-ovr-custom"]).To(ConsistOf("custom.example.com"))
+	})
+
+	It("should apply the domain limit after overridden domains are moved out", func() {
+		var lines []string
+		for i := range 300 {
+			lines = append(lines, fmt.Sprintf("site%d.example.com", i))
+		}
+		lines = append(lines, "googlevideo.com")
+		Expect(os.WriteFile(domainFile, []byte(strings.Join(lines, "\n")), 0644)).To(Succeed())
+		groups := []config.DnsRoutingGroup{{
+			Name: "video", DomainFile: []string{domainFile}, InterfaceID: "Wireguard0",
+			Overrides: []config.DnsRoutingOverride{{Domain: "googlevideo.com", InterfaceID: "ISP"}},
+		}}
+
+		Expect(DnsRouting.AddDnsRoutingGroups(groups)).To(Succeed())
+
+		existing, err := DnsRouting.GetExistingDnsRoutingGroups()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(existing["video"]).To(HaveLen(300))
+		Expect(existing["video-ovr-ISP"]).To(ConsistOf("googlevideo.com"))
+	})
+
+	It("should prune companion groups together with a removed parent", func() {
+		groups := []config.DnsRoutingGroup{{
+			Name: "video", DomainFile: []string{domainFile}, InterfaceID: "Wireguard0",
+			Overrides: []config.DnsRoutingOverride{{Domain: "googlevideo.com", InterfaceID: "ISP"}},
+		}}
+		Expect(DnsRouting.AddDnsRoutingGroups(groups)).To(Succeed())
+
+		orphans, err := DnsRouting.FindOrphanedDnsRoutingGroups(nil, "video")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(orphans).To(Equal([]config.DnsRoutingGroup{
+			{Name: "video", InterfaceID: "Wireguard0"},
+			{Name: "video-ovr-ISP", InterfaceID: "ISP"},
+		}))
+	})
+
+	It("should reject overrides through unknown interfaces", func() {
+		groups := []config.DnsRoutingGroup{{
+			Name: "video", DomainFile: []string{domainFile}, InterfaceID: "Wireguard0",
+			Overrides: []config.DnsRoutingOverride{{Domain: "googlevideo.com", InterfaceID: "Wireguard9"}},
+		}}
+
+		err := DnsRouting.AddDnsRoutingGroups(groups)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("Wireguard9"))
+	})
+})
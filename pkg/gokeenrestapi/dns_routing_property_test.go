@@ -2,8 +2,10 @@ package gokeenrestapi
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
+	"github.com/noksa/gokeenapi/pkg/config"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"pgregory.net/rapid"
@@ -322,3 +324,104 @@ var _ = Describe("Property: IDNA Domain Validation", func() {
 		Expect(reason).To(ContainSubstring("empty"))
 	})
 })
+
+var _ = Describe("Property: Subdomain Collapsing", func() {
+	It("should never keep a domain whose parent is in the result", func() {
+		rapid.Check(GinkgoT(), func(t *rapid.T) {
+			domains := rapid.SliceOfNDistinct(genValidDomain(), 1, 20, rapid.ID[string]).Draw(t, "domains")
+			parent := genValidDomain().Draw(t, "parent")
+			sub := rapid.StringMatching(`[a-z0-9]{1,10}`).Draw(t, "sub")
+			domains = append(domains, parent, sub+"."+parent)
+
+			collapsed, removed := collapseSubdomains(domains)
+
+			Expect(collapsed).NotTo(ContainElement(sub + "." + parent))
+			Expect(len(collapsed) + removed).To(Equal(len(domains)))
+			present := make(map[string]bool, len(collapsed))
+			for _, domain := range collapsed {
+				present[domain] = true
+			}
+			for _, domain := range collapsed {
+				for _, p := range parentDomains(domain) {
+					Expect(present[p]).To(BeFalse(), "%s kept although parent %s is present", domain, p)
+				}
+			}
+		})
+	})
+
+	It("should be idempotent", func() {
+		rapid.Check(GinkgoT(), func(t *rapid.T) {
+			domains := rapid.SliceOfN(genValidDomain(), 1, 30).Draw(t, "domains")
+
+			once, _ := collapseSubdomains(domains)
+			twice, removed := collapseSubdomains(once)
+
+			Expect(twice).To(Equal(once))
+			Expect(removed).To(BeZero())
+		})
+	})
+})
+
+var _ = Describe("Property: Domain Conflict Resolution", func() {
+	genGroupsWithDomains := func(t *rapid.T) ([]config.DnsRoutingGroup, map[string][]string) {
+		pool := rapid.SliceOfNDistinct(genValidDomain(), 1, 10, rapid.ID[string]).Draw(t, "pool")
+		numGroups := rapid.IntRange(1, 5).Draw(t, "numGroups")
+		groups := make([]config.DnsRoutingGroup, numGroups)
+		groupDomains := make(map[string][]string, numGroups)
+		for i := range numGroups {
+			name := fmt.Sprintf("group-%d", i)
+			groups[i] = config.DnsRoutingGroup{Name: name, Priority: rapid.IntRange(0, 3).Draw(t, fmt.Sprintf("priority%d", i))}
+			groupDomains[name] = rapid.SliceOfNDistinct(rapid.SampledFrom(pool), 0, len(pool), rapid.ID[string]).Draw(t, fmt.Sprintf("domains%d", i))
+		}
+		return groups, groupDomains
+	}
+
+	cloneGroupDomains := func(groupDomains map[string][]string) map[string][]string {
+		cloned := make(map[string][]string, len(groupDomains))
+		for name, domains := range groupDomains {
+			cloned[name] = slices.Clone(domains)
+		}
+		return cloned
+	}
+
+	It("should leave every domain in exactly one group with the highest priority", func() {
+		rapid.Check(GinkgoT(), func(t *rapid.T) {
+			groups, groupDomains := genGroupsWithDomains(t)
+			original := cloneGroupDomains(groupDomains)
+
+			resolveDomainConflicts(groups, groupDomains, config.ConflictPolicyPriorityWins)
+
+			owners := make(map[string]string)
+			for _, group := range groups {
+				for _, domain := range groupDomains[group.Name] {
+					Expect(owners).NotTo(HaveKey(domain), "domain %s kept in several groups", domain)
+					owners[domain] = group.Name
+				}
+			}
+			for i, group := range groups {
+				for _, domain := range original[group.Name] {
+					Expect(owners).To(HaveKey(domain), "domain %s lost", domain)
+					owner := slices.IndexFunc(groups, func(g config.DnsRoutingGroup) bool { return g.Name == owners[domain] })
+					Expect(groups[owner].Priority).To(BeNumerically(">=", group.Priority))
+					if groups[owner].Priority == group.Priority {
+						Expect(owner).To(BeNumerically("<=", i))
+					}
+				}
+			}
+		})
+	})
+
+	It("should be deterministic regardless of map iteration order", func() {
+		rapid.Check(GinkgoT(), func(t *rapid.T) {
+			groups, groupDomains := genGroupsWithDomains(t)
+			policy := rapid.SampledFrom([]string{config.ConflictPolicyFirstWins, config.ConflictPolicyPriorityWins}).Draw(t, "policy")
+
+			first := cloneGroupDomains(groupDomains)
+			second := cloneGroupDomains(groupDomains)
+			resolveDomainConflicts(groups, first, policy)
+			resolveDomainConflicts(groups, second, policy)
+
+			Expect(first).To(Equal(second))
+		})
+	})
+})
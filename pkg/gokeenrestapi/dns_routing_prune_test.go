@@ -0,0 +1,65 @@
+package gokeenrestapi
+
+import (
+	"net/http/httptest"
+
+	"github.com/noksa/gokeenapi/pkg/config"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FindOrphanedDnsRoutingGroups", func() {
+	var server *httptest.Server
+
+	BeforeEach(func() {
+		server = NewMockRouterServer(
+			WithVersion("5.0.1"),
+			WithDnsRoutingGroups(
+				[]MockDnsRoutingGroup{
+					{Name: "gk-b", Domains: []string{"b.example.com"}},
+					{Name: "gk-a", Domains: []string{"a.example.com"}},
+					{Name: "gk-kept", Domains: []string{"kept.example.com"}},
+					{Name: "manual", Domains: []string{"manual.example.com"}},
+				},
+				[]MockDnsProxyRoute{
+					{GroupName: "gk-a", InterfaceID: "Wireguard0", Mode: "auto"},
+					{GroupName: "gk-kept", InterfaceID: "Wireguard0", Mode: "auto"},
+				},
+			),
+		)
+		SetupTestConfig(server.URL)
+		Expect(Common.Auth()).To(Succeed())
+	})
+
+	AfterEach(func() {
+		CleanupTestConfig()
+		server.Close()
+	})
+
+	It("should return sorted orphans matching the prefix with their route interface", func() {
+		groups := []config.DnsRoutingGroup{{Name: "gk-kept", InterfaceID: "Wireguard0"}}
+
+		orphans, err := DnsRouting.FindOrphanedDnsRoutingGroups(groups, "gk-")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(orphans).To(Equal([]config.DnsRoutingGroup{
+			{Name: "gk-a", InterfaceID: "Wireguard0"},
+			{Name: "gk-b"},
+		}))
+	})
+
+	It("should require a prefix", func() {
+		_, err := DnsRouting.FindOrphanedDnsRoutingGroups(nil, "")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should delete unrouted orphans without a dns-proxy route command", func() {
+		orphans, err := DnsRouting.FindOrphanedDnsRoutingGroups(nil, "gk-b")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(DnsRouting.DeleteDnsRoutingGroups(orphans)).To(Succeed())
+
+		existing, err := DnsRouting.GetExistingDnsRoutingGroups()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(existing).NotTo(HaveKey("gk-b"))
+		Expect(existing).To(HaveLen(3))
+	})
+})
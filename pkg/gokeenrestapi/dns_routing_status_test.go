@@ -0,0 +1,96 @@
+package gokeenrestapi
+
+import (
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	"github.com/noksa/gokeenapi/pkg/config"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("GetDnsRoutingStatus", func() {
+	var (
+		server *httptest.Server
+		tmpDir string
+	)
+
+	BeforeEach(func() {
+		server = NewMockRouterServer(
+			WithVersion("5.0.1"),
+			WithInterfaces([]MockInterface{
+				{ID: "Wireguard0", Type: InterfaceTypeWireguard, Connected: StateConnected, Link: StateUp, State: StateUp},
+				{ID: "Wireguard1", Type: InterfaceTypeWireguard, Connected: StateDisconnected, Link: StateDown, State: StateDown},
+			}),
+			WithDnsRoutingGroups(
+				[]MockDnsRoutingGroup{
+					{Name: "in-sync", Domains: []string{"a.com", "b.com"}},
+					{Name: "wrong-iface", Domains: []string{"c.com"}},
+					{Name: "wrong-domains", Domains: []string{"d.com", "stale.com"}},
+					{Name: "no-route", Domains: []string{"e.com"}},
+					{Name: "manual", Domains: []string{"manual.com"}},
+				},
+				[]MockDnsProxyRoute{
+					{GroupName: "in-sync", InterfaceID: "Wireguard0", Mode: "auto"},
+					{GroupName: "wrong-iface", InterfaceID: "Wireguard1", Mode: "auto"},
+					{GroupName: "wrong-domains", InterfaceID: "Wireguard0", Mode: "auto"},
+					{GroupName: "manual", InterfaceID: "Wireguard1", Mode: "auto"},
+				},
+			),
+		)
+		SetupTestConfig(server.URL)
+		Expect(Common.Auth()).To(Succeed())
+		tmpDir = GinkgoT().TempDir()
+	})
+
+	AfterEach(func() {
+		CleanupTestConfig()
+		server.Close()
+	})
+
+	domainFile := func(name, content string) []string {
+		path := filepath.Join(tmpDir, name)
+		Expect(os.WriteFile(path, []byte(content), 0644)).To(Succeed())
+		return []string{path}
+	}
+
+	It("should classify groups against config", func() {
+		groups := []config.DnsRoutingGroup{
+			{Name: "in-sync", DomainFile: domainFile("in-sync.txt", "b.com\na.com\n"), InterfaceID: "Wireguard0"},
+			{Name: "wrong-iface", DomainFile: domainFile("wrong-iface.txt", "c.com\n"), InterfaceID: "Wireguard0"},
+			{Name: "wrong-domains", DomainFile: domainFile("wrong-domains.txt", "d.com\nnew.com\n"), InterfaceID: "Wireguard0"},
+			{Name: "no-route", DomainFile: domainFile("no-route.txt", "e.com\n"), InterfaceID: "Wireguard0"},
+			{Name: "not-created", DomainFile: domainFile("not-created.txt", "f.com\n"), InterfaceID: "Wireguard1"},
+		}
+
+		statuses, err := DnsRouting.GetDnsRoutingStatus(groups)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(statuses).To(Equal([]DnsRoutingGroupStatus{
+			{Name: "in-sync", InterfaceID: "Wireguard0", InterfaceUp: true, DomainCount: 2, Status: DnsRoutingStatusInSync},
+			{Name: "manual", InterfaceID: "Wireguard1", InterfaceUp: false, DomainCount: 1, Status: DnsRoutingStatusUnmanaged},
+			{Name: "no-route", DomainCount: 1, Status: DnsRoutingStatusDrifted,
+				Drift: []string{"dns-proxy route not found on router"}},
+			{Name: "not-created", InterfaceID: "Wireguard1", Status: DnsRoutingStatusMissing,
+				Drift: []string{"object-group not found on router"}},
+			{Name: "wrong-domains", InterfaceID: "Wireguard0", InterfaceUp: true, DomainCount: 2, Status: DnsRoutingStatusDrifted,
+				Drift: []string{"1 domain(s) from config missing on router", "1 domain(s) on router not in config"}},
+			{Name: "wrong-iface", InterfaceID: "Wireguard1", DomainCount: 1, Status: DnsRoutingStatusDrifted,
+				Drift: []string{"routed via Wireguard1, config expects Wireguard0"}},
+		}))
+	})
+
+	It("should report groups as in sync right after AddDnsRoutingGroups", func() {
+		groups := []config.DnsRoutingGroup{
+			{Name: "fresh", DomainFile: domainFile("fresh.txt", "x.com\ny.com\n"), InterfaceID: "Wireguard0"},
+		}
+		Expect(DnsRouting.AddDnsRoutingGroups(groups)).To(Succeed())
+
+		statuses, err := DnsRouting.GetDnsRoutingStatus(groups)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(statuses).To(ContainElement(DnsRoutingGroupStatus{
+			Name: "fresh", InterfaceID: "Wireguard0", InterfaceUp: true, DomainCount: 2, Status: DnsRoutingStatusInSync,
+		}))
+	})
+})
@@ -1,6 +1,8 @@
 package gokeenrestapi
 
 import (
+	"errors"
+
 	"github.com/noksa/gokeenapi/internal/gokeencache"
 	"github.com/noksa/gokeenapi/pkg/config"
 	"github.com/noksa/gokeenapi/pkg/gokeenrestapimodels"
@@ -31,6 +33,7 @@ var _ = Describe("CheckDnsRoutingSupport", func() {
 		Expect(err).To(HaveOccurred())
 		Expect(err.Error()).To(ContainSubstring("DNS-routing requires Keenetic firmware version 5.0.1 or higher"))
 		Expect(err.Error()).To(ContainSubstring("4.3.6.3"))
+		Expect(errors.Is(err, ErrDnsRoutingUnsupported)).To(BeTrue())
 	})
 
 	It("should reject version just below minimum", func() {
@@ -49,6 +52,7 @@ var _ = Describe("CheckDnsRoutingSupport", func() {
 		err := DnsRouting.CheckDnsRoutingSupport()
 		Expect(err).To(HaveOccurred())
 		Expect(err.Error()).To(ContainSubstring("router version information not available"))
+		Expect(errors.Is(err, ErrDnsRoutingUnsupported)).To(BeFalse())
 	})
 
 	It("should support beta version above minimum", func() {
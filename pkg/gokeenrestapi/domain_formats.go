@@ -0,0 +1,250 @@
+package gokeenrestapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+
+	"github.com/noksa/gokeenapi/internal/gokeenlog"
+	"github.com/noksa/gokeenapi/pkg/config"
+)
+
+// formatDetectionSampleSize is the number of significant lines inspected to detect a domain list format
+const formatDetectionSampleSize = 100
+
+// dnsmasqLineRe matches dnsmasq options that carry domains: server=/a.com/..., ipset=/a.com/..., etc.
+var dnsmasqLineRe = regexp.MustCompile(`^(server|ipset|nftset|address|local|rebind-domain-ok)=/`)
+
+// detectDomainFormat guesses the format of a domain list from its content
+// A format other than plain is chosen only when it matches at least half of the sampled lines
+func detectDomainFormat(content string) string {
+	trimmed := strings.TrimSpace(content)
+	if strings.HasPrefix(trimmed, "[") && json.Valid([]byte(trimmed)) {
+		return config.DomainFormatJSON
+	}
+
+	counts := make(map[string]int)
+	sampled := 0
+	for line := range strings.SplitSeq(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "!") || strings.HasPrefix(line, "[") {
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(line, "||") || strings.HasPrefix(line, "@@||"):
+			counts[config.DomainFormatAdGuard]++
+		case dnsmasqLineRe.MatchString(line):
+			counts[config.DomainFormatDnsmasq]++
+		default:
+			if fields := strings.Fields(line); len(fields) >= 2 && net.ParseIP(fields[0]) != nil {
+				counts[config.DomainFormatHosts]++
+			}
+		}
+
+		sampled++
+		if sampled >= formatDetectionSampleSize {
+			break
+		}
+	}
+
+	detected := config.DomainFormatPlain
+	best := 0
+	for _, format := range []string{config.DomainFormatHosts, config.DomainFormatDnsmasq, config.DomainFormatAdGuard} {
+		if counts[format] > best {
+			detected = format
+			best = counts[format]
+		}
+	}
+	if best*2 < sampled {
+		return config.DomainFormatPlain
+	}
+	return detected
+}
+
+// parseDomainContent parses a domain list in the given format and returns valid domains and count of skipped entries
+// An empty format or auto detects the format from the content
+func parseDomainContent(content, source, format string) ([]string, int, string, error) {
+	if format == "" || format == config.DomainFormatAuto {
+		format = detectDomainFormat(content)
+	}
+
+	lines := strings.Split(content, "\n")
+	switch format {
+	case config.DomainFormatPlain:
+		domains, skipped := parseDomainLines(lines, source)
+		return domains, skipped, format, nil
+	case config.DomainFormatHosts:
+		domains, skipped := parseHostsLines(lines, source)
+		return domains, skipped, format, nil
+	case config.DomainFormatDnsmasq:
+		domains, skipped := parseDnsmasqLines(lines, source)
+		return domains, skipped, format, nil
+	case config.DomainFormatAdGuard:
+		domains, skipped := parseAdGuardLines(lines, source)
+		return domains, skipped, format, nil
+	case config.DomainFormatJSON:
+		domains, skipped, err := parseJSONDomains(content, source)
+		return domains, skipped, format, err
+	default:
+		return nil, 0, format, fmt.Errorf("unsupported domain list format '%s'", format)
+	}
+}
+
+// acceptDomain validates a domain extracted from a list entry and logs the reason for rejection in debug mode
+func acceptDomain(domain, originalLine, source string) bool {
+	valid, reason := validateDomainWithIDNA(domain)
+	if !valid && config.Cfg.Logs.Debug {
+		gokeenlog.InfoSubStepf("Skipped invalid domain from %s: %s (%s)", source, originalLine, reason)
+	}
+	return valid
+}
+
+// parseHostsLines parses hosts file lines ("0.0.0.0 domain [domain...]")
+// Returns the list of valid domains and count of skipped entries
+func parseHostsLines(lines []string, source string) ([]string, int) {
+	var domains []string
+	var skipped int
+
+	for _, line := range lines {
+		originalLine := strings.TrimSpace(line)
+
+		// Strip inline comments: "0.0.0.0 ads.example.com # tracker"
+		if idx := strings.Index(originalLine, "#"); idx >= 0 {
+			originalLine = strings.TrimSpace(originalLine[:idx])
+		}
+		if originalLine == "" {
+			continue
+		}
+
+		fields := strings.Fields(originalLine)
+		if len(fields) < 2 || net.ParseIP(fields[0]) == nil {
+			skipped++
+			if config.Cfg.Logs.Debug {
+				gokeenlog.InfoSubStepf("Skipped invalid hosts entry from %s: %s", source, originalLine)
+			}
+			continue
+		}
+
+		for _, host := range fields[1:] {
+			// Hosts files map loopback names and IP literals ("0.0.0.0 0.0.0.0") as well,
+			// they are never routable domains
+			if host == "localhost" || strings.HasPrefix(host, "localhost.") || net.ParseIP(host) != nil {
+				skipped++
+				continue
+			}
+			if !acceptDomain(host, originalLine, source) {
+				skipped++
+				continue
+			}
+			domains = append(domains, host)
+		}
+	}
+
+	return domains, skipped
+}
+
+// parseDnsmasqLines parses dnsmasq option lines ("server=/a.com/b.com/1.1.1.1", "ipset=/a.com/set")
+// Returns the list of valid domains and count of skipped entries
+func parseDnsmasqLines(lines []string, source string) ([]string, int) {
+	var domains []string
+	var skipped int
+
+	for _, line := range lines {
+		originalLine := strings.TrimSpace(line)
+		if originalLine == "" || strings.HasPrefix(originalLine, "#") {
+			continue
+		}
+
+		if !dnsmasqLineRe.MatchString(originalLine) {
+			skipped++
+			if config.Cfg.Logs.Debug {
+				gokeenlog.InfoSubStepf("Skipped unsupported dnsmasq entry from %s: %s", source, originalLine)
+			}
+			continue
+		}
+
+		// Domains are between the first and the last slash: "server=/a.com/b.com/1.1.1.1" -> "a.com/b.com"
+		value := originalLine[strings.Index(originalLine, "=/")+2:]
+		lastSlash := strings.LastIndex(value, "/")
+		if lastSlash <= 0 {
+			skipped++
+			continue
+		}
+
+		for domain := range strings.SplitSeq(value[:lastSlash], "/") {
+			domain = strings.TrimPrefix(strings.TrimPrefix(domain, "*"), ".")
+			if !acceptDomain(domain, originalLine, source) {
+				skipped++
+				continue
+			}
+			domains = append(domains, domain)
+		}
+	}
+
+	return domains, skipped
+}
+
+// parseAdGuardLines parses AdGuard/Adblock filter lines ("||domain^", "||domain^$important")
+// Exception rules (@@), cosmetic rules and rules with wildcards or paths are skipped
+// Returns the list of valid domains and count of skipped entries
+func parseAdGuardLines(lines []string, source string) ([]string, int) {
+	var domains []string
+	var skipped int
+
+	for _, line := range lines {
+		originalLine := strings.TrimSpace(line)
+		if originalLine == "" || strings.HasPrefix(originalLine, "!") || strings.HasPrefix(originalLine, "#") || strings.HasPrefix(originalLine, "[") {
+			continue
+		}
+
+		domain := originalLine
+		if rule, found := strings.CutPrefix(domain, "||"); found {
+			domain = rule
+			if idx := strings.IndexAny(domain, "^$"); idx >= 0 {
+				domain = domain[:idx]
+			}
+		}
+
+		if strings.ContainsAny(domain, "*/|@$^#") || !acceptDomain(domain, originalLine, source) {
+			skipped++
+			if config.Cfg.Logs.Debug {
+				gokeenlog.InfoSubStepf("Skipped unsupported AdGuard rule from %s: %s", source, originalLine)
+			}
+			continue
+		}
+		domains = append(domains, domain)
+	}
+
+	return domains, skipped
+}
+
+// parseJSONDomains parses a JSON array of domain strings
+// Non-string items and invalid domains are skipped
+// Returns the list of valid domains and count of skipped entries
+func parseJSONDomains(content, source string) ([]string, int, error) {
+	var items []any
+	if err := json.Unmarshal([]byte(content), &items); err != nil {
+		return nil, 0, fmt.Errorf("failed to parse JSON domain list from %s: %w", source, err)
+	}
+
+	var domains []string
+	var skipped int
+	for _, item := range items {
+		domain, ok := item.(string)
+		if !ok {
+			skipped++
+			continue
+		}
+		domain = strings.TrimSpace(domain)
+		if !acceptDomain(domain, domain, source) {
+			skipped++
+			continue
+		}
+		domains = append(domains, domain)
+	}
+
+	return domains, skipped, nil
+}
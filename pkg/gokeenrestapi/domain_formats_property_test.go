@@ -0,0 +1,120 @@
+package gokeenrestapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/noksa/gokeenapi/pkg/config"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"pgregory.net/rapid"
+)
+
+// genHostsIP generates IP addresses commonly used in hosts-format block lists
+func genHostsIP() *rapid.Generator[string] {
+	return rapid.SampledFrom([]string{"0.0.0.0", "127.0.0.1", "::", "10.1.2.3"})
+}
+
+var _ = Describe("Property: Hosts Format Parsing", func() {
+	It("should extract every hostname from valid entries", func() {
+		rapid.Check(GinkgoT(), func(t *rapid.T) {
+			hostsPerLine := rapid.SliceOfN(rapid.SliceOfN(genValidDomain(), 1, 3), 1, 20).Draw(t, "hosts")
+
+			var lines, expected []string
+			for i, hosts := range hostsPerLine {
+				ip := genHostsIP().Draw(t, fmt.Sprintf("ip%d", i))
+				lines = append(lines, ip+" "+strings.Join(hosts, " "))
+				expected = append(expected, hosts...)
+			}
+
+			domains, skipped := parseHostsLines(lines, "test")
+
+			Expect(domains).To(Equal(expected))
+			Expect(skipped).To(BeZero())
+			Expect(detectDomainFormat(strings.Join(lines, "\n"))).To(Equal(config.DomainFormatHosts))
+		})
+	})
+})
+
+var _ = Describe("Property: Dnsmasq Format Parsing", func() {
+	It("should extract every domain from server and ipset options", func() {
+		rapid.Check(GinkgoT(), func(t *rapid.T) {
+			domainsPerLine := rapid.SliceOfN(rapid.SliceOfN(genValidDomain(), 1, 3), 1, 20).Draw(t, "domains")
+
+			var lines, expected []string
+			for i, lineDomains := range domainsPerLine {
+				option := rapid.SampledFrom([]string{"server", "ipset", "nftset", "address"}).Draw(t, fmt.Sprintf("option%d", i))
+				lines = append(lines, option+"=/"+strings.Join(lineDomains, "/")+"/target")
+				expected = append(expected, lineDomains...)
+			}
+
+			domains, skipped := parseDnsmasqLines(lines, "test")
+
+			Expect(domains).To(Equal(expected))
+			Expect(skipped).To(BeZero())
+			Expect(detectDomainFormat(strings.Join(lines, "\n"))).To(Equal(config.DomainFormatDnsmasq))
+		})
+	})
+})
+
+var _ = Describe("Property: AdGuard Format Parsing", func() {
+	It("should extract domains from blocking rules and skip exceptions", func() {
+		rapid.Check(GinkgoT(), func(t *rapid.T) {
+			blocked := rapid.SliceOfN(genValidDomain(), 1, 20).Draw(t, "blocked")
+			allowed := rapid.SliceOfN(genValidDomain(), 0, 5).Draw(t, "allowed")
+
+			lines := []string{"! Title: test list"}
+			for i, domain := range blocked {
+				modifier := rapid.SampledFrom([]string{"", "$important", "$all"}).Draw(t, fmt.Sprintf("modifier%d", i))
+				lines = append(lines, "||"+domain+"^"+modifier)
+			}
+			for _, domain := range allowed {
+				lines = append(lines, "@@||"+domain+"^")
+			}
+
+			domains, skipped := parseAdGuardLines(lines, "test")
+
+			Expect(domains).To(Equal(blocked))
+			Expect(skipped).To(Equal(len(allowed)))
+			Expect(detectDomainFormat(strings.Join(lines, "\n"))).To(Equal(config.DomainFormatAdGuard))
+		})
+	})
+})
+
+var _ = Describe("Property: JSON Format Parsing", func() {
+	It("should round-trip any array of valid domains", func() {
+		rapid.Check(GinkgoT(), func(t *rapid.T) {
+			expected := rapid.SliceOfN(genValidDomain(), 1, 30).Draw(t, "domains")
+			b, err := json.Marshal(expected)
+			Expect(err).NotTo(HaveOccurred())
+
+			domains, skipped, format, err := parseDomainContent(string(b), "test", config.DomainFormatAuto)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(format).To(Equal(config.DomainFormatJSON))
+			Expect(domains).To(Equal(expected))
+			Expect(skipped).To(BeZero())
+		})
+	})
+})
+
+var _ = Describe("Property: Format Detection", func() {
+	It("should keep plain lists in plain format", func() {
+		rapid.Check(GinkgoT(), func(t *rapid.T) {
+			lines := rapid.SliceOfN(genValidDomain(), 1, 50).Draw(t, "domains")
+
+			Expect(detectDomainFormat(strings.Join(lines, "\n"))).To(Equal(config.DomainFormatPlain))
+		})
+	})
+
+	It("should never panic on arbitrary content", func() {
+		rapid.Check(GinkgoT(), func(t *rapid.T) {
+			content := rapid.String().Draw(t, "content")
+
+			Expect(func() {
+				_, _, _, _ = parseDomainContent(content, "test", config.DomainFormatAuto)
+			}).NotTo(Panic())
+		})
+	})
+})
@@ -0,0 +1,196 @@
+package gokeenrestapi
+
+import (
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	"github.com/noksa/gokeenapi/pkg/config"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("detectDomainFormat", func() {
+	DescribeTable("should detect the format from content",
+		func(content, expected string) {
+			Expect(detectDomainFormat(content)).To(Equal(expected))
+		},
+		Entry("plain", "example.com\nfoo.org\n", config.DomainFormatPlain),
+		Entry("v2fly", "full:example.com\ndomain:foo.org\n", config.DomainFormatPlain),
+		Entry("hosts", "# hosts\n0.0.0.0 ads.example.com\n127.0.0.1 tracker.foo.org\n", config.DomainFormatHosts),
+		Entry("dnsmasq server", "server=/example.com/1.1.1.1\nserver=/foo.org/1.1.1.1\n", config.DomainFormatDnsmasq),
+		Entry("dnsmasq ipset", "ipset=/example.com/vpn\n", config.DomainFormatDnsmasq),
+		Entry("adguard", "[Adblock Plus 2.0]\n! Title: list\n||example.com^\n||foo.org^$important\n", config.DomainFormatAdGuard),
+		Entry("json", "[\"example.com\", \"foo.org\"]", config.DomainFormatJSON),
+		Entry("plain with a few hosts lines", "a.com\nb.com\nc.com\n0.0.0.0 d.com\n", config.DomainFormatPlain),
+		Entry("empty", "", config.DomainFormatPlain),
+	)
+})
+
+var _ = Describe("parseHostsLines", func() {
+	It("should extract all hostnames and skip loopback names, IP literals and malformed entries", func() {
+		lines := []string{
+			"# comment",
+			"127.0.0.1 localhost",
+			"0.0.0.0 0.0.0.0",
+			"::1 localhost ip6-localhost",
+			"0.0.0.0 ads.example.com tracker.example.com # inline comment",
+			"0.0.0.0",
+			"not-an-ip example.com",
+			"",
+		}
+
+		domains, skipped := parseHostsLines(lines, "test")
+
+		Expect(domains).To(Equal([]string{"ads.example.com", "tracker.example.com"}))
+		Expect(skipped).To(Equal(6))
+	})
+})
+
+var _ = Describe("parseDnsmasqLines", func() {
+	It("should extract domains from server, ipset and address options", func() {
+		lines := []string{
+			"# comment",
+			"server=/example.com/foo.org/1.1.1.1",
+			"ipset=/.bar.net/vpn",
+			"nftset=/baz.io/4#inet#fw4#vpn",
+			"address=/blocked.com/0.0.0.0",
+			"local=/lan.example/",
+			"cache-size=1000",
+		}
+
+		domains, skipped := parseDnsmasqLines(lines, "test")
+
+		Expect(domains).To(Equal([]string{"example.com", "foo.org", "bar.net", "baz.io", "blocked.com", "lan.example"}))
+		Expect(skipped).To(Equal(1))
+	})
+})
+
+var _ = Describe("parseAdGuardLines", func() {
+	It("should extract domains from basic blocking rules only", func() {
+		lines := []string{
+			"[Adblock Plus 2.0]",
+			"! comment",
+			"||example.com^",
+			"||foo.org^$important",
+			"plain.net",
+			"@@||allowed.com^",
+			"||*.wildcard.com^",
+			"example.com##.banner",
+			"/regex/",
+		}
+
+		domains, skipped := parseAdGuardLines(lines, "test")
+
+		Expect(domains).To(Equal([]string{"example.com", "foo.org", "plain.net"}))
+		Expect(skipped).To(Equal(4))
+	})
+})
+
+var _ = Describe("parseJSONDomains", func() {
+	It("should extract string items and skip others", func() {
+		domains, skipped, err := parseJSONDomains(`["example.com", 42, "invalid", " foo.org "]`, "test")
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(domains).To(Equal([]string{"example.com", "foo.org"}))
+		Expect(skipped).To(Equal(2))
+	})
+
+	It("should return an error for malformed JSON", func() {
+		_, _, err := parseJSONDomains(`["example.com"`, "test")
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("parseDomainContent", func() {
+	It("should honor an explicit format over detection", func() {
+		domains, _, format, err := parseDomainContent("0.0.0.0 example.com\n", "test", config.DomainFormatPlain)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(format).To(Equal(config.DomainFormatPlain))
+		Expect(domains).To(Equal([]string{"0.0.0.0"}))
+	})
+
+	It("should report the detected format", func() {
+		domains, _, format, err := parseDomainContent("0.0.0.0 example.com\n", "test", config.DomainFormatAuto)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(format).To(Equal(config.DomainFormatHosts))
+		Expect(domains).To(Equal([]string{"example.com"}))
+	})
+
+	It("should reject unknown formats", func() {
+		_, _, _, err := parseDomainContent("example.com", "test", "yaml")
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("AddDnsRoutingGroups with domain list formats", func() {
+	var server *httptest.Server
+
+	BeforeEach(func() {
+		server = NewMockRouterServer(WithVersion("5.0.1"))
+		SetupTestConfig(server.URL)
+		Expect(Common.Auth()).To(Succeed())
+	})
+
+	AfterEach(func() {
+		CleanupTestConfig()
+		server.Close()
+	})
+
+	It("should load hosts, dnsmasq, AdGuard and JSON sources in one group", func() {
+		tmpDir := GinkgoT().TempDir()
+		files := map[string]string{
+			"hosts":        "0.0.0.0 hosts.example.com\n",
+			"dnsmasq.conf": "server=/dnsmasq.example.com/1.1.1.1\n",
+			"adguard.txt":  "||adguard.example.com^\n",
+			"list.json":    `["json.example.com"]`,
+		}
+		var domainFiles []string
+		for name, content := range files {
+			path := filepath.Join(tmpDir, name)
+			Expect(os.WriteFile(path, []byte(content), 0644)).To(Succeed())
+			domainFiles = append(domainFiles, path)
+		}
+
+		groups := []config.DnsRoutingGroup{{Name: "mixed", DomainFile: domainFiles, InterfaceID: "Wireguard0"}}
+		Expect(DnsRouting.AddDnsRoutingGroups(groups)).To(Succeed())
+
+		existing, err := DnsRouting.GetExistingDnsRoutingGroups()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(existing["mixed"]).To(ConsistOf("hosts.example.com", "dnsmasq.example.com", "adguard.example.com", "json.example.com"))
+	})
+
+	It("should use the group format for every source", func() {
+		tmpDir := GinkgoT().TempDir()
+		path := filepath.Join(tmpDir, "list.txt")
+		Expect(os.WriteFile(path, []byte("||example.com^\n"), 0644)).To(Succeed())
+
+		groups := []config.DnsRoutingGroup{{Name: "forced", DomainFile: []string{path}, InterfaceID: "Wireguard0", Format: config.DomainFormatJSON}}
+		err := DnsRouting.AddDnsRoutingGroups(groups)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("failed to parse JSON domain list"))
+	})
+
+	It("should prefer the format forced on a source over the group format", func() {
+		tmpDir := GinkgoT().TempDir()
+		adguardPath := filepath.Join(tmpDir, "filters.txt")
+		Expect(os.WriteFile(adguardPath, []byte("||adguard.example.com^\n"), 0644)).To(Succeed())
+		jsonPath := filepath.Join(tmpDir, "list.json")
+		Expect(os.WriteFile(jsonPath, []byte(`["json.example.com"]`), 0644)).To(Succeed())
+
+		groups := []config.DnsRoutingGroup{{
+			Name:          "forced",
+			DomainFile:    []string{adguardPath, jsonPath},
+			InterfaceID:   "Wireguard0",
+			Format:        config.DomainFormatJSON,
+			SourceFormats: map[string]string{adguardPath: config.DomainFormatAdGuard},
+		}}
+		Expect(DnsRouting.AddDnsRoutingGroups(groups)).To(Succeed())
+
+		existing, err := DnsRouting.GetExistingDnsRoutingGroups()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(existing["forced"]).To(ConsistOf("adguard.example.com", "json.example.com"))
+	})
+})
@@ -0,0 +1,122 @@
+package gokeenrestapi
+
+import (
+	"cmp"
+	"fmt"
+	"net/netip"
+	"sort"
+	"strings"
+
+	"github.com/noksa/gokeenapi/pkg/gokeenrestapimodels"
+)
+
+// Sort keys of known hosts
+const (
+	HostSortName     = "name"
+	HostSortMac      = "mac"
+	HostSortIP       = "ip"
+	HostSortLastSeen = "last-seen"
+	HostSortVendor   = "vendor"
+)
+
+// HostSortKeys lists supported sort keys of known hosts
+var HostSortKeys = []string{HostSortName, HostSortMac, HostSortIP, HostSortLastSeen, HostSortVendor}
+
+// HostInfo describes a known host for reports
+type HostInfo struct {
+	// Name is the user-assigned host name
+	Name string `json:"name"`
+	// Hostname is the host name reported by the device via DHCP or mDNS
+	Hostname string `json:"hostname,omitempty"`
+	// Mac is the MAC address of the host
+	Mac string `json:"mac"`
+	// IP is the current IP address of the host
+	IP string `json:"ip,omitempty"`
+	// Via is the interface the host is connected through
+	Via string `json:"via,omitempty"`
+	// Registered indicates if the host is registered in the router
+	Registered bool `json:"registered"`
+	// Online indicates if the host is currently connected
+	Online bool `json:"online"`
+	// RandomMac indicates a randomized (locally administered) MAC address
+	RandomMac bool `json:"randomMac"`
+	// Vendor is the organization the MAC prefix is assigned to, empty without an OUI database
+	Vendor string `json:"vendor,omitempty"`
+	// FirstSeen is the number of seconds since the host was first seen
+	FirstSeen int64 `json:"firstSeen"`
+	// LastSeen is the number of seconds since the host was last seen
+	LastSeen int64 `json:"lastSeen"`
+}
+
+// HostsInfo converts known hosts for reports, vendors are looked up in oui when it is not nil
+func HostsInfo(hosts []gokeenrestapimodels.Host, oui *OuiDB) []HostInfo {
+	infos := make([]HostInfo, 0, len(hosts))
+	for _, host := range hosts {
+		infos = append(infos, HostInfo{
+			Name:       host.Name,
+			Hostname:   host.Hostname,
+			Mac:        host.Mac,
+			IP:         host.IP,
+			Via:        host.Via,
+			Registered: host.Registered,
+			Online:     IsHostOnline(host),
+			RandomMac:  IsRandomizedMac(host.Mac),
+			Vendor:     oui.Vendor(host.Mac),
+			FirstSeen:  host.FirstSeen,
+			LastSeen:   host.LastSeen,
+		})
+	}
+	return infos
+}
+
+// SortHostsInfo sorts hosts in place by one of HostSortKeys. Hosts with equal keys are ordered by MAC.
+// Sorting by last-seen puts recently seen hosts first
+func SortHostsInfo(hosts []HostInfo, by string) error {
+	var compare func(a, b HostInfo) int
+	switch by {
+	case HostSortName:
+		compare = func(a, b HostInfo) int {
+			return strings.Compare(strings.ToLower(hostDisplayName(a)), strings.ToLower(hostDisplayName(b)))
+		}
+	case HostSortMac:
+		compare = func(a, b HostInfo) int { return 0 }
+	case HostSortIP:
+		compare = func(a, b HostInfo) int { return compareIPs(a.IP, b.IP) }
+	case HostSortLastSeen:
+		compare = func(a, b HostInfo) int { return cmp.Compare(a.LastSeen, b.LastSeen) }
+	case HostSortVendor:
+		compare = func(a, b HostInfo) int { return strings.Compare(strings.ToLower(a.Vendor), strings.ToLower(b.Vendor)) }
+	default:
+		return fmt.Errorf("unsupported sort key '%s': must be one of %s", by, strings.Join(HostSortKeys, ", "))
+	}
+	sort.SliceStable(hosts, func(i, j int) bool {
+		if c := compare(hosts[i], hosts[j]); c != 0 {
+			return c < 0
+		}
+		return strings.ToLower(hosts[i].Mac) < strings.ToLower(hosts[j].Mac)
+	})
+	return nil
+}
+
+// hostDisplayName returns the user-assigned name, or the device hostname when the host has no name
+func hostDisplayName(host HostInfo) string {
+	if host.Name != "" {
+		return host.Name
+	}
+	return host.Hostname
+}
+
+// compareIPs orders addresses numerically, hosts without a valid address go last
+func compareIPs(a, b string) int {
+	ipA, errA := netip.ParseAddr(a)
+	ipB, errB := netip.ParseAddr(b)
+	switch {
+	case errA != nil && errB != nil:
+		return 0
+	case errA != nil:
+		return 1
+	case errB != nil:
+		return -1
+	}
+	return ipA.Compare(ipB)
+}
@@ -0,0 +1,93 @@
+package gokeenrestapi
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/noksa/gokeenapi/pkg/gokeenrestapimodels"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+const testOuiCSV = `Registry,Assignment,Organization Name,Organization Address
+MA-L,001A2B,"Example Devices, Inc.",1 Example Street US
+MA-L,112233,Short Prefix Corp,2 Example Street US
+MA-M,1122334,Long Prefix Corp,3 Example Street US
+MA-L,XYZ,Broken Row,4 Example Street US
+`
+
+var _ = Describe("OuiDB", func() {
+	It("looks up vendors by the longest matching prefix", func() {
+		db, err := ParseOuiDB(strings.NewReader(testOuiCSV))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(db.Len()).To(Equal(3))
+
+		Expect(db.Vendor("00:1a:2b:dd:ee:ff")).To(Equal("Example Devices, Inc."))
+		Expect(db.Vendor("11-22-33-44-55-66")).To(Equal("Long Prefix Corp"))
+		Expect(db.Vendor("11:22:33:54:55:66")).To(Equal("Short Prefix Corp"))
+		Expect(db.Vendor("00:00:00:00:00:01")).To(BeEmpty())
+	})
+
+	It("does not look up randomized or malformed addresses", func() {
+		db, err := ParseOuiDB(strings.NewReader("Registry,Assignment,Organization Name\nMA-L,DAA119,Nobody\n"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(db.Vendor("da:a1:19:00:00:01")).To(BeEmpty())
+		Expect(db.Vendor("not-a-mac")).To(BeEmpty())
+
+		var noDb *OuiDB
+		Expect(noDb.Vendor("aa:bb:cc:dd:ee:ff")).To(BeEmpty())
+	})
+
+	It("rejects files without assignments", func() {
+		path := filepath.Join(GinkgoT().TempDir(), "oui.csv")
+		Expect(os.WriteFile(path, []byte("name,mac\nfoo,bar\n"), 0o600)).To(Succeed())
+
+		_, err := LoadOuiDB(path)
+		Expect(err).To(MatchError(ContainSubstring("no assignments found")))
+
+		_, err = LoadOuiDB(filepath.Join(GinkgoT().TempDir(), "missing.csv"))
+		Expect(err).To(MatchError(ContainSubstring("failed to open OUI database")))
+	})
+})
+
+var _ = Describe("HostsInfo", func() {
+	hosts := []gokeenrestapimodels.Host{
+		{Name: "nas", Mac: "00:1a:2b:00:00:01", IP: "192.168.1.10", Registered: true, Active: true, LastSeen: 0},
+		{Hostname: "Galaxy", Mac: "da:a1:19:00:00:02", IP: "192.168.1.9", LastSeen: 3600},
+		{Name: "Camera", Mac: "00:1a:2b:00:00:03", LastSeen: 60},
+	}
+
+	It("converts hosts and flags randomized MACs", func() {
+		db, err := ParseOuiDB(strings.NewReader(testOuiCSV))
+		Expect(err).NotTo(HaveOccurred())
+
+		infos := HostsInfo(hosts, db)
+		Expect(infos).To(HaveLen(3))
+		Expect(infos[0].Online).To(BeTrue())
+		Expect(infos[0].Vendor).To(Equal("Example Devices, Inc."))
+		Expect(infos[1].RandomMac).To(BeTrue())
+		Expect(infos[1].Vendor).To(BeEmpty())
+		Expect(infos[2].Online).To(BeFalse())
+	})
+
+	DescribeTable("SortHostsInfo",
+		func(by string, expected []string) {
+			infos := HostsInfo(hosts, nil)
+			Expect(SortHostsInfo(infos, by)).To(Succeed())
+			macs := make([]string, 0, len(infos))
+			for _, info := range infos {
+				macs = append(macs, info.Mac)
+			}
+			Expect(macs).To(Equal(expected))
+		},
+		Entry("by name falls back to hostname", HostSortName, []string{"00:1a:2b:00:00:03", "da:a1:19:00:00:02", "00:1a:2b:00:00:01"}),
+		Entry("by mac", HostSortMac, []string{"00:1a:2b:00:00:01", "00:1a:2b:00:00:03", "da:a1:19:00:00:02"}),
+		Entry("by ip numerically, missing last", HostSortIP, []string{"da:a1:19:00:00:02", "00:1a:2b:00:00:01", "00:1a:2b:00:00:03"}),
+		Entry("by last seen, recent first", HostSortLastSeen, []string{"00:1a:2b:00:00:01", "00:1a:2b:00:00:03", "da:a1:19:00:00:02"}),
+	)
+
+	It("rejects unknown sort keys", func() {
+		Expect(SortHostsInfo(nil, "age")).To(MatchError(ContainSubstring("unsupported sort key 'age'")))
+	})
+})
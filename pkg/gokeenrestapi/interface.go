@@ -104,23 +104,6 @@ func (*keeneticInterface) GetInterfacesViaRciShowScInterfaces(ids ...string) (ma
 	return realInterfaces, nil
 }
 
-// PrintInfoAboutInterfaces displays formatted information about interfaces to the console
-func (*keeneticInterface) PrintInfoAboutInterfaces(interfaces map[string]gokeenrestapimodels.RciShowInterface) {
-	for k, interfaceDetails := range interfaces {
-		gokeenlog.Infof("Interface '%v':", color.BlueString(k))
-		gokeenlog.InfoSubStepf("Id: %v", color.CyanString(interfaceDetails.Id))
-		gokeenlog.InfoSubStepf("Type: %v", color.CyanString(interfaceDetails.Type))
-		if interfaceDetails.Description != "" {
-			gokeenlog.InfoSubStepf("Description: %v", color.CyanString(interfaceDetails.Description))
-		}
-		if interfaceDetails.Address != "" {
-			gokeenlog.InfoSubStepf("Address: %v", color.CyanString(interfaceDetails.Address))
-		}
-		gokeenlog.Infof("")
-	}
-
-}
-
 // WaitUntilInterfaceIsUp waits up to 60 seconds for an interface to become fully operational.
 // Accepts context for cancellation; derives from Background if needed.
 // For context-aware use WaitUntilInterfaceIsUpContext.
@@ -131,29 +114,74 @@ func (*keeneticInterface) WaitUntilInterfaceIsUp(interfaceId string) error {
 // WaitUntilInterfaceIsUpContext waits until interface is up or ctx cancelled.
 // Replaces busy-wait sleep with ticker + ctx select, returns ctx.Err() on cancel.
 func (*keeneticInterface) WaitUntilInterfaceIsUpContext(ctx context.Context, interfaceId string) error {
-	err := gokeenspinner.WrapWithSpinner(fmt.Sprintf("Waiting 60s until %v interface is up, connected to peers and working", interfaceId), func() error {
+	return Interface.WaitUntilInterfaceIsUpWithTimeout(ctx, interfaceId, 60*time.Second)
+}
+
+// WaitUntilInterfaceIsUpWithTimeout waits up to timeout until interface is up or ctx cancelled
+func (*keeneticInterface) WaitUntilInterfaceIsUpWithTimeout(ctx context.Context, interfaceId string, timeout time.Duration) error {
+	return waitUntilInterface(ctx, interfaceId, timeout,
+		fmt.Sprintf("Waiting %v until %v interface is up, connected to peers and working", timeout, interfaceId),
+		fmt.Errorf("looks like interface %v is still not up. Please check The keenetic web-interface", interfaceId),
+		isInterfaceUp)
+}
+
+// WaitUntilInterfaceReconnectedContext waits until the interface is up and any of its WireGuard peers
+// completed a handshake after since. Unlike WaitUntilInterfaceIsUpContext it does not trust a link
+// that stayed up from before a configuration change
+func (*keeneticInterface) WaitUntilInterfaceReconnectedContext(ctx context.Context, interfaceId string, since time.Time, timeout time.Duration) error {
+	return waitUntilInterface(ctx, interfaceId, timeout,
+		fmt.Sprintf("Waiting %v for a new handshake on %v", timeout, color.CyanString(interfaceId)),
+		fmt.Errorf("no handshake on %s within %v", interfaceId, timeout),
+		func(iface gokeenrestapimodels.RciShowInterface) bool {
+			return isInterfaceUp(iface) && hasHandshakeSince(iface, since)
+		})
+}
+
+// waitUntilInterface polls the interface until ready reports true, returns timeoutErr after timeout or ctx.Err() on cancel
+func waitUntilInterface(ctx context.Context, interfaceId string, timeout time.Duration, message string, timeoutErr error, ready func(gokeenrestapimodels.RciShowInterface) bool) error {
+	return gokeenspinner.WrapWithSpinner(message, func() error {
 		ticker := time.NewTicker(500 * time.Millisecond)
 		defer ticker.Stop()
-		deadline := time.NewTimer(60 * time.Second)
+		deadline := time.NewTimer(timeout)
 		defer deadline.Stop()
 		for {
 			select {
 			case <-ctx.Done():
 				return ctx.Err()
 			case <-deadline.C:
-				return fmt.Errorf("looks like interface %v is still not up. Please check The keenetic web-interface", interfaceId)
+				return timeoutErr
 			case <-ticker.C:
 				myInterface, err := Interface.GetInterfaceViaRciShowInterfaces(interfaceId)
 				if err != nil {
 					return err
 				}
-				if myInterface.Connected == StateConnected && myInterface.Link == StateUp && myInterface.State == StateUp {
+				if ready(myInterface) {
 					return nil
 				}
 			}
 		}
 	})
-	return err
+}
+
+// isInterfaceUp reports whether the interface is enabled, has a link and is connected
+func isInterfaceUp(iface gokeenrestapimodels.RciShowInterface) bool {
+	return iface.Connected == StateConnected && iface.Link == StateUp && iface.State == StateUp
+}
+
+// hasHandshakeSince reports whether any WireGuard peer of the interface completed a handshake after since.
+// The router reports the handshake age in whole seconds, so the real age may be up to a second more.
+// A handshake only counts when even that upper bound is newer than since
+func hasHandshakeSince(iface gokeenrestapimodels.RciShowInterface, since time.Time) bool {
+	if iface.Wireguard == nil {
+		return false
+	}
+	elapsed := time.Since(since)
+	for _, peer := range iface.Wireguard.Peer {
+		if peer.LastHandshake >= 0 && time.Duration(peer.LastHandshake+1)*time.Second <= elapsed {
+			return true
+		}
+	}
+	return false
 }
 
 // UpInterface brings the specified interface up (enables it)
@@ -173,6 +201,57 @@ func (*keeneticInterface) UpInterface(interfaceId string) error {
 	return err
 }
 
+// DownInterface brings the specified interface down (disables it)
+func (*keeneticInterface) DownInterface(interfaceId string) error {
+	var parseSlice []gokeenrestapimodels.ParseRequest
+	parseSlice = append(parseSlice, gokeenrestapimodels.ParseRequest{
+		Parse: fmt.Sprintf("interface %v down", interfaceId),
+	})
+	var parseResponse []gokeenrestapimodels.ParseResponse
+	err := gokeenspinner.WrapWithSpinner(fmt.Sprintf("Bringing %v interface down", color.CyanString(interfaceId)), func() error {
+		var executeErr error
+		parseSlice = Common.EnsureSaveConfigAtEnd(parseSlice)
+		parseResponse, executeErr = Common.ExecutePostParse(parseSlice...)
+		return executeErr
+	})
+	gokeenlog.PrintParseResponse(parseResponse)
+	return err
+}
+
+// RestartInterface brings the specified interface down and up again in one request.
+// The configuration is not saved, the interface stays enabled in the startup config
+func (*keeneticInterface) RestartInterface(interfaceId string) error {
+	parseSlice := []gokeenrestapimodels.ParseRequest{
+		{Parse: fmt.Sprintf("interface %v down", interfaceId)},
+		{Parse: fmt.Sprintf("interface %v up", interfaceId)},
+	}
+	var parseResponse []gokeenrestapimodels.ParseResponse
+	err := gokeenspinner.WrapWithSpinner(fmt.Sprintf("Restarting %v interface", color.CyanString(interfaceId)), func() error {
+		var executeErr error
+		parseResponse, executeErr = Common.ExecutePostParse(parseSlice...)
+		return executeErr
+	})
+	gokeenlog.PrintParseResponse(parseResponse)
+	return err
+}
+
+// DeleteInterface removes the specified interface from the router
+func (*keeneticInterface) DeleteInterface(interfaceId string) error {
+	var parseSlice []gokeenrestapimodels.ParseRequest
+	parseSlice = append(parseSlice, gokeenrestapimodels.ParseRequest{
+		Parse: fmt.Sprintf("no interface %v", interfaceId),
+	})
+	var parseResponse []gokeenrestapimodels.ParseResponse
+	err := gokeenspinner.WrapWithSpinner(fmt.Sprintf("Deleting %v interface", color.CyanString(interfaceId)), func() error {
+		var executeErr error
+		parseSlice = Common.EnsureSaveConfigAtEnd(parseSlice)
+		parseResponse, executeErr = Common.ExecutePostParse(parseSlice...)
+		return executeErr
+	})
+	gokeenlog.PrintParseResponse(parseResponse)
+	return err
+}
+
 // SetGlobalIpInInterface configures global IP routing for the specified interface
 func (*keeneticInterface) SetGlobalIpInInterface(interfaceId string, global bool) error {
 	var parseSlice []gokeenrestapimodels.ParseRequest
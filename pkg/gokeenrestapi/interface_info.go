@@ -0,0 +1,71 @@
+package gokeenrestapi
+
+import (
+	"sort"
+	"strconv"
+)
+
+// InterfaceInfo combines the runtime state of an interface with its system configuration
+type InterfaceInfo struct {
+	// Id is the unique interface identifier (e.g., "Wireguard0", "ISP")
+	Id string `json:"id" yaml:"id"`
+	// Type indicates the interface type (e.g., "Wireguard", "PPPoE", "Bridge")
+	Type string `json:"type" yaml:"type"`
+	// Description is the user-friendly interface name
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	// Address is the IP address currently assigned to the interface
+	Address string `json:"address,omitempty" yaml:"address,omitempty"`
+	// Connected indicates connection status (e.g., "yes", "no")
+	Connected string `json:"connected" yaml:"connected"`
+	// Link indicates physical link status (e.g., "up", "down")
+	Link string `json:"link" yaml:"link"`
+	// State indicates administrative state (e.g., "up", "down")
+	State string `json:"state" yaml:"state"`
+	// DefaultGw indicates if this interface is the default gateway
+	DefaultGw bool `json:"defaultGw" yaml:"defaultGw"`
+	// Addresses are the configured addresses in CIDR notation
+	Addresses []string `json:"addresses,omitempty" yaml:"addresses,omitempty"`
+	// Mtu is the configured MTU, zero when the router uses its default
+	Mtu int `json:"mtu,omitempty" yaml:"mtu,omitempty"`
+}
+
+// GetInterfacesInfo retrieves interfaces with optional type filtering and enriches them
+// with addresses and MTU from the system configuration. The runtime state is always fetched
+// fresh, results are sorted by interface ID
+func (*keeneticInterface) GetInterfacesInfo(interfaceTypes ...string) ([]InterfaceInfo, error) {
+	interfaces, err := Interface.GetInterfacesViaRciShowInterfaces(false, interfaceTypes...)
+	if err != nil {
+		return nil, err
+	}
+	scInterfaces, err := Interface.GetInterfacesViaRciShowScInterfaces()
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]InterfaceInfo, 0, len(interfaces))
+	for id, iface := range interfaces {
+		info := InterfaceInfo{
+			Id:          id,
+			Type:        iface.Type,
+			Description: iface.Description,
+			Address:     iface.Address,
+			Connected:   iface.Connected,
+			Link:        iface.Link,
+			State:       iface.State,
+			DefaultGw:   iface.DefaultGw,
+		}
+		if scIface, ok := scInterfaces[id]; ok {
+			if scIface.IP.Address.Address != "" {
+				info.Addresses = append(info.Addresses, addressToCIDR(scIface.IP.Address))
+			}
+			// A malformed MTU is reported as the router default rather than failing the whole listing
+			info.Mtu, _ = strconv.Atoi(scIface.IP.Mtu)
+		}
+		infos = append(infos, info)
+	}
+
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].Id < infos[j].Id
+	})
+	return infos, nil
+}
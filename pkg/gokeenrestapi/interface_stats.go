@@ -0,0 +1,116 @@
+package gokeenrestapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/noksa/gokeenapi/internal/gokeenspinner"
+	"github.com/noksa/gokeenapi/pkg/gokeenrestapimodels"
+)
+
+// InterfaceStats describes traffic counters of an interface
+type InterfaceStats struct {
+	// InterfaceID is the unique interface identifier
+	InterfaceID string `json:"interfaceId"`
+	// Type indicates the interface type (e.g., "Wireguard", "PPPoE")
+	Type string `json:"type"`
+	// State indicates administrative state (e.g., "up", "down")
+	State string `json:"state"`
+	gokeenrestapimodels.RciShowInterfaceStat
+	// Error explains why the counters could not be read, the counters are zero then
+	Error string `json:"error,omitempty"`
+}
+
+// InterfaceRate is the traffic rate of an interface between two polls
+type InterfaceRate struct {
+	// RxBytesPerSecond is the receive rate
+	RxBytesPerSecond float64 `json:"rxBytesPerSecond"`
+	// TxBytesPerSecond is the send rate
+	TxBytesPerSecond float64 `json:"txBytesPerSecond"`
+}
+
+// RateSince computes the traffic rate from prev to s taken elapsed apart.
+// A counter that went backwards means the interface was restarted and yields a zero rate
+func (s InterfaceStats) RateSince(prev InterfaceStats, elapsed time.Duration) InterfaceRate {
+	if elapsed <= 0 {
+		return InterfaceRate{}
+	}
+	perSecond := func(current, previous int64) float64 {
+		if current < previous {
+			return 0
+		}
+		return float64(current-previous) / elapsed.Seconds()
+	}
+	return InterfaceRate{
+		RxBytesPerSecond: perSecond(s.RxBytes, prev.RxBytes),
+		TxBytesPerSecond: perSecond(s.TxBytes, prev.TxBytes),
+	}
+}
+
+// GetInterfaceStat retrieves traffic counters of a specific interface
+func (*keeneticInterface) GetInterfaceStat(interfaceId string) (gokeenrestapimodels.RciShowInterfaceStat, error) {
+	var stat gokeenrestapimodels.RciShowInterfaceStat
+	body, err := Common.ExecuteGetSubPath(fmt.Sprintf("/rci/show/interface/stat?name=%v", url.QueryEscape(interfaceId)))
+	if err != nil {
+		return stat, err
+	}
+	if err := json.Unmarshal(body, &stat); err != nil {
+		return stat, fmt.Errorf("failed to read statistics of %v interface: %w", interfaceId, err)
+	}
+	return stat, nil
+}
+
+// GetInterfacesStats retrieves traffic counters of interfaces with optional type filtering.
+// Without interfaceIds all interfaces of the given types are read. Interfaces whose counters
+// can't be read are returned with Error set. Results are sorted by interface ID
+func (*keeneticInterface) GetInterfacesStats(interfaceTypes []string, interfaceIds ...string) ([]InterfaceStats, error) {
+	interfaces, err := Interface.GetInterfacesViaRciShowInterfaces(false, interfaceTypes...)
+	if err != nil {
+		return nil, err
+	}
+	for _, interfaceId := range interfaceIds {
+		if _, ok := interfaces[interfaceId]; !ok {
+			if len(interfaceTypes) > 0 {
+				return nil, fmt.Errorf("keenetic router doesn't have interface with id '%v' of type %v", interfaceId, strings.Join(interfaceTypes, ", "))
+			}
+			return nil, fmt.Errorf("keenetic router doesn't have interface with id '%v'. Verify that you specified correct ID", interfaceId)
+		}
+	}
+
+	var stats []InterfaceStats
+	err = gokeenspinner.WrapWithSpinner(fmt.Sprintf("Fetching %v", color.CyanString("interface statistics")), func() error {
+		for id, iface := range interfaces {
+			if len(interfaceIds) > 0 && !slices.Contains(interfaceIds, id) {
+				continue
+			}
+			entry := InterfaceStats{
+				InterfaceID: id,
+				Type:        iface.Type,
+				State:       iface.State,
+			}
+			// Some interfaces have no statistics, one of them must not hide the others
+			stat, err := Interface.GetInterfaceStat(id)
+			if err != nil {
+				entry.Error = err.Error()
+			} else {
+				entry.RciShowInterfaceStat = stat
+			}
+			stats = append(stats, entry)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].InterfaceID < stats[j].InterfaceID
+	})
+	return stats, nil
+}
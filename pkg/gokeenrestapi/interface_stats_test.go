@@ -0,0 +1,98 @@
+package gokeenrestapi
+
+import (
+	"net/http/httptest"
+	"time"
+
+	"github.com/noksa/gokeenapi/pkg/gokeenrestapimodels"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Interface statistics", func() {
+	var server *httptest.Server
+
+	BeforeEach(func() {
+		server = SetupMockRouterForTest(
+			WithInterfaces([]MockInterface{
+				{
+					ID: "Wireguard0", Type: InterfaceTypeWireguard,
+					Connected: StateConnected, Link: StateUp, State: StateUp,
+					RxBytes: 4096, TxBytes: 2048, RxPackets: 40, TxPackets: 20, RxErrors: 1,
+				},
+				{
+					ID: "ISP", Type: InterfaceTypePPPoE,
+					Connected: StateConnected, Link: StateUp, State: StateUp,
+					RxBytes: 100, TxBytes: 200,
+				},
+			}),
+		)
+	})
+
+	AfterEach(func() {
+		if server != nil {
+			server.Close()
+		}
+	})
+
+	It("reads counters of all interfaces sorted by ID", func() {
+		stats, err := Interface.GetInterfacesStats(nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(stats).To(HaveLen(2))
+		Expect(stats[0].InterfaceID).To(Equal("ISP"))
+		Expect(stats[1]).To(Equal(InterfaceStats{
+			InterfaceID: "Wireguard0", Type: InterfaceTypeWireguard, State: StateUp,
+			RciShowInterfaceStat: gokeenrestapimodels.RciShowInterfaceStat{
+				RxBytes: 4096, TxBytes: 2048, RxPackets: 40, TxPackets: 20, RxErrors: 1,
+			},
+		}))
+	})
+
+	It("filters by type and interface ID", func() {
+		stats, err := Interface.GetInterfacesStats([]string{InterfaceTypeWireguard})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(stats).To(HaveLen(1))
+		Expect(stats[0].InterfaceID).To(Equal("Wireguard0"))
+
+		stats, err = Interface.GetInterfacesStats(nil, "ISP")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(stats).To(HaveLen(1))
+		Expect(stats[0].InterfaceID).To(Equal("ISP"))
+	})
+
+	It("fails for unknown interfaces", func() {
+		_, err := Interface.GetInterfacesStats(nil, "Wireguard9")
+		Expect(err).To(MatchError(ContainSubstring("doesn't have interface with id 'Wireguard9'")))
+	})
+
+	It("marks interfaces whose counters can't be read instead of failing", func() {
+		server.Close()
+		server = SetupMockRouterForTest(
+			WithInterfaces([]MockInterface{
+				{ID: "ISP", Type: InterfaceTypePPPoE, State: StateUp, RxBytes: 100},
+				{ID: "Wireguard0", Type: InterfaceTypeWireguard, State: StateUp, NoStat: true},
+			}),
+		)
+
+		stats, err := Interface.GetInterfacesStats(nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(stats).To(HaveLen(2))
+		Expect(stats[0].Error).To(BeEmpty())
+		Expect(stats[0].RxBytes).To(Equal(int64(100)))
+		Expect(stats[1].InterfaceID).To(Equal("Wireguard0"))
+		Expect(stats[1].Error).NotTo(BeEmpty())
+	})
+
+	DescribeTable("RateSince",
+		func(prevRx, curRx int64, elapsed time.Duration, expected float64) {
+			current := InterfaceStats{RciShowInterfaceStat: gokeenrestapimodels.RciShowInterfaceStat{RxBytes: curRx}}
+			previous := InterfaceStats{RciShowInterfaceStat: gokeenrestapimodels.RciShowInterfaceStat{RxBytes: prevRx}}
+			rate := current.RateSince(previous, elapsed)
+			Expect(rate.RxBytesPerSecond).To(Equal(expected))
+		},
+		Entry("steady traffic", int64(1000), int64(6000), 5*time.Second, 1000.0),
+		Entry("no traffic", int64(1000), int64(1000), 5*time.Second, 0.0),
+		Entry("counter reset", int64(6000), int64(1000), 5*time.Second, 0.0),
+		Entry("no elapsed time", int64(1000), int64(6000), time.Duration(0), 0.0),
+	)
+})
@@ -114,3 +114,20 @@ var _ = Describe("WaitUntilInterfaceIsUpContext", func() {
 		Expect(Interface.WaitUntilInterfaceIsUp("Wireguard0")).To(Succeed())
 	})
 })
+
+var _ = DescribeTable("hasHandshakeSince",
+	func(lastHandshake int64, elapsed time.Duration, expected bool) {
+		iface := gokeenrestapimodels.RciShowInterface{
+			Wireguard: &gokeenrestapimodels.RciShowInterfaceWireguard{
+				Peer: []gokeenrestapimodels.RciShowInterfaceWireguardPeer{{LastHandshake: lastHandshake}},
+			},
+		}
+		Expect(hasHandshakeSince(iface, time.Now().Add(-elapsed))).To(Equal(expected))
+	},
+	Entry("no handshake yet", int64(-1), time.Minute, false),
+	Entry("handshake well after the start", int64(5), 30*time.Second, true),
+	Entry("handshake before the start", int64(40), 30*time.Second, false),
+	Entry("truncated age within the same second as the start", int64(5), 5500*time.Millisecond, false),
+	Entry("truncated age a full second after the start", int64(5), 6500*time.Millisecond, true),
+	Entry("handshake reported as just now right after the start", int64(0), 500*time.Millisecond, false),
+)
@@ -161,6 +161,37 @@ func (*keeneticIp) DeleteRoutes(routes []gokeenrestapimodels.RciIpRoute, interfa
 	})
 }
 
+// MoveRoutes re-points static routes of fromInterface to toInterface
+func (*keeneticIp) MoveRoutes(routes []gokeenrestapimodels.RciIpRoute, fromInterface, toInterface string) error {
+	var parseSlice []gokeenrestapimodels.ParseRequest
+	for _, route := range routes {
+		if route.Interface != fromInterface {
+			continue
+		}
+		var ip string
+		if route.Host != "" {
+			ip = route.Host
+		}
+		if route.Network != "" {
+			ip = fmt.Sprintf("%s %s", route.Network, route.Mask)
+		}
+		parseSlice = append(parseSlice,
+			gokeenrestapimodels.ParseRequest{Parse: fmt.Sprintf("no ip route %v %v", ip, fromInterface)},
+			gokeenrestapimodels.ParseRequest{Parse: fmt.Sprintf("ip route %v %v auto", ip, toInterface)},
+		)
+	}
+	if len(parseSlice) == 0 {
+		gokeenlog.Info("No need to move static routes")
+		return nil
+	}
+	gokeencache.SetRciShowIpRoute(nil)
+	return gokeenspinner.WrapWithSpinner(fmt.Sprintf("Moving %v static routes from %v to %v interface", color.BlueString("%v", len(parseSlice)/2), fromInterface, toInterface), func() error {
+		parseSlice = Common.EnsureSaveConfigAtEnd(parseSlice)
+		_, err := Common.ExecutePostParse(parseSlice...)
+		return err
+	})
+}
+
 // AddDnsRecords adds static DNS records to the router configuration
 func (*keeneticIp) AddDnsRecords(domains []string) error {
 	var parseSlice []gokeenrestapimodels.ParseRequest
@@ -0,0 +1,94 @@
+package gokeenrestapi
+
+import (
+	"net"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/noksa/gokeenapi/pkg/gokeenrestapimodels"
+)
+
+// HostFilter selects known hosts. Only set conditions are checked and all of them must match
+type HostFilter struct {
+	// NamePattern matches the user-assigned host name
+	NamePattern *regexp.Regexp
+	// MacPattern matches the host MAC address
+	MacPattern *regexp.Regexp
+	// Offline selects hosts that are not online
+	Offline bool
+	// Unregistered selects hosts that are not registered in the router
+	Unregistered bool
+	// NotSeenFor selects offline hosts last seen at least this long ago
+	NotSeenFor time.Duration
+	// RandomMac selects hosts with a randomized (locally administered) MAC address
+	RandomMac bool
+	// Via selects hosts connected via this interface
+	Via string
+	// IPIn selects hosts with an IP address inside this network
+	IPIn *net.IPNet
+}
+
+// IsEmpty reports whether no condition is set, an empty filter matches every host
+func (f HostFilter) IsEmpty() bool {
+	return f.NamePattern == nil && f.MacPattern == nil && !f.Offline && !f.Unregistered &&
+		f.NotSeenFor == 0 && !f.RandomMac && f.Via == "" && f.IPIn == nil
+}
+
+// Match reports whether the host satisfies all set conditions
+func (f HostFilter) Match(host gokeenrestapimodels.Host) bool {
+	if f.NamePattern != nil && !f.NamePattern.MatchString(host.Name) {
+		return false
+	}
+	if f.MacPattern != nil && !f.MacPattern.MatchString(host.Mac) {
+		return false
+	}
+	if f.Offline && IsHostOnline(host) {
+		return false
+	}
+	if f.Unregistered && host.Registered {
+		return false
+	}
+	if f.NotSeenFor > 0 && (IsHostOnline(host) || time.Duration(host.LastSeen)*time.Second < f.NotSeenFor) {
+		return false
+	}
+	if f.RandomMac && !IsRandomizedMac(host.Mac) {
+		return false
+	}
+	if f.Via != "" && !strings.EqualFold(host.Via, f.Via) {
+		return false
+	}
+	if f.IPIn != nil {
+		ip := net.ParseIP(host.IP)
+		if ip == nil || !f.IPIn.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// FilterHosts returns hosts matching the filter in their original order
+func FilterHosts(hosts []gokeenrestapimodels.Host, filter HostFilter) []gokeenrestapimodels.Host {
+	var matched []gokeenrestapimodels.Host
+	for _, host := range hosts {
+		if filter.Match(host) {
+			matched = append(matched, host)
+		}
+	}
+	return matched
+}
+
+// IsHostOnline reports whether the host is currently connected to the router
+func IsHostOnline(host gokeenrestapimodels.Host) bool {
+	return host.Active || host.Link == StateUp
+}
+
+// IsRandomizedMac reports whether the MAC address is locally administered, as phones use for
+// per-network randomized addresses. Malformed addresses are not considered randomized
+func IsRandomizedMac(mac string) bool {
+	hw, err := net.ParseMAC(mac)
+	if err != nil || len(hw) == 0 {
+		return false
+	}
+	return hw[0]&0x02 != 0
+}
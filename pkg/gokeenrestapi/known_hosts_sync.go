@@ -0,0 +1,197 @@
+package gokeenrestapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/noksa/gokeenapi/internal/gokeenlog"
+	"github.com/noksa/gokeenapi/internal/gokeenspinner"
+	"github.com/noksa/gokeenapi/pkg/config"
+	"github.com/noksa/gokeenapi/pkg/gokeenrestapimodels"
+)
+
+// Actions of a known host change
+const (
+	KnownHostActionAdd    = "add"
+	KnownHostActionUpdate = "update"
+	KnownHostActionPrune  = "prune"
+)
+
+// KnownHostChange describes what has to be done on the router to bring a host in line with the inventory
+type KnownHostChange struct {
+	// Mac is the MAC address of the host
+	Mac string `json:"mac"`
+	// Name is the name of the host, the current router name for pruned hosts
+	Name string `json:"name"`
+	// Action is one of KnownHostActionAdd, KnownHostActionUpdate or KnownHostActionPrune
+	Action string `json:"action"`
+	// Details are human-readable descriptions of the changed settings
+	Details []string `json:"details,omitempty"`
+	// Commands are the RCI commands applying the change
+	Commands []string `json:"commands"`
+}
+
+// GetStaticLeases retrieves static DHCP leases keyed by lowercase MAC address
+func (*keeneticIp) GetStaticLeases() (map[string]string, error) {
+	var leases []gokeenrestapimodels.RciIpDhcpHost
+	body, err := Common.ExecuteGetSubPath("/rci/ip/dhcp/host")
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(body, &leases); err != nil {
+		return nil, fmt.Errorf("failed to read static DHCP leases: %w", err)
+	}
+	result := make(map[string]string, len(leases))
+	for _, lease := range leases {
+		result[strings.ToLower(lease.Mac)] = lease.IP
+	}
+	return result, nil
+}
+
+// GetHostPolicies retrieves IP connection policies assigned to hosts keyed by lowercase MAC address
+func (*keeneticIp) GetHostPolicies() (map[string]string, error) {
+	var hosts []gokeenrestapimodels.RciIpHotspotHost
+	body, err := Common.ExecuteGetSubPath("/rci/ip/hotspot/host")
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(body, &hosts); err != nil {
+		return nil, fmt.Errorf("failed to read host policies: %w", err)
+	}
+	result := make(map[string]string, len(hosts))
+	for _, host := range hosts {
+		if host.Policy != "" {
+			result[strings.ToLower(host.Mac)] = host.Policy
+		}
+	}
+	return result, nil
+}
+
+// PlanKnownHosts compares the inventory with the router and returns changes needed to sync it.
+// With prune, registered hosts missing from the inventory are removed
+func (*keeneticIp) PlanKnownHosts(hosts []config.KnownHost, prune bool) ([]KnownHostChange, error) {
+	if err := config.ValidateKnownHosts(hosts); err != nil {
+		return nil, err
+	}
+	hotspot, err := Ip.GetAllHotspots()
+	if err != nil {
+		return nil, err
+	}
+	var leases, policies map[string]string
+	err = gokeenspinner.WrapWithSpinner("Fetching static leases and policies", func() error {
+		var err error
+		if leases, err = Ip.GetStaticLeases(); err != nil {
+			return err
+		}
+		policies, err = Ip.GetHostPolicies()
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return planKnownHosts(hosts, hotspot.Host, leases, policies, prune), nil
+}
+
+// planKnownHosts computes inventory changes from the router state. Inventory hosts come first in
+// config order, pruned hosts follow in router order
+func planKnownHosts(desired []config.KnownHost, existing []gokeenrestapimodels.Host, leases, policies map[string]string, prune bool) []KnownHostChange {
+	existingByMac := make(map[string]gokeenrestapimodels.Host, len(existing))
+	for _, host := range existing {
+		existingByMac[normalizeMac(host.Mac)] = host
+	}
+
+	var changes []KnownHostChange
+	wanted := make(map[string]bool, len(desired))
+	for _, host := range desired {
+		mac := normalizeMac(host.Mac)
+		wanted[mac] = true
+		change := KnownHostChange{Mac: mac, Name: host.Name, Action: KnownHostActionUpdate}
+
+		current, found := existingByMac[mac]
+		switch {
+		case !found || !current.Registered:
+			change.Action = KnownHostActionAdd
+			change.Details = append(change.Details, fmt.Sprintf("register as %q", host.Name))
+			change.Commands = append(change.Commands, fmt.Sprintf("known host \"%v\" %v", host.Name, mac))
+		case current.Name != host.Name:
+			change.Details = append(change.Details, fmt.Sprintf("rename %q -> %q", current.Name, host.Name))
+			change.Commands = append(change.Commands, fmt.Sprintf("known host \"%v\" %v", host.Name, mac))
+		}
+		if host.IP != "" && leases[mac] != host.IP {
+			change.Details = append(change.Details, fmt.Sprintf("static IP %v -> %v", valueOrNone(leases[mac]), host.IP))
+			change.Commands = append(change.Commands, fmt.Sprintf("ip dhcp host %v %v", mac, host.IP))
+		}
+		if host.Policy != "" && policies[mac] != host.Policy {
+			change.Details = append(change.Details, fmt.Sprintf("policy %v -> %v", valueOrNone(policies[mac]), host.Policy))
+			change.Commands = append(change.Commands, fmt.Sprintf("ip hotspot host %v policy %v", mac, host.Policy))
+		}
+		if len(change.Commands) > 0 {
+			changes = append(changes, change)
+		}
+	}
+
+	if !prune {
+		return changes
+	}
+	for _, host := range existing {
+		mac := normalizeMac(host.Mac)
+		if !host.Registered || wanted[mac] {
+			continue
+		}
+		change := KnownHostChange{Mac: mac, Name: host.Name, Action: KnownHostActionPrune}
+		change.Commands = append(change.Commands, fmt.Sprintf("no known host \"%v\"", mac))
+		if leases[mac] != "" {
+			change.Details = append(change.Details, fmt.Sprintf("remove static IP %v", leases[mac]))
+			change.Commands = append(change.Commands, fmt.Sprintf("no ip dhcp host %v", mac))
+		}
+		if policies[mac] != "" {
+			change.Details = append(change.Details, fmt.Sprintf("remove policy %v", policies[mac]))
+			change.Commands = append(change.Commands, fmt.Sprintf("no ip hotspot host %v policy", mac))
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+// ApplyKnownHostChanges executes the commands of the changes and saves the configuration
+func (*keeneticIp) ApplyKnownHostChanges(changes []KnownHostChange) error {
+	var parseSlice []gokeenrestapimodels.ParseRequest
+	for _, change := range changes {
+		for _, command := range change.Commands {
+			parseSlice = append(parseSlice, gokeenrestapimodels.ParseRequest{Parse: command})
+		}
+	}
+	if len(parseSlice) == 0 {
+		gokeenlog.Info("Known hosts are already in sync")
+		return nil
+	}
+	parseSlice = Common.EnsureSaveConfigAtEnd(parseSlice)
+
+	var parseResponse []gokeenrestapimodels.ParseResponse
+	err := gokeenspinner.WrapWithSpinner(fmt.Sprintf("Syncing %v known hosts", color.CyanString("%d", len(changes))), func() error {
+		var executeErr error
+		parseResponse, executeErr = Common.ExecutePostParse(parseSlice...)
+		return executeErr
+	})
+	gokeenlog.PrintParseResponse(parseResponse)
+	return err
+}
+
+// normalizeMac returns the MAC address in lowercase colon-separated form, malformed addresses are only lowercased
+func normalizeMac(mac string) string {
+	hw, err := net.ParseMAC(mac)
+	if err != nil {
+		return strings.ToLower(mac)
+	}
+	return hw.String()
+}
+
+func valueOrNone(value string) string {
+	if value == "" {
+		return "none"
+	}
+	return value
+}
@@ -0,0 +1,118 @@
+package gokeenrestapi
+
+import (
+	"net/http/httptest"
+
+	"github.com/noksa/gokeenapi/pkg/config"
+	"github.com/noksa/gokeenapi/pkg/gokeenrestapimodels"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("planKnownHosts", func() {
+	existing := []gokeenrestapimodels.Host{
+		{Name: "nas", Mac: "aa:bb:cc:dd:ee:01", Registered: true},
+		{Mac: "aa:bb:cc:dd:ee:02"},
+		{Name: "old-tablet", Mac: "aa:bb:cc:dd:ee:03", Registered: true},
+	}
+	leases := map[string]string{"aa:bb:cc:dd:ee:01": "192.168.1.10", "aa:bb:cc:dd:ee:03": "192.168.1.30"}
+	policies := map[string]string{"aa:bb:cc:dd:ee:03": "Policy1"}
+
+	It("returns no changes when the router is in sync", func() {
+		desired := []config.KnownHost{{Mac: "AA:BB:CC:DD:EE:01", Name: "nas", IP: "192.168.1.10"}}
+		Expect(planKnownHosts(desired, existing, leases, policies, false)).To(BeEmpty())
+	})
+
+	It("registers, renames and assigns leases and policies", func() {
+		desired := []config.KnownHost{
+			{Mac: "aa:bb:cc:dd:ee:01", Name: "NAS", IP: "192.168.1.11"},
+			{Mac: "aa:bb:cc:dd:ee:02", Name: "TV", Policy: "Policy0"},
+			{Mac: "aa:bb:cc:dd:ee:04", Name: "Printer"},
+		}
+		changes := planKnownHosts(desired, existing, leases, policies, false)
+		Expect(changes).To(HaveLen(3))
+
+		Expect(changes[0].Action).To(Equal(KnownHostActionUpdate))
+		Expect(changes[0].Commands).To(Equal([]string{
+			`known host "NAS" aa:bb:cc:dd:ee:01`,
+			"ip dhcp host aa:bb:cc:dd:ee:01 192.168.1.11",
+		}))
+		Expect(changes[1].Action).To(Equal(KnownHostActionAdd))
+		Expect(changes[1].Commands).To(Equal([]string{
+			`known host "TV" aa:bb:cc:dd:ee:02`,
+			"ip hotspot host aa:bb:cc:dd:ee:02 policy Policy0",
+		}))
+		Expect(changes[2].Action).To(Equal(KnownHostActionAdd))
+		Expect(changes[2].Commands).To(Equal([]string{`known host "Printer" aa:bb:cc:dd:ee:04`}))
+	})
+
+	It("prunes only registered hosts missing from the inventory", func() {
+		desired := []config.KnownHost{{Mac: "aa:bb:cc:dd:ee:01", Name: "nas"}}
+		changes := planKnownHosts(desired, existing, leases, policies, true)
+		Expect(changes).To(HaveLen(1))
+		Expect(changes[0].Action).To(Equal(KnownHostActionPrune))
+		Expect(changes[0].Name).To(Equal("old-tablet"))
+		Expect(changes[0].Commands).To(Equal([]string{
+			`no known host "aa:bb:cc:dd:ee:03"`,
+			"no ip dhcp host aa:bb:cc:dd:ee:03",
+			"no ip hotspot host aa:bb:cc:dd:ee:03 policy",
+		}))
+	})
+})
+
+var _ = Describe("Known hosts sync", func() {
+	var server *httptest.Server
+
+	BeforeEach(func() {
+		server = SetupMockRouterForTest(WithHotspotDevices([]MockHost{
+			{Name: "nas", Mac: "aa:bb:cc:dd:ee:01", Registered: true, StaticIP: "192.168.1.10"},
+			{Mac: "aa:bb:cc:dd:ee:02", IP: "192.168.1.102"},
+			{Name: "old-tablet", Mac: "aa:bb:cc:dd:ee:03", Registered: true, Policy: "Policy1"},
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("reads static leases and host policies", func() {
+		leases, err := Ip.GetStaticLeases()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(leases).To(Equal(map[string]string{"aa:bb:cc:dd:ee:01": "192.168.1.10"}))
+
+		policies, err := Ip.GetHostPolicies()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(policies).To(Equal(map[string]string{"aa:bb:cc:dd:ee:03": "Policy1"}))
+	})
+
+	It("applies the plan and converges", func() {
+		hosts := []config.KnownHost{
+			{Mac: "aa:bb:cc:dd:ee:01", Name: "NAS"},
+			{Mac: "aa:bb:cc:dd:ee:02", Name: "TV", IP: "192.168.1.20", Policy: "Policy0"},
+		}
+		changes, err := Ip.PlanKnownHosts(hosts, true)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(changes).To(HaveLen(3))
+		Expect(Ip.ApplyKnownHostChanges(changes)).To(Succeed())
+
+		hotspot, err := Ip.GetAllHotspots()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(hotspot.Host).To(HaveLen(2))
+		Expect(hotspot.Host[0].Name).To(Equal("NAS"))
+		Expect(hotspot.Host[1].Name).To(Equal("TV"))
+		Expect(hotspot.Host[1].Registered).To(BeTrue())
+
+		leases, err := Ip.GetStaticLeases()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(leases).To(HaveKeyWithValue("aa:bb:cc:dd:ee:02", "192.168.1.20"))
+
+		changes, err = Ip.PlanKnownHosts(hosts, true)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(changes).To(BeEmpty())
+	})
+
+	It("rejects an invalid inventory", func() {
+		_, err := Ip.PlanKnownHosts([]config.KnownHost{{Mac: "bad", Name: "x"}}, false)
+		Expect(err).To(MatchError(ContainSubstring("invalid MAC address")))
+	})
+})
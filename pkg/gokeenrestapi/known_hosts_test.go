@@ -0,0 +1,65 @@
+package gokeenrestapi
+
+import (
+	"net"
+	"regexp"
+	"time"
+
+	"github.com/noksa/gokeenapi/pkg/gokeenrestapimodels"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("HostFilter", func() {
+	_, guestNet, _ := net.ParseCIDR("10.1.30.0/24")
+
+	host := gokeenrestapimodels.Host{
+		Name: "old-phone", Mac: "da:a1:19:00:00:01", IP: "10.1.30.7",
+		Via: "Bridge1", LastSeen: 7200,
+	}
+
+	DescribeTable("Match",
+		func(filter HostFilter, expected bool) {
+			Expect(filter.Match(host)).To(Equal(expected))
+		},
+		Entry("empty filter", HostFilter{}, true),
+		Entry("name pattern", HostFilter{NamePattern: regexp.MustCompile("phone")}, true),
+		Entry("mac pattern mismatch", HostFilter{MacPattern: regexp.MustCompile("^00:")}, false),
+		Entry("offline", HostFilter{Offline: true}, true),
+		Entry("unregistered", HostFilter{Unregistered: true}, true),
+		Entry("not seen for less", HostFilter{NotSeenFor: time.Hour}, true),
+		Entry("not seen for more", HostFilter{NotSeenFor: 3 * time.Hour}, false),
+		Entry("random mac", HostFilter{RandomMac: true}, true),
+		Entry("via is case-insensitive", HostFilter{Via: "bridge1"}, true),
+		Entry("via mismatch", HostFilter{Via: "Bridge0"}, false),
+		Entry("ip in network", HostFilter{IPIn: guestNet}, true),
+		Entry("all combined", HostFilter{Offline: true, RandomMac: true, Via: "Bridge1", IPIn: guestNet}, true),
+		Entry("one condition fails", HostFilter{Offline: true, Via: "Bridge0"}, false),
+	)
+
+	It("does not treat online hosts as offline or stale", func() {
+		online := host
+		online.Active = true
+		Expect(HostFilter{Offline: true}.Match(online)).To(BeFalse())
+		Expect(HostFilter{NotSeenFor: time.Minute}.Match(online)).To(BeFalse())
+
+		online = host
+		online.Link = StateUp
+		Expect(IsHostOnline(online)).To(BeTrue())
+	})
+
+	It("reports whether the filter is empty", func() {
+		Expect(HostFilter{}.IsEmpty()).To(BeTrue())
+		Expect(HostFilter{Via: "Bridge0"}.IsEmpty()).To(BeFalse())
+	})
+
+	DescribeTable("IsRandomizedMac",
+		func(mac string, expected bool) {
+			Expect(IsRandomizedMac(mac)).To(Equal(expected))
+		},
+		Entry("vendor assigned", "00:11:22:33:44:55", false),
+		Entry("locally administered", "da:a1:19:00:00:01", true),
+		Entry("locally administered, upper case", "DA:A1:19:00:00:01", true),
+		Entry("malformed", "not-a-mac", false),
+	)
+})
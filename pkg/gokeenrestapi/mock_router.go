@@ -22,6 +22,20 @@ type MockInterface struct {
 	Link        string
 	State       string
 	DefaultGw   bool
+	// Global is set by "interface <id> ip global auto" and cleared by "interface <id> no ip global"
+	Global bool
+	// LastHandshake is reported for every WireGuard peer, in seconds since the last handshake
+	LastHandshake int64
+	// RxBytes and TxBytes are reported for every WireGuard peer and in interface statistics
+	RxBytes int64
+	TxBytes int64
+	// RxPackets, TxPackets, RxErrors and TxErrors are reported in interface statistics
+	RxPackets int64
+	TxPackets int64
+	RxErrors  int64
+	TxErrors  int64
+	// NoStat makes the interface statistics request fail
+	NoStat bool
 }
 
 // MockRoute represents a static route in the mock router.
@@ -42,6 +56,13 @@ type MockHost struct {
 	Registered bool
 	Link       string
 	Via        string
+	Active     bool
+	// LastSeen is reported in seconds since the device was last seen
+	LastSeen int64
+	// StaticIP is the static DHCP lease of the device, empty when there is none
+	StaticIP string
+	// Policy is the IP connection policy assigned to the device, empty when there is none
+	Policy string
 }
 
 // MockSystemMode represents the system mode configuration.
@@ -53,6 +74,8 @@ type MockSystemMode struct {
 // MockIP represents IP configuration for SC interfaces.
 type MockIP struct {
 	Address string
+	Mask    string
+	Mtu     string
 }
 
 // MockAsc represents AWG (Amnezia WireGuard) configuration parameters.
@@ -95,8 +118,9 @@ type MockAllowedIP struct {
 
 // MockWireguard represents WireGuard configuration for SC interfaces.
 type MockWireguard struct {
-	Asc  MockAsc
-	Peer []MockPeer
+	PrivateKey string
+	Asc        MockAsc
+	Peer       []MockPeer
 }
 
 // MockScInterface represents the SC (system configuration) view of an interface.
@@ -119,6 +143,13 @@ type MockDnsProxyRoute struct {
 	Mode        string // typically "auto"
 }
 
+// MockPolicy represents an IP connection policy in the mock router.
+type MockPolicy struct {
+	ID          string
+	Description string
+	Interfaces  []string
+}
+
 // MockRouterState represents a snapshot of the mock router's state.
 type MockRouterState struct {
 	Interfaces       map[string]*MockInterface
@@ -129,6 +160,7 @@ type MockRouterState struct {
 	SystemMode       MockSystemMode
 	DnsRoutingGroups []MockDnsRoutingGroup
 	DnsProxyRoutes   []MockDnsProxyRoute
+	Policies         []MockPolicy
 }
 
 // MockRouter is a comprehensive mock implementation of the Keenetic router API.
@@ -146,11 +178,13 @@ type MockRouter struct {
 	systemMode          MockSystemMode
 	dnsRoutingGroups    []MockDnsRoutingGroup
 	dnsProxyRoutes      []MockDnsProxyRoute
+	policies            []MockPolicy
 	initialState        MockRouterState
 	staticRunningConfig []string
 	version             string
 	rciBodyOverride     []byte
 	components          map[string]gokeenrestapimodels.Component
+	peersUnreachable    bool
 }
 
 // MockRouterOption is a functional option for configuring the mock router.
@@ -227,6 +261,14 @@ func WithDnsRoutingGroups(groups []MockDnsRoutingGroup, routes []MockDnsProxyRou
 	}
 }
 
+// WithPolicies sets custom initial IP connection policies for the mock router.
+func WithPolicies(policies []MockPolicy) MockRouterOption {
+	return func(m *MockRouter) {
+		m.policies = make([]MockPolicy, len(policies))
+		copy(m.policies, policies)
+	}
+}
+
 // WithVersion sets custom firmware version for the mock router.
 func WithVersion(version string) MockRouterOption {
 	return func(m *MockRouter) {
@@ -251,6 +293,14 @@ func WithComponents(components map[string]gokeenrestapimodels.Component) MockRou
 	}
 }
 
+// WithPeersUnreachable makes interfaces brought up by "interface <id> up" never connect to their peers,
+// like a tunnel whose server does not know the router yet.
+func WithPeersUnreachable() MockRouterOption {
+	return func(m *MockRouter) {
+		m.peersUnreachable = true
+	}
+}
+
 // NewMockRouter creates a new mock router with default state and optional configuration.
 func NewMockRouter(opts ...MockRouterOption) *MockRouter {
 	m := &MockRouter{
@@ -261,6 +311,7 @@ func NewMockRouter(opts ...MockRouterOption) *MockRouter {
 		hotspotDevices:   []MockHost{},
 		dnsRoutingGroups: []MockDnsRoutingGroup{},
 		dnsProxyRoutes:   []MockDnsProxyRoute{},
+		policies:         []MockPolicy{},
 		authRealm:        "test-realm",
 		authChallenge:    "test-challenge",
 		sessionCookie:    "session=test-session",
@@ -325,6 +376,7 @@ func (m *MockRouter) captureState() MockRouterState {
 		SystemMode:       m.systemMode,
 		DnsRoutingGroups: make([]MockDnsRoutingGroup, len(m.dnsRoutingGroups)),
 		DnsProxyRoutes:   make([]MockDnsProxyRoute, len(m.dnsProxyRoutes)),
+		Policies:         make([]MockPolicy, len(m.policies)),
 	}
 
 	for id, iface := range m.interfaces {
@@ -340,6 +392,7 @@ func (m *MockRouter) captureState() MockRouterState {
 	copy(state.HotspotDevices, m.hotspotDevices)
 	copy(state.DnsRoutingGroups, m.dnsRoutingGroups)
 	copy(state.DnsProxyRoutes, m.dnsProxyRoutes)
+	copy(state.Policies, m.policies)
 
 	return state
 }
@@ -377,6 +430,8 @@ func (m *MockRouter) ResetState() {
 	copy(m.dnsRoutingGroups, m.initialState.DnsRoutingGroups)
 	m.dnsProxyRoutes = make([]MockDnsProxyRoute, len(m.initialState.DnsProxyRoutes))
 	copy(m.dnsProxyRoutes, m.initialState.DnsProxyRoutes)
+	m.policies = make([]MockPolicy, len(m.initialState.Policies))
+	copy(m.policies, m.initialState.Policies)
 }
 
 // NewMockRouterServer creates an httptest.Server with the mock router.
@@ -389,6 +444,7 @@ func NewMockRouterServer(opts ...MockRouterOption) *httptest.Server {
 	mux.HandleFunc("/rci/show/version", m.handleVersion)
 	mux.HandleFunc("/rci/show/interface", m.handleInterfaces)
 	mux.HandleFunc("/rci/show/interface/", m.handleInterface)
+	mux.HandleFunc("/rci/show/interface/stat", m.handleInterfaceStat)
 	mux.HandleFunc("/rci/show/sc/interface", m.handleScInterfaces)
 	mux.HandleFunc("/rci/show/sc/interface/", m.handleScInterface)
 	mux.HandleFunc("/rci/ip/route", m.handleRoutes)
@@ -398,8 +454,12 @@ func NewMockRouterServer(opts ...MockRouterOption) *httptest.Server {
 	mux.HandleFunc("/rci/dns-proxy/route", m.handleDnsProxyRoute)
 	mux.HandleFunc("/rci/components/list", m.handleComponentsList)
 	mux.HandleFunc("/rci/show/ip/hotspot", m.handleHotspot)
+	mux.HandleFunc("/rci/ip/dhcp/host", m.handleDhcpHosts)
+	mux.HandleFunc("/rci/ip/hotspot/host", m.handleHotspotHosts)
+	mux.HandleFunc("/rci/ip/policy", m.handleIpPolicies)
 	mux.HandleFunc("/rci/show/running-config", m.handleRunningConfig)
 	mux.HandleFunc("/rci/show/system/mode", m.handleSystemMode)
+	mux.HandleFunc("/rci/interface/wireguard/import", m.handleWireguardImport)
 	mux.HandleFunc("/rci/", m.handleParse)
 
 	return httptest.NewServer(mux)
@@ -229,6 +229,9 @@ func (m *MockRouter) routeParseCommand(command string) gokeenrestapimodels.Parse
 	case tokens[0] == "dns-proxy" && len(tokens) >= 5:
 		return m.dispatchDnsProxyCommand(tokens)
 
+	case tokens[0] == "known" && len(tokens) >= 4 && tokens[1] == "host":
+		return m.parseAddKnownHost(tokens[2:])
+
 	case tokens[0] == "no" && len(tokens) >= 2:
 		return m.dispatchNoCommand(tokens)
 
@@ -259,10 +262,12 @@ func (m *MockRouter) dispatchInterfaceCommand(tokens []string) gokeenrestapimode
 	}
 
 	if tokens[2] == "ip" && len(tokens) >= 5 && tokens[3] == "global" && tokens[4] == "auto" {
+		m.setInterfaceGlobal(interfaceID, true)
 		return m.successResponse(fmt.Sprintf("IP global auto enabled for interface %s", interfaceID))
 	}
 
 	if tokens[2] == "no" && len(tokens) >= 5 && tokens[3] == "ip" && tokens[4] == "global" {
+		m.setInterfaceGlobal(interfaceID, false)
 		return m.successResponse(fmt.Sprintf("IP global disabled for interface %s", interfaceID))
 	}
 
@@ -275,6 +280,21 @@ func (m *MockRouter) dispatchIPCommand(tokens []string) gokeenrestapimodels.Pars
 		return m.parseAddRoute(tokens[2:])
 	case "host":
 		return m.parseAddDnsRecord(tokens[2:])
+	case "dhcp":
+		if len(tokens) >= 5 && tokens[2] == "host" {
+			return m.parseSetStaticLease(tokens[3], tokens[4])
+		}
+		return m.errorResponse("Invalid ip dhcp command: expected 'ip dhcp host <mac> <ip>'")
+	case "hotspot":
+		if len(tokens) >= 6 && tokens[2] == "host" && tokens[4] == "policy" {
+			return m.parseSetHostPolicy(tokens[3], tokens[5])
+		}
+		return m.errorResponse("Invalid ip hotspot command: expected 'ip hotspot host <mac> policy <policy>'")
+	case "policy":
+		if len(tokens) >= 3 {
+			return m.parseIpPolicy(tokens[2], tokens[3:])
+		}
+		return m.errorResponse("Invalid ip policy command: expected 'ip policy <policy>'")
 	default:
 		return m.errorResponse(fmt.Sprintf("Unknown ip subcommand: %s", tokens[1]))
 	}
@@ -307,9 +327,12 @@ func (m *MockRouter) dispatchDnsProxyCommand(tokens []string) gokeenrestapimodel
 func (m *MockRouter) dispatchNoCommand(tokens []string) gokeenrestapimodels.ParseResponse {
 	switch tokens[1] {
 	case "interface":
-		if len(tokens) >= 7 && tokens[3] == "wireguard" && tokens[4] == "peer" {
+		if len(tokens) >= 6 && tokens[3] == "wireguard" && tokens[4] == "peer" {
 			return m.parseNoWireguardPeer(tokens[2], tokens[5:])
 		}
+		if len(tokens) == 3 {
+			return m.parseDeleteInterface(tokens[2])
+		}
 		return m.errorResponse("Invalid no interface command")
 
 	case "object-group":
@@ -335,6 +358,21 @@ func (m *MockRouter) dispatchNoCommand(tokens []string) gokeenrestapimodels.Pars
 				return m.parseDeleteRoute(tokens[3:])
 			case "host":
 				return m.parseDeleteDnsRecord(tokens[3:])
+			case "dhcp":
+				if len(tokens) >= 5 && tokens[3] == "host" {
+					return m.parseSetStaticLease(tokens[4], "")
+				}
+				return m.errorResponse("Invalid no ip dhcp command: expected 'no ip dhcp host <mac>'")
+			case "hotspot":
+				if len(tokens) >= 6 && tokens[3] == "host" && tokens[5] == "policy" {
+					return m.parseSetHostPolicy(tokens[4], "")
+				}
+				return m.errorResponse("Invalid no ip hotspot command: expected 'no ip hotspot host <mac> policy'")
+			case "policy":
+				if len(tokens) >= 4 {
+					return m.parseDeleteIpPolicy(tokens[3])
+				}
+				return m.errorResponse("Invalid no ip policy command: expected 'no ip policy <policy>'")
 			default:
 				return m.errorResponse(fmt.Sprintf("Unknown no ip subcommand: %s", tokens[2]))
 			}
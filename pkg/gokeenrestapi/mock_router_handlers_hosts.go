@@ -0,0 +1,109 @@
+package gokeenrestapi
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/noksa/gokeenapi/pkg/gokeenrestapimodels"
+)
+
+// handleDhcpHosts handles GET /rci/ip/dhcp/host requests and returns static DHCP leases.
+func (m *MockRouter) handleDhcpHosts(w http.ResponseWriter, r *http.Request) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	leases := make([]gokeenrestapimodels.RciIpDhcpHost, 0)
+	for _, host := range m.hotspotDevices {
+		if host.StaticIP != "" {
+			leases = append(leases, gokeenrestapimodels.RciIpDhcpHost{Mac: host.Mac, IP: host.StaticIP})
+		}
+	}
+	m.encodeJSON(w, leases)
+}
+
+// handleHotspotHosts handles GET /rci/ip/hotspot/host requests and returns per-device policies.
+func (m *MockRouter) handleHotspotHosts(w http.ResponseWriter, r *http.Request) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	hosts := make([]gokeenrestapimodels.RciIpHotspotHost, 0)
+	for _, host := range m.hotspotDevices {
+		if host.Policy != "" {
+			hosts = append(hosts, gokeenrestapimodels.RciIpHotspotHost{Mac: host.Mac, Access: "permit", Policy: host.Policy})
+		}
+	}
+	m.encodeJSON(w, hosts)
+}
+
+// findHotspotDevice returns the index of the device with the MAC address, or -1. Caller must hold the lock.
+func (m *MockRouter) findHotspotDevice(mac string) int {
+	for i, host := range m.hotspotDevices {
+		if strings.EqualFold(host.Mac, mac) {
+			return i
+		}
+	}
+	return -1
+}
+
+// parseAddKnownHost handles "known host <name> <mac>" commands.
+// Registers the device with the name, creating it if the router has not seen it yet.
+func (m *MockRouter) parseAddKnownHost(tokens []string) gokeenrestapimodels.ParseResponse {
+	mac := strings.Trim(tokens[len(tokens)-1], "\"")
+	name := strings.Trim(strings.Join(tokens[:len(tokens)-1], " "), "\"")
+	if name == "" {
+		return m.errorResponse("Invalid known host command: expected 'known host \"<name>\" <mac>'")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if i := m.findHotspotDevice(mac); i >= 0 {
+		m.hotspotDevices[i].Name = name
+		m.hotspotDevices[i].Registered = true
+		return m.successResponse(fmt.Sprintf("Known host '%s' updated", name))
+	}
+	m.hotspotDevices = append(m.hotspotDevices, MockHost{Name: name, Mac: mac, Registered: true})
+	return m.successResponse(fmt.Sprintf("Known host '%s' added", name))
+}
+
+// parseSetStaticLease handles "ip dhcp host <mac> <ip>" and, with an empty ip, "no ip dhcp host <mac>" commands.
+func (m *MockRouter) parseSetStaticLease(mac, ip string) gokeenrestapimodels.ParseResponse {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	i := m.findHotspotDevice(mac)
+	if i < 0 {
+		if ip == "" {
+			return m.successResponse(fmt.Sprintf("Static lease for '%s' (not found, but command accepted)", mac))
+		}
+		m.hotspotDevices = append(m.hotspotDevices, MockHost{Mac: mac})
+		i = len(m.hotspotDevices) - 1
+	}
+	m.hotspotDevices[i].StaticIP = ip
+	if ip == "" {
+		return m.successResponse(fmt.Sprintf("Static lease for '%s' removed", mac))
+	}
+	return m.successResponse(fmt.Sprintf("Static lease %s assigned to '%s'", ip, mac))
+}
+
+// parseSetHostPolicy handles "ip hotspot host <mac> policy <policy>" and, with an empty policy,
+// "no ip hotspot host <mac> policy" commands.
+func (m *MockRouter) parseSetHostPolicy(mac, policy string) gokeenrestapimodels.ParseResponse {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	i := m.findHotspotDevice(mac)
+	if i < 0 {
+		if policy == "" {
+			return m.successResponse(fmt.Sprintf("Policy for '%s' (not found, but command accepted)", mac))
+		}
+		m.hotspotDevices = append(m.hotspotDevices, MockHost{Mac: mac})
+		i = len(m.hotspotDevices) - 1
+	}
+	m.hotspotDevices[i].Policy = policy
+	if policy == "" {
+		return m.successResponse(fmt.Sprintf("Policy for '%s' removed", mac))
+	}
+	return m.successResponse(fmt.Sprintf("Policy %s assigned to '%s'", policy, mac))
+}
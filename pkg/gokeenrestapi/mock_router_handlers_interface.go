@@ -1,11 +1,16 @@
 package gokeenrestapi
 
 import (
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"net"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/noksa/gokeenapi/pkg/gokeenrestapimodels"
+	"gopkg.in/ini.v1"
 )
 
 func (m *MockRouter) handleInterfaces(w http.ResponseWriter, r *http.Request) {
@@ -24,16 +29,7 @@ func (m *MockRouter) handleInterfaces(w http.ResponseWriter, r *http.Request) {
 		if typeFilter != "" && iface.Type != typeFilter {
 			continue
 		}
-		interfaces[id] = gokeenrestapimodels.RciShowInterface{
-			Id:          iface.ID,
-			Type:        iface.Type,
-			Description: iface.Description,
-			Address:     iface.Address,
-			Connected:   iface.Connected,
-			Link:        iface.Link,
-			State:       iface.State,
-			DefaultGw:   iface.DefaultGw,
-		}
+		interfaces[id] = m.showInterface(iface)
 	}
 	m.encodeJSON(w, interfaces)
 }
@@ -55,6 +51,42 @@ func (m *MockRouter) handleInterface(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	m.encodeJSON(w, m.showInterface(iface))
+}
+
+// handleInterfaceStat serves traffic counters of the interface given by the name query parameter.
+func (m *MockRouter) handleInterfaceStat(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	iface, exists := m.interfaces[r.URL.Query().Get("name")]
+	if !exists {
+		http.Error(w, "Interface not found", http.StatusNotFound)
+		return
+	}
+	if iface.NoStat {
+		http.Error(w, "Statistics not available", http.StatusInternalServerError)
+		return
+	}
+
+	m.encodeJSON(w, gokeenrestapimodels.RciShowInterfaceStat{
+		RxPackets: iface.RxPackets,
+		RxBytes:   iface.RxBytes,
+		RxErrors:  iface.RxErrors,
+		TxPackets: iface.TxPackets,
+		TxBytes:   iface.TxBytes,
+		TxErrors:  iface.TxErrors,
+	})
+}
+
+// showInterface renders the "show interface" view of an interface, WireGuard interfaces
+// get the runtime state of their SC peers. Caller must hold the lock.
+func (m *MockRouter) showInterface(iface *MockInterface) gokeenrestapimodels.RciShowInterface {
 	response := gokeenrestapimodels.RciShowInterface{
 		Id:          iface.ID,
 		Type:        iface.Type,
@@ -64,8 +96,31 @@ func (m *MockRouter) handleInterface(w http.ResponseWriter, r *http.Request) {
 		Link:        iface.Link,
 		State:       iface.State,
 		DefaultGw:   iface.DefaultGw,
+		Global:      iface.Global,
 	}
-	m.encodeJSON(w, response)
+	if iface.Type != InterfaceTypeWireguard {
+		return response
+	}
+
+	wireguard := &gokeenrestapimodels.RciShowInterfaceWireguard{Status: iface.State}
+	if scIface, ok := m.scInterfaces[iface.ID]; ok {
+		for _, peer := range scIface.Wireguard.Peer {
+			runtimePeer := gokeenrestapimodels.RciShowInterfaceWireguardPeer{
+				PublicKey:     peer.Key,
+				Rxbytes:       iface.RxBytes,
+				Txbytes:       iface.TxBytes,
+				LastHandshake: iface.LastHandshake,
+				Online:        iface.State == StateUp && iface.LastHandshake >= 0,
+			}
+			if host, port, err := net.SplitHostPort(peer.Endpoint); err == nil {
+				runtimePeer.Remote = host
+				runtimePeer.RemotePort, _ = strconv.Atoi(port)
+			}
+			wireguard.Peer = append(wireguard.Peer, runtimePeer)
+		}
+	}
+	response.Wireguard = wireguard
+	return response
 }
 
 func (m *MockRouter) handleScInterfaces(w http.ResponseWriter, r *http.Request) {
@@ -118,6 +173,8 @@ func (m *MockRouter) handleHotspot(w http.ResponseWriter, r *http.Request) {
 			Registered: device.Registered,
 			Link:       device.Link,
 			Via:        device.Via,
+			Active:     device.Active,
+			LastSeen:   device.LastSeen,
 		})
 	}
 	m.encodeJSON(w, gokeenrestapimodels.RciShowIpHotspot{Host: hosts})
@@ -154,9 +211,12 @@ func convertScInterface(scIface *MockScInterface) gokeenrestapimodels.RciShowScI
 		IP: gokeenrestapimodels.IP{
 			Address: gokeenrestapimodels.Address{
 				Address: scIface.IP.Address,
+				Mask:    scIface.IP.Mask,
 			},
+			Mtu: scIface.IP.Mtu,
 		},
 		Wireguard: gokeenrestapimodels.Wireguard{
+			PrivateKey: scIface.Wireguard.PrivateKey,
 			Asc: gokeenrestapimodels.Asc{
 				Jc:   scIface.Wireguard.Asc.Jc,
 				Jmin: scIface.Wireguard.Asc.Jmin,
@@ -194,7 +254,11 @@ func (m *MockRouter) parseInterfaceState(interfaceID string, state string) gokee
 		return m.errorResponse(fmt.Sprintf("Interface '%s' does not exist", interfaceID))
 	}
 
-	if state == "up" {
+	if state == "up" && m.peersUnreachable {
+		iface.Connected = StateDisconnected
+		iface.Link = StateDown
+		iface.State = StateUp
+	} else if state == "up" {
 		iface.Connected = StateConnected
 		iface.Link = StateUp
 		iface.State = StateUp
@@ -207,6 +271,16 @@ func (m *MockRouter) parseInterfaceState(interfaceID string, state string) gokee
 	return m.successResponse(fmt.Sprintf("Interface %s set to %s", interfaceID, state))
 }
 
+// setInterfaceGlobal records the global IP routing flag of an existing interface
+func (m *MockRouter) setInterfaceGlobal(interfaceID string, global bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if iface, exists := m.interfaces[interfaceID]; exists {
+		iface.Global = global
+	}
+}
+
 // parseCreateInterface handles "interface <id> create type <type> ..." commands.
 func (m *MockRouter) parseCreateInterface(interfaceID string, tokens []string) gokeenrestapimodels.ParseResponse {
 	if len(tokens) < 2 || tokens[0] != "type" {
@@ -258,6 +332,20 @@ func (m *MockRouter) parseCreateInterface(interfaceID string, tokens []string) g
 	return m.successResponse(fmt.Sprintf("Interface %s created with type %s", interfaceID, interfaceType))
 }
 
+// parseDeleteInterface handles "no interface <id>" commands.
+func (m *MockRouter) parseDeleteInterface(interfaceID string) gokeenrestapimodels.ParseResponse {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, exists := m.interfaces[interfaceID]; !exists {
+		return m.errorResponse(fmt.Sprintf("Interface '%s' does not exist", interfaceID))
+	}
+	delete(m.interfaces, interfaceID)
+	delete(m.scInterfaces, interfaceID)
+
+	return m.successResponse(fmt.Sprintf("Interface %s removed", interfaceID))
+}
+
 // parseDeleteKnownHost handles "no known host <mac>" commands.
 func (m *MockRouter) parseDeleteKnownHost(tokens []string) gokeenrestapimodels.ParseResponse {
 	if len(tokens) < 1 {
@@ -285,3 +373,100 @@ func (m *MockRouter) parseDeleteKnownHost(tokens []string) gokeenrestapimodels.P
 	}
 	return m.successResponse(fmt.Sprintf("Known host with MAC '%s' (not found, but command accepted)", mac))
 }
+
+// handleWireguardImport handles POST /rci/interface/wireguard/import requests.
+// Creates the next free WireguardN interface with the private key, address and peers of the imported .conf file.
+// ASC parameters are not imported, like on the router they are applied afterwards with "wireguard asc" commands.
+func (m *MockRouter) handleWireguardImport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var importData gokeenrestapimodels.Import
+	if err := json.NewDecoder(r.Body).Decode(&importData); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	importError := func(message string) {
+		var created gokeenrestapimodels.CreatedInterface
+		created.Status = append(created.Status, struct {
+			Status  string `json:"status"`
+			Code    string `json:"code"`
+			Ident   string `json:"ident"`
+			Message string `json:"message"`
+		}{Status: StatusError, Code: "1", Ident: "import", Message: message})
+		m.encodeJSON(w, created)
+	}
+
+	content, err := base64.StdEncoding.DecodeString(importData.Import)
+	if err != nil {
+		importError("invalid base64 content")
+		return
+	}
+	conf, err := ini.LoadSources(ini.LoadOptions{AllowNonUniqueSections: true}, content)
+	if err != nil {
+		importError(fmt.Sprintf("invalid conf file: %v", err))
+		return
+	}
+	interfaceSection, err := conf.GetSection("Interface")
+	if err != nil {
+		importError("missing [Interface] section")
+		return
+	}
+	peerSections, err := conf.SectionsByName("Peer")
+	if err != nil {
+		importError("missing [Peer] section")
+		return
+	}
+
+	var peers []MockPeer
+	for _, section := range peerSections {
+		peer := MockPeer{
+			Key:               section.Key("PublicKey").String(),
+			Endpoint:          section.Key("Endpoint").String(),
+			KeepaliveInterval: section.Key("PersistentKeepalive").MustInt(0),
+			PresharedKey:      section.Key("PresharedKey").String(),
+		}
+		for cidr := range strings.SplitSeq(section.Key("AllowedIPs").String(), ",") {
+			if cidr = strings.TrimSpace(cidr); cidr != "" {
+				peer.AllowedIPs = append(peer.AllowedIPs, parseCIDRToAllowedIP(cidr))
+			}
+		}
+		peers = append(peers, peer)
+	}
+
+	address := strings.TrimSpace(strings.Split(interfaceSection.Key("Address").String(), ",")[0])
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	interfaceID := ""
+	for i := 0; interfaceID == ""; i++ {
+		if _, exists := m.interfaces[fmt.Sprintf("Wireguard%d", i)]; !exists {
+			interfaceID = fmt.Sprintf("Wireguard%d", i)
+		}
+	}
+
+	m.interfaces[interfaceID] = &MockInterface{
+		ID:          interfaceID,
+		Type:        InterfaceTypeWireguard,
+		Description: importData.Filename,
+		Address:     address,
+		Connected:   StateDisconnected,
+		Link:        StateDown,
+		State:       StateDown,
+	}
+	m.scInterfaces[interfaceID] = &MockScInterface{
+		Description: importData.Filename,
+		IP:          MockIP{Address: address, Mtu: interfaceSection.Key("MTU").String()},
+		Wireguard: MockWireguard{
+			PrivateKey: interfaceSection.Key("PrivateKey").String(),
+			Asc:        MockAsc{Jc: "0", Jmin: "0", Jmax: "0", S1: "0", S2: "0", H1: "0", H2: "0", H3: "0", H4: "0"},
+			Peer:       peers,
+		},
+	}
+
+	m.encodeJSON(w, gokeenrestapimodels.CreatedInterface{Created: interfaceID})
+}
@@ -0,0 +1,94 @@
+package gokeenrestapi
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/noksa/gokeenapi/pkg/gokeenrestapimodels"
+)
+
+// handleIpPolicies handles GET /rci/ip/policy requests and returns policies keyed by ID.
+func (m *MockRouter) handleIpPolicies(w http.ResponseWriter, r *http.Request) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	response := make(map[string]gokeenrestapimodels.RciIpPolicy, len(m.policies))
+	for _, policy := range m.policies {
+		permits := make([]gokeenrestapimodels.RciIpPolicyPermit, 0, len(policy.Interfaces))
+		for _, interfaceId := range policy.Interfaces {
+			permits = append(permits, gokeenrestapimodels.RciIpPolicyPermit{Interface: interfaceId, Enabled: true})
+		}
+		response[policy.ID] = gokeenrestapimodels.RciIpPolicy{Description: policy.Description, Permit: permits}
+	}
+	m.encodeJSON(w, response)
+}
+
+// findPolicy returns the index of the policy with the ID, or -1. Caller must hold the lock.
+func (m *MockRouter) findPolicy(policyId string) int {
+	for i, policy := range m.policies {
+		if policy.ID == policyId {
+			return i
+		}
+	}
+	return -1
+}
+
+// parseIpPolicy handles "ip policy <policy> [description <text> | permit auto <iface> | no permit auto <iface>]"
+// commands. The policy is created when it does not exist.
+func (m *MockRouter) parseIpPolicy(policyId string, tokens []string) gokeenrestapimodels.ParseResponse {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	i := m.findPolicy(policyId)
+	if i < 0 {
+		m.policies = append(m.policies, MockPolicy{ID: policyId})
+		i = len(m.policies) - 1
+	}
+	policy := &m.policies[i]
+
+	switch {
+	case len(tokens) == 0:
+		return m.successResponse(fmt.Sprintf("Policy %s created", policyId))
+
+	case tokens[0] == "description" && len(tokens) >= 2:
+		policy.Description = strings.Trim(strings.Join(tokens[1:], " "), "\"")
+		return m.successResponse(fmt.Sprintf("Policy %s description set", policyId))
+
+	case len(tokens) >= 3 && tokens[0] == "permit" && tokens[1] == "auto":
+		interfaceId := tokens[2]
+		if _, ok := m.interfaces[interfaceId]; !ok {
+			return m.errorResponse(fmt.Sprintf("Interface %s not found", interfaceId))
+		}
+		if !slices.Contains(policy.Interfaces, interfaceId) {
+			// A new slice keeps the captured initial state intact
+			policy.Interfaces = append(slices.Clone(policy.Interfaces), interfaceId)
+		}
+		return m.successResponse(fmt.Sprintf("Interface %s permitted in policy %s", interfaceId, policyId))
+
+	case len(tokens) >= 4 && tokens[0] == "no" && tokens[1] == "permit" && tokens[2] == "auto":
+		interfaceId := tokens[3]
+		policy.Interfaces = slices.DeleteFunc(slices.Clone(policy.Interfaces), func(id string) bool { return id == interfaceId })
+		return m.successResponse(fmt.Sprintf("Interface %s denied in policy %s", interfaceId, policyId))
+	}
+	return m.errorResponse(fmt.Sprintf("Unknown ip policy command: %s", strings.Join(tokens, " ")))
+}
+
+// parseDeleteIpPolicy handles "no ip policy <policy>" commands and unbinds hosts of the policy.
+func (m *MockRouter) parseDeleteIpPolicy(policyId string) gokeenrestapimodels.ParseResponse {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	i := m.findPolicy(policyId)
+	if i < 0 {
+		return m.successResponse(fmt.Sprintf("Policy %s (not found, but command accepted)", policyId))
+	}
+	m.policies = slices.Delete(slices.Clone(m.policies), i, i+1)
+	for j := range m.hotspotDevices {
+		if m.hotspotDevices[j].Policy == policyId {
+			m.hotspotDevices[j].Policy = ""
+		}
+	}
+	return m.successResponse(fmt.Sprintf("Policy %s removed", policyId))
+}
@@ -217,8 +217,12 @@ func (m *MockRouter) parseWireguardPeer(interfaceID string, tokens []string) gok
 	return m.successResponse(fmt.Sprintf("Wireguard peer %s %s updated for interface %s", publicKey, subcommand, interfaceID))
 }
 
-// parseNoWireguardPeer handles "no interface <id> wireguard peer <key> allow-ips <cidr>" commands.
+// parseNoWireguardPeer handles "no interface <id> wireguard peer <key> allow-ips <cidr>"
+// and "no interface <id> wireguard peer <key>" commands.
 func (m *MockRouter) parseNoWireguardPeer(interfaceID string, tokens []string) gokeenrestapimodels.ParseResponse {
+	if len(tokens) == 1 {
+		return m.parseDeleteWireguardPeer(interfaceID, tokens[0])
+	}
 	if len(tokens) < 3 {
 		return m.errorResponse("Invalid no wireguard peer command: expected '<key> allow-ips <cidr>'")
 	}
@@ -256,6 +260,26 @@ func (m *MockRouter) parseNoWireguardPeer(interfaceID string, tokens []string) g
 	return m.successResponse(fmt.Sprintf("Peer %s not found on interface %s (command accepted)", publicKey, interfaceID))
 }
 
+// parseDeleteWireguardPeer removes the peer with the given public key from the interface.
+func (m *MockRouter) parseDeleteWireguardPeer(interfaceID, publicKey string) gokeenrestapimodels.ParseResponse {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	scIface, exists := m.scInterfaces[interfaceID]
+	if !exists {
+		return m.errorResponse(fmt.Sprintf("SC interface '%s' does not exist", interfaceID))
+	}
+
+	for i := range scIface.Wireguard.Peer {
+		if scIface.Wireguard.Peer[i].Key == publicKey {
+			scIface.Wireguard.Peer = append(scIface.Wireguard.Peer[:i], scIface.Wireguard.Peer[i+1:]...)
+			return m.successResponse(fmt.Sprintf("Peer %s removed from interface %s", publicKey, interfaceID))
+		}
+	}
+
+	return m.errorResponse(fmt.Sprintf("Peer %s not found on interface %s", publicKey, interfaceID))
+}
+
 // parseCIDRToAllowedIP converts a CIDR string to MockAllowedIP (address + dotted mask).
 func parseCIDRToAllowedIP(cidr string) MockAllowedIP {
 	_, ipNet, err := net.ParseCIDR(cidr)
@@ -140,6 +140,43 @@ var _ = Describe("MockRouter Interfaces", func() {
 		})
 	})
 
+	Describe("interface deletion", func() {
+		It("should delete an existing interface", func() {
+			server := NewMockRouterServer()
+			defer server.Close()
+
+			requests := []gokeenrestapimodels.ParseRequest{{Parse: "no interface Wireguard0"}}
+			body, _ := json.Marshal(requests)
+			resp, _ := http.Post(server.URL+"/rci/", "application/json", bytes.NewReader(body))
+			var responses []gokeenrestapimodels.ParseResponse
+			_ = json.NewDecoder(resp.Body).Decode(&responses)
+			_ = resp.Body.Close()
+			Expect(responses[0].Parse.Status[0].Status).To(Equal(StatusOK))
+
+			resp, _ = http.Get(server.URL + "/rci/show/interface")
+			var interfaces map[string]gokeenrestapimodels.RciShowInterface
+			_ = json.NewDecoder(resp.Body).Decode(&interfaces)
+			_ = resp.Body.Close()
+			Expect(interfaces).NotTo(HaveKey("Wireguard0"))
+			Expect(interfaces).To(HaveKey("ISP"))
+		})
+
+		It("should reject deleting a non-existent interface", func() {
+			server := NewMockRouterServer()
+			defer server.Close()
+
+			requests := []gokeenrestapimodels.ParseRequest{{Parse: "no interface Wireguard9"}}
+			body, _ := json.Marshal(requests)
+			resp, _ := http.Post(server.URL+"/rci/", "application/json", bytes.NewReader(body))
+			var responses []gokeenrestapimodels.ParseResponse
+			_ = json.NewDecoder(resp.Body).Decode(&responses)
+			_ = resp.Body.Close()
+
+			Expect(responses[0].Parse.Status[0].Status).To(Equal(StatusError))
+			Expect(responses[0].Parse.Status[0].Message).To(ContainSubstring("does not exist"))
+		})
+	})
+
 	Describe("AWG config", func() {
 		It("should update AWG parameters", func() {
 			server := NewMockRouterServer()
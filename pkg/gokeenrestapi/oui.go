@@ -0,0 +1,111 @@
+package gokeenrestapi
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"slices"
+	"strings"
+)
+
+// OuiDB maps MAC address prefixes assigned by IEEE to vendor names
+type OuiDB struct {
+	vendors map[string]string
+	// lengths are the prefix lengths in hex digits present in vendors, longest first
+	lengths []int
+}
+
+// LoadOuiDB reads an IEEE registry CSV file (oui.csv, mam.csv or oas.csv from
+// standards-oui.ieee.org) with Registry, Assignment and Organization Name columns
+func LoadOuiDB(path string) (*OuiDB, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open OUI database: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	db, err := ParseOuiDB(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OUI database %v: %w", path, err)
+	}
+	return db, nil
+}
+
+// ParseOuiDB parses an IEEE registry CSV. Rows with malformed assignments are skipped
+func ParseOuiDB(r io.Reader) (*OuiDB, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	db := &OuiDB{vendors: make(map[string]string)}
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(record) < 3 {
+			continue
+		}
+		prefix := hexDigits(record[1])
+		vendor := strings.TrimSpace(record[2])
+		if len(prefix) < 6 || len(prefix) > 9 || vendor == "" {
+			// header row and malformed assignments
+			continue
+		}
+		db.vendors[prefix] = vendor
+		if !slices.Contains(db.lengths, len(prefix)) {
+			db.lengths = append(db.lengths, len(prefix))
+		}
+	}
+	if len(db.vendors) == 0 {
+		return nil, errors.New("no assignments found, expected IEEE registry CSV with Registry,Assignment,Organization Name columns")
+	}
+	slices.Sort(db.lengths)
+	slices.Reverse(db.lengths)
+	return db, nil
+}
+
+// Vendor returns the organization the MAC address prefix is assigned to, using the longest
+// matching assignment. Randomized and unknown addresses have no vendor
+func (db *OuiDB) Vendor(mac string) string {
+	if db == nil || IsRandomizedMac(mac) {
+		return ""
+	}
+	hw, err := net.ParseMAC(mac)
+	if err != nil {
+		return ""
+	}
+	digits := hexDigits(hw.String())
+	for _, length := range db.lengths {
+		if vendor, ok := db.vendors[digits[:length]]; ok {
+			return vendor
+		}
+	}
+	return ""
+}
+
+// Len returns the number of assignments in the database
+func (db *OuiDB) Len() int {
+	return len(db.vendors)
+}
+
+// hexDigits returns the uppercase hex digits of s, dropping separators. A non-hex character yields an empty string
+func hexDigits(s string) string {
+	var b strings.Builder
+	for _, r := range strings.TrimSpace(s) {
+		switch {
+		case r >= '0' && r <= '9', r >= 'A' && r <= 'F':
+			b.WriteRune(r)
+		case r >= 'a' && r <= 'f':
+			b.WriteRune(r - 'a' + 'A')
+		case r == ':' || r == '-' || r == '.':
+		default:
+			return ""
+		}
+	}
+	return b.String()
+}
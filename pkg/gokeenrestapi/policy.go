@@ -0,0 +1,244 @@
+package gokeenrestapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/fatih/color"
+	"github.com/noksa/gokeenapi/internal/gokeenlog"
+	"github.com/noksa/gokeenapi/internal/gokeenspinner"
+	"github.com/noksa/gokeenapi/pkg/config"
+	"github.com/noksa/gokeenapi/pkg/gokeenrestapimodels"
+)
+
+var (
+	// Policy provides IP connection policy management for source-based routing of LAN devices
+	Policy keeneticPolicy
+)
+
+type keeneticPolicy struct{}
+
+// Actions of a policy change
+const (
+	PolicyActionCreate = "create"
+	PolicyActionUpdate = "update"
+	PolicyActionDelete = "delete"
+)
+
+// PolicyChange describes what has to be done on the router to bring a policy in line with the config
+type PolicyChange struct {
+	// PolicyID is the policy identifier, e.g. Policy0
+	PolicyID string `json:"policyId"`
+	// Action is one of PolicyActionCreate, PolicyActionUpdate or PolicyActionDelete
+	Action string `json:"action"`
+	// Details are human-readable descriptions of the changed settings
+	Details []string `json:"details,omitempty"`
+	// Commands are the RCI commands applying the change
+	Commands []string `json:"commands"`
+}
+
+// GetPolicies retrieves IP connection policies keyed by policy ID
+func (*keeneticPolicy) GetPolicies() (map[string]gokeenrestapimodels.RciIpPolicy, error) {
+	policies := make(map[string]gokeenrestapimodels.RciIpPolicy)
+	body, err := Common.ExecuteGetSubPath("/rci/ip/policy")
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(body, &policies); err != nil {
+		return nil, fmt.Errorf("failed to read IP connection policies: %w", err)
+	}
+	return policies, nil
+}
+
+// PlanPolicies compares policies from the config with the router and returns changes needed to sync them.
+// Devices are bound to a policy when listed in its hosts or when a host in the inventory refers to it,
+// other devices bound to a configured policy are unbound
+func (*keeneticPolicy) PlanPolicies(policies []config.ConnectionPolicy, hosts []config.KnownHost) ([]PolicyChange, error) {
+	if err := config.ValidateConnectionPolicies(policies, hosts); err != nil {
+		return nil, err
+	}
+	interfaces, err := Interface.GetInterfacesViaRciShowInterfaces(false)
+	if err != nil {
+		return nil, err
+	}
+	for _, policy := range policies {
+		for _, interfaceId := range policy.Interfaces {
+			if _, ok := interfaces[interfaceId]; !ok {
+				return nil, fmt.Errorf("policy %v permits interface '%v' the router doesn't have", policy.ID, interfaceId)
+			}
+		}
+	}
+
+	hotspot, err := Ip.GetAllHotspots()
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool, len(hotspot.Host))
+	for _, host := range hotspot.Host {
+		seen[normalizeMac(host.Mac)] = true
+	}
+	for _, policy := range policies {
+		for _, mac := range policy.Hosts {
+			if !seen[normalizeMac(mac)] {
+				gokeenlog.Infof("⚠️  %s: host %v of policy %v has not been seen by the router yet, it is bound anyway",
+					color.YellowString("WARNING"), color.BlueString(mac), policy.ID)
+			}
+		}
+	}
+
+	existing, hostPolicies, err := fetchPoliciesState()
+	if err != nil {
+		return nil, err
+	}
+	return planPolicies(policies, hosts, existing, hostPolicies), nil
+}
+
+// PlanPolicyDeletion returns changes removing the policies and unbinding their devices.
+// Policies missing on the router are skipped
+func (*keeneticPolicy) PlanPolicyDeletion(policyIds []string) ([]PolicyChange, error) {
+	existing, hostPolicies, err := fetchPoliciesState()
+	if err != nil {
+		return nil, err
+	}
+	return planPolicyDeletion(policyIds, existing, hostPolicies), nil
+}
+
+// ApplyPolicyChanges executes the commands of the changes and saves the configuration
+func (*keeneticPolicy) ApplyPolicyChanges(changes []PolicyChange) error {
+	var parseSlice []gokeenrestapimodels.ParseRequest
+	for _, change := range changes {
+		for _, command := range change.Commands {
+			parseSlice = append(parseSlice, gokeenrestapimodels.ParseRequest{Parse: command})
+		}
+	}
+	if len(parseSlice) == 0 {
+		gokeenlog.Info("Policies are already in sync")
+		return nil
+	}
+	parseSlice = Common.EnsureSaveConfigAtEnd(parseSlice)
+
+	var parseResponse []gokeenrestapimodels.ParseResponse
+	err := gokeenspinner.WrapWithSpinner(fmt.Sprintf("Applying %v policy changes", color.CyanString("%d", len(changes))), func() error {
+		var executeErr error
+		parseResponse, executeErr = Common.ExecutePostParse(parseSlice...)
+		return executeErr
+	})
+	gokeenlog.PrintParseResponse(parseResponse)
+	return err
+}
+
+// fetchPoliciesState reads policies and device bindings from the router
+func fetchPoliciesState() (map[string]gokeenrestapimodels.RciIpPolicy, map[string]string, error) {
+	var existing map[string]gokeenrestapimodels.RciIpPolicy
+	var hostPolicies map[string]string
+	err := gokeenspinner.WrapWithSpinner(fmt.Sprintf("Fetching %v", color.CyanString("connection policies")), func() error {
+		var err error
+		if existing, err = Policy.GetPolicies(); err != nil {
+			return err
+		}
+		hostPolicies, err = Ip.GetHostPolicies()
+		return err
+	})
+	return existing, hostPolicies, err
+}
+
+// planPolicies computes policy changes from the router state in config order
+func planPolicies(desired []config.ConnectionPolicy, hosts []config.KnownHost, existing map[string]gokeenrestapimodels.RciIpPolicy, hostPolicies map[string]string) []PolicyChange {
+	var changes []PolicyChange
+	for _, policy := range desired {
+		change := PolicyChange{PolicyID: policy.ID, Action: PolicyActionUpdate}
+		current, found := existing[policy.ID]
+		if !found {
+			change.Action = PolicyActionCreate
+			change.Commands = append(change.Commands, fmt.Sprintf("ip policy %v", policy.ID))
+		}
+		if policy.Description != "" && current.Description != policy.Description {
+			change.Details = append(change.Details, fmt.Sprintf("description %q", policy.Description))
+			change.Commands = append(change.Commands, fmt.Sprintf("ip policy %v description \"%v\"", policy.ID, policy.Description))
+		}
+
+		var permitted []string
+		for _, permit := range current.Permit {
+			if permit.Enabled {
+				permitted = append(permitted, permit.Interface)
+			}
+		}
+		for _, interfaceId := range policy.Interfaces {
+			if !slices.Contains(permitted, interfaceId) {
+				change.Details = append(change.Details, "permit "+interfaceId)
+				change.Commands = append(change.Commands, fmt.Sprintf("ip policy %v permit auto %v", policy.ID, interfaceId))
+			}
+		}
+		for _, interfaceId := range permitted {
+			if !slices.Contains(policy.Interfaces, interfaceId) {
+				change.Details = append(change.Details, "deny "+interfaceId)
+				change.Commands = append(change.Commands, fmt.Sprintf("ip policy %v no permit auto %v", policy.ID, interfaceId))
+			}
+		}
+
+		bound := policyHosts(policy, hosts)
+		for _, mac := range bound {
+			if hostPolicies[mac] != policy.ID {
+				change.Details = append(change.Details, "bind "+mac)
+				change.Commands = append(change.Commands, fmt.Sprintf("ip hotspot host %v policy %v", mac, policy.ID))
+			}
+		}
+		for _, mac := range hostsBoundTo(policy.ID, hostPolicies) {
+			if !slices.Contains(bound, mac) {
+				change.Details = append(change.Details, "unbind "+mac)
+				change.Commands = append(change.Commands, fmt.Sprintf("no ip hotspot host %v policy", mac))
+			}
+		}
+
+		if len(change.Commands) > 0 {
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
+
+// planPolicyDeletion computes changes removing existing policies, devices are unbound first
+func planPolicyDeletion(policyIds []string, existing map[string]gokeenrestapimodels.RciIpPolicy, hostPolicies map[string]string) []PolicyChange {
+	var changes []PolicyChange
+	for _, policyId := range policyIds {
+		if _, found := existing[policyId]; !found {
+			continue
+		}
+		change := PolicyChange{PolicyID: policyId, Action: PolicyActionDelete}
+		for _, mac := range hostsBoundTo(policyId, hostPolicies) {
+			change.Details = append(change.Details, "unbind "+mac)
+			change.Commands = append(change.Commands, fmt.Sprintf("no ip hotspot host %v policy", mac))
+		}
+		change.Commands = append(change.Commands, fmt.Sprintf("no ip policy %v", policyId))
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+// policyHosts returns normalized MAC addresses of devices bound to the policy by the config
+func policyHosts(policy config.ConnectionPolicy, hosts []config.KnownHost) []string {
+	var macs []string
+	for _, mac := range policy.Hosts {
+		macs = append(macs, normalizeMac(mac))
+	}
+	for _, host := range hosts {
+		if host.Policy == policy.ID && !slices.Contains(macs, normalizeMac(host.Mac)) {
+			macs = append(macs, normalizeMac(host.Mac))
+		}
+	}
+	return macs
+}
+
+// hostsBoundTo returns sorted MAC addresses of devices bound to the policy on the router
+func hostsBoundTo(policyId string, hostPolicies map[string]string) []string {
+	var macs []string
+	for mac, id := range hostPolicies {
+		if id == policyId {
+			macs = append(macs, mac)
+		}
+	}
+	sort.Strings(macs)
+	return macs
+}
@@ -0,0 +1,140 @@
+package gokeenrestapi
+
+import (
+	"net/http/httptest"
+
+	"github.com/noksa/gokeenapi/pkg/config"
+	"github.com/noksa/gokeenapi/pkg/gokeenrestapimodels"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("planPolicies", func() {
+	existing := map[string]gokeenrestapimodels.RciIpPolicy{
+		"Policy0": {Description: "VPN", Permit: []gokeenrestapimodels.RciIpPolicyPermit{
+			{Interface: "Wireguard0", Enabled: true},
+			{Interface: "ISP", Enabled: true},
+		}},
+	}
+	hostPolicies := map[string]string{"aa:bb:cc:dd:ee:01": "Policy0", "aa:bb:cc:dd:ee:09": "Policy0"}
+
+	It("returns no changes when the router is in sync", func() {
+		desired := []config.ConnectionPolicy{{
+			ID: "Policy0", Description: "VPN", Interfaces: []string{"ISP", "Wireguard0"},
+			Hosts: []string{"AA:BB:CC:DD:EE:01"},
+		}}
+		hosts := []config.KnownHost{{Mac: "aa:bb:cc:dd:ee:09", Name: "Laptop", Policy: "Policy0"}}
+		Expect(planPolicies(desired, hosts, existing, hostPolicies)).To(BeEmpty())
+	})
+
+	It("updates permitted interfaces and bindings", func() {
+		desired := []config.ConnectionPolicy{{
+			ID: "Policy0", Description: "TV via VPN", Interfaces: []string{"Wireguard0", "Wireguard1"},
+			Hosts: []string{"aa:bb:cc:dd:ee:02"},
+		}}
+		changes := planPolicies(desired, nil, existing, hostPolicies)
+		Expect(changes).To(HaveLen(1))
+		Expect(changes[0].Action).To(Equal(PolicyActionUpdate))
+		Expect(changes[0].Commands).To(Equal([]string{
+			`ip policy Policy0 description "TV via VPN"`,
+			"ip policy Policy0 permit auto Wireguard1",
+			"ip policy Policy0 no permit auto ISP",
+			"ip hotspot host aa:bb:cc:dd:ee:02 policy Policy0",
+			"no ip hotspot host aa:bb:cc:dd:ee:01 policy",
+			"no ip hotspot host aa:bb:cc:dd:ee:09 policy",
+		}))
+	})
+
+	It("creates missing policies", func() {
+		desired := []config.ConnectionPolicy{{ID: "Policy1", Interfaces: []string{"ISP"}, Hosts: []string{"aa:bb:cc:dd:ee:01"}}}
+		changes := planPolicies(desired, nil, existing, hostPolicies)
+		Expect(changes).To(HaveLen(1))
+		Expect(changes[0].Action).To(Equal(PolicyActionCreate))
+		Expect(changes[0].Commands).To(Equal([]string{
+			"ip policy Policy1",
+			"ip policy Policy1 permit auto ISP",
+			"ip hotspot host aa:bb:cc:dd:ee:01 policy Policy1",
+		}))
+	})
+
+	It("deletes existing policies after unbinding their hosts", func() {
+		changes := planPolicyDeletion([]string{"Policy0", "Policy5"}, existing, hostPolicies)
+		Expect(changes).To(HaveLen(1))
+		Expect(changes[0].Action).To(Equal(PolicyActionDelete))
+		Expect(changes[0].Commands).To(Equal([]string{
+			"no ip hotspot host aa:bb:cc:dd:ee:01 policy",
+			"no ip hotspot host aa:bb:cc:dd:ee:09 policy",
+			"no ip policy Policy0",
+		}))
+	})
+})
+
+var _ = Describe("Policy", func() {
+	var server *httptest.Server
+
+	BeforeEach(func() {
+		server = SetupMockRouterForTest(
+			WithPolicies([]MockPolicy{{ID: "Policy0", Description: "Old", Interfaces: []string{"ISP"}}}),
+			WithHotspotDevices([]MockHost{
+				{Name: "tv", Mac: "aa:bb:cc:dd:ee:01", Registered: true},
+				{Name: "phone", Mac: "aa:bb:cc:dd:ee:02", Registered: true, Policy: "Policy0"},
+			}),
+		)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("reads policies", func() {
+		policies, err := Policy.GetPolicies()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(policies).To(HaveKey("Policy0"))
+		Expect(policies["Policy0"].Description).To(Equal("Old"))
+		Expect(policies["Policy0"].Permit).To(ConsistOf(gokeenrestapimodels.RciIpPolicyPermit{Interface: "ISP", Enabled: true}))
+	})
+
+	It("syncs policies idempotently", func() {
+		policies := []config.ConnectionPolicy{
+			{ID: "Policy0", Description: "VPN", Interfaces: []string{"Wireguard0"}, Hosts: []string{"aa:bb:cc:dd:ee:01"}},
+			{ID: "Policy1", Interfaces: []string{"ISP"}},
+		}
+		changes, err := Policy.PlanPolicies(policies, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(changes).To(HaveLen(2))
+		Expect(Policy.ApplyPolicyChanges(changes)).To(Succeed())
+
+		existing, err := Policy.GetPolicies()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(existing["Policy0"].Description).To(Equal("VPN"))
+		Expect(existing["Policy0"].Permit).To(ConsistOf(gokeenrestapimodels.RciIpPolicyPermit{Interface: "Wireguard0", Enabled: true}))
+		Expect(existing).To(HaveKey("Policy1"))
+
+		hostPolicies, err := Ip.GetHostPolicies()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(hostPolicies).To(Equal(map[string]string{"aa:bb:cc:dd:ee:01": "Policy0"}))
+
+		changes, err = Policy.PlanPolicies(policies, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(changes).To(BeEmpty())
+	})
+
+	It("rejects policies permitting unknown interfaces", func() {
+		_, err := Policy.PlanPolicies([]config.ConnectionPolicy{{ID: "Policy0", Interfaces: []string{"Wireguard9"}}}, nil)
+		Expect(err).To(MatchError(ContainSubstring("permits interface 'Wireguard9' the router doesn't have")))
+	})
+
+	It("deletes policies and unbinds their hosts", func() {
+		changes, err := Policy.PlanPolicyDeletion([]string{"Policy0"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(Policy.ApplyPolicyChanges(changes)).To(Succeed())
+
+		existing, err := Policy.GetPolicies()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(existing).To(BeEmpty())
+
+		hostPolicies, err := Ip.GetHostPolicies()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(hostPolicies).To(BeEmpty())
+	})
+})
@@ -18,6 +18,40 @@ type RciShowInterface struct {
 	State string `json:"state"`
 	// DefaultGw indicates if this interface is the default gateway
 	DefaultGw bool `json:"defaultgw,omitempty"`
+	// Global indicates that the interface takes part in global IP routing ("ip global")
+	Global bool `json:"global,omitempty"`
+	// Wireguard contains the runtime WireGuard state, present for WireGuard interfaces only
+	Wireguard *RciShowInterfaceWireguard `json:"wireguard,omitempty"`
+}
+
+// RciShowInterfaceWireguard represents the runtime state of a WireGuard interface.
+type RciShowInterfaceWireguard struct {
+	// PublicKey is the public key of the interface
+	PublicKey string `json:"public-key"`
+	// ListenPort is the local UDP port
+	ListenPort int `json:"listen-port"`
+	// Status is the tunnel status (e.g., "up", "down")
+	Status string `json:"status"`
+	// Peer contains the runtime state of every peer
+	Peer []RciShowInterfaceWireguardPeer `json:"peer"`
+}
+
+// RciShowInterfaceWireguardPeer represents the runtime state of a WireGuard peer.
+type RciShowInterfaceWireguardPeer struct {
+	// PublicKey is the public key of the peer
+	PublicKey string `json:"public-key"`
+	// Remote is the current IP address of the peer endpoint
+	Remote string `json:"remote"`
+	// RemotePort is the current UDP port of the peer endpoint
+	RemotePort int `json:"remote-port"`
+	// Rxbytes is the number of bytes received from the peer
+	Rxbytes int64 `json:"rxbytes"`
+	// Txbytes is the number of bytes sent to the peer
+	Txbytes int64 `json:"txbytes"`
+	// LastHandshake is the number of seconds since the last handshake, negative if there was none
+	LastHandshake int64 `json:"last-handshake"`
+	// Online indicates if the peer is reachable
+	Online bool `json:"online"`
 }
 
 // Import represents an interface import configuration.
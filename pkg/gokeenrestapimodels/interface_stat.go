@@ -0,0 +1,30 @@
+package gokeenrestapimodels
+
+// RciShowInterfaceStat represents traffic counters of an interface from /rci/show/interface/stat endpoint.
+// Counters are cumulative since the interface was brought up and reset when it restarts.
+type RciShowInterfaceStat struct {
+	// RxPackets is the number of received packets
+	RxPackets int64 `json:"rxpackets"`
+	// RxMulticastPackets is the number of received multicast packets
+	RxMulticastPackets int64 `json:"rx-multicast-packets"`
+	// RxBroadcastPackets is the number of received broadcast packets
+	RxBroadcastPackets int64 `json:"rx-broadcast-packets"`
+	// RxBytes is the number of received bytes
+	RxBytes int64 `json:"rxbytes"`
+	// RxErrors is the number of receive errors
+	RxErrors int64 `json:"rxerrors"`
+	// RxDropped is the number of dropped incoming packets
+	RxDropped int64 `json:"rxdropped"`
+	// TxPackets is the number of sent packets
+	TxPackets int64 `json:"txpackets"`
+	// TxMulticastPackets is the number of sent multicast packets
+	TxMulticastPackets int64 `json:"tx-multicast-packets"`
+	// TxBroadcastPackets is the number of sent broadcast packets
+	TxBroadcastPackets int64 `json:"tx-broadcast-packets"`
+	// TxBytes is the number of sent bytes
+	TxBytes int64 `json:"txbytes"`
+	// TxErrors is the number of send errors
+	TxErrors int64 `json:"txerrors"`
+	// TxDropped is the number of dropped outgoing packets
+	TxDropped int64 `json:"txdropped"`
+}
@@ -0,0 +1,18 @@
+package gokeenrestapimodels
+
+// RciIpPolicy represents an IP connection policy from /rci/ip/policy endpoint.
+// The endpoint returns a map keyed by policy ID (e.g., "Policy0").
+type RciIpPolicy struct {
+	// Description is the policy name shown in the web interface
+	Description string `json:"description,omitempty"`
+	// Permit lists interfaces the policy allows in priority order
+	Permit []RciIpPolicyPermit `json:"permit,omitempty"`
+}
+
+// RciIpPolicyPermit represents an interface permitted by an IP connection policy.
+type RciIpPolicyPermit struct {
+	// Interface is the permitted interface ID
+	Interface string `json:"interface"`
+	// Enabled indicates if the interface is enabled in the policy
+	Enabled bool `json:"enabled"`
+}
@@ -0,0 +1,19 @@
+package gokeenrestapimodels
+
+// RciIpDhcpHost represents a static DHCP lease from /rci/ip/dhcp/host endpoint.
+type RciIpDhcpHost struct {
+	// Mac is the MAC address of the device
+	Mac string `json:"mac"`
+	// IP is the address leased to the device
+	IP string `json:"ip"`
+}
+
+// RciIpHotspotHost represents per-device hotspot settings from /rci/ip/hotspot/host endpoint.
+type RciIpHotspotHost struct {
+	// Mac is the MAC address of the device
+	Mac string `json:"mac"`
+	// Access is the access mode of the device (e.g., "permit", "deny")
+	Access string `json:"access,omitempty"`
+	// Policy is the IP connection policy assigned to the device (e.g., "Policy0")
+	Policy string `json:"policy,omitempty"`
+}
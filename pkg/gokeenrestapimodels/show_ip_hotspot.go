@@ -24,4 +24,10 @@ type Host struct {
 	Registered bool `json:"registered,omitempty"`
 	// Link indicates the connection status
 	Link string `json:"link,omitempty"`
+	// Active indicates if the device is currently online
+	Active bool `json:"active,omitempty"`
+	// FirstSeen is the number of seconds since the device was first seen
+	FirstSeen int64 `json:"first-seen,omitempty"`
+	// LastSeen is the number of seconds since the device was last seen
+	LastSeen int64 `json:"last-seen,omitempty"`
 }
@@ -93,6 +93,8 @@ type Peer struct {
 
 // Wireguard contains WireGuard-specific interface configuration.
 type Wireguard struct {
+	// PrivateKey is the interface private key, empty when the firmware does not expose it
+	PrivateKey string `json:"private-key,omitempty"`
 	// Asc contains AmneziaWG obfuscation parameters
 	Asc Asc `json:"asc"`
 	// Peer contains the list of WireGuard peers